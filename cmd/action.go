@@ -0,0 +1,203 @@
+// Package cmd provides command-line interface commands for gh-issue-dependency.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+// actionCmd represents the action command
+var actionCmd = &cobra.Command{
+	Use:   "action",
+	Short: "React to an issues event inside a GitHub Actions workflow",
+	Long: `React to an issue closing or reopening, driven by the GitHub Actions event payload.
+
+The event is read from the file named by GITHUB_EVENT_PATH, and the repository
+from GITHUB_REPOSITORY. For closed and reopened issues the enabled reactions
+run in turn:
+
+  --sync-labels   Add or remove the blocked label across the repository
+  --notify        Comment on the issues the closed issue was blocking
+  --project       Update the blocked field on a GitHub Project
+
+Each outcome is reported as a ::notice or ::error workflow annotation, and a
+Markdown summary is appended to GITHUB_STEP_SUMMARY. Other events, such as an
+issue being edited, do nothing. The command exits non-zero when any reaction
+fails, after running the others.
+
+LOCAL TESTING
+Pass a saved event payload with --event and the repository with --repo. The
+summary is written to the terminal when GITHUB_STEP_SUMMARY is not set. Add
+--dry-run to see what would change without changing it.
+
+FLAGS
+  --event file              Event payload (default $GITHUB_EVENT_PATH)
+  --sync-labels             Keep the blocked label in step with open blockers
+  --label string            Label for --sync-labels (default "blocked")
+  --color string            Color if the label has to be created (default "b60205")
+  --notify                  Comment on dependents when an issue closes
+  --comment-template file   Read the --notify comment template from a file
+  --project string          Project as owner/number or project URL
+  --field string            Project field to update (default "Status")
+  --blocked-value string    Project field value for blocked issues (default "Blocked")
+  --dry-run                 Show the changes without making them`,
+	Example: `  # In a workflow step triggered on issues: [closed, reopened]
+  gh issue-dependency action --sync-labels --notify --project my-org/5
+
+  # Try it locally against a saved event payload
+  gh issue-dependency action --event event.json --repo owner/repo --notify --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !actionSyncLabels && !actionNotify && actionProject == "" {
+			return pkg.NewAppError(
+				pkg.ErrorTypeValidation,
+				"No reactions enabled",
+				nil,
+			).WithSuggestion("Enable at least one of --sync-labels, --notify, or --project")
+		}
+
+		opts := pkg.ActionOptions{
+			SyncLabels:   actionSyncLabels,
+			Label:        actionLabel,
+			Color:        actionColor,
+			Notify:       actionNotify,
+			Field:        actionField,
+			BlockedValue: actionBlockedValue,
+			DryRun:       actionDryRun,
+		}
+		if actionSyncLabels {
+			if _, err := pkg.NormalizeLabelColor(actionColor); err != nil {
+				return err
+			}
+		}
+		if actionProject != "" {
+			project, err := pkg.ParseProjectRef(actionProject)
+			if err != nil {
+				return err
+			}
+			opts.Project = &project
+		}
+		tmpl, err := readCommentTemplate(actionCommentTemplate)
+		if err != nil {
+			return err
+		}
+		opts.CommentTemplate = tmpl
+
+		eventPath := actionEvent
+		if eventPath == "" {
+			eventPath = os.Getenv(pkg.EnvGitHubEventPath)
+		}
+		event, err := pkg.ReadActionEvent(eventPath)
+		if err != nil {
+			return err
+		}
+
+		opts.Owner, opts.Repo, err = resolveActionRepository(event)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		out := cmd.OutOrStdout()
+		result := pkg.NewActionRunner(opts, out).Run(ctx, event)
+		if err := writeActionSummary(out, os.Getenv(pkg.EnvGitHubStepSummary), result); err != nil {
+			return err
+		}
+
+		if result.Failed() {
+			return pkg.NewAppError(
+				pkg.ErrorTypeAPI,
+				fmt.Sprintf("Some reactions to %s %s failed", result.Issue, result.Event),
+				nil,
+			).WithSuggestion("See the error annotations above; re-running the workflow retries them")
+		}
+		return nil
+	},
+}
+
+// Flags for action command
+var (
+	// actionEvent is the event payload file, overriding GITHUB_EVENT_PATH
+	actionEvent string
+
+	// actionSyncLabels enables the label sync reaction
+	actionSyncLabels bool
+
+	// actionLabel is the label kept in step with open blockers
+	actionLabel string
+
+	// actionColor is the color used when the label has to be created
+	actionColor string
+
+	// actionNotify enables comments on dependents of closed issues
+	actionNotify bool
+
+	// actionCommentTemplate is the path of a file holding the comment template
+	actionCommentTemplate string
+
+	// actionProject enables project sync on this project
+	actionProject string
+
+	// actionField is the project field to update
+	actionField string
+
+	// actionBlockedValue is the project field value given to blocked issues
+	actionBlockedValue string
+
+	// actionDryRun reports the changes without making them
+	actionDryRun bool
+)
+
+// resolveActionRepository returns the repository to act on: the --repo flag,
+// then GITHUB_REPOSITORY, then the repository in the event payload
+func resolveActionRepository(event *pkg.ActionEvent) (owner, repo string, err error) {
+	switch {
+	case repoFlag != "":
+		return pkg.ParseRepoFlag(repoFlag)
+	case os.Getenv(pkg.EnvGitHubRepository) != "":
+		return pkg.ParseRepoFlag(os.Getenv(pkg.EnvGitHubRepository))
+	case event.Repository != nil && event.Repository.FullName != "":
+		return pkg.ParseRepoFlag(event.Repository.FullName)
+	}
+
+	return "", "", pkg.NewAppError(
+		pkg.ErrorTypeValidation,
+		"Cannot tell which repository the event is for",
+		nil,
+	).WithSuggestion(fmt.Sprintf("Set %s or pass --repo owner/repo", pkg.EnvGitHubRepository))
+}
+
+// writeActionSummary appends the step summary to summaryPath, or writes it to
+// out when running outside Actions
+func writeActionSummary(out io.Writer, summaryPath string, result *pkg.ActionResult) error {
+	if summaryPath != "" {
+		return pkg.AppendStepSummary(summaryPath, result)
+	}
+
+	fmt.Fprintln(out)
+	return pkg.FormatActionSummary(out, result)
+}
+
+// init registers the action command with the root command and sets up its flags.
+func init() {
+	rootCmd.AddCommand(actionCmd)
+
+	actionCmd.Flags().StringVar(&actionEvent, "event", "", "Event payload (default $GITHUB_EVENT_PATH)")
+	actionCmd.Flags().BoolVar(&actionSyncLabels, "sync-labels", false, "Keep the blocked label in step with open blockers")
+	actionCmd.Flags().StringVar(&actionLabel, "label", pkg.DefaultBlockedLabel, "Label for --sync-labels")
+	actionCmd.Flags().StringVar(&actionColor, "color", pkg.DefaultBlockedLabelColor, "Color if the label has to be created")
+	actionCmd.Flags().BoolVar(&actionNotify, "notify", false, "Comment on dependents when an issue closes")
+	actionCmd.Flags().StringVar(&actionCommentTemplate, "comment-template", "", "Read the --notify comment template from a file")
+	actionCmd.Flags().StringVar(&actionProject, "project", "", "Project as owner/number or project URL")
+	actionCmd.Flags().StringVar(&actionField, "field", "Status", "Project field to update")
+	actionCmd.Flags().StringVar(&actionBlockedValue, "blocked-value", "Blocked", "Project field value for blocked issues")
+	actionCmd.Flags().BoolVar(&actionDryRun, "dry-run", false, "Show the changes without making them")
+}
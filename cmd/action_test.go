@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+func TestActionCommandValidation(t *testing.T) {
+	originalEvent, originalNotify, originalProject := actionEvent, actionNotify, actionProject
+	defer func() { actionEvent, actionNotify, actionProject = originalEvent, originalNotify, originalProject }()
+	t.Setenv(pkg.EnvGitHubEventPath, "")
+
+	noIssue := filepath.Join(t.TempDir(), "event.json")
+	require.NoError(t, os.WriteFile(noIssue, []byte(`{"action": "published"}`), 0600))
+
+	tests := []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{"no reactions", []string{}, "No reactions enabled"},
+		{"invalid project", []string{"--project", "org"}, "Invalid project: org"},
+		{"no event", []string{"--notify"}, "No event payload"},
+		{"event without issue", []string{"--notify", "--event", noIssue}, "Event payload has no issue"},
+		{"positional argument", []string{"extra"}, "unknown command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "action", Args: actionCmd.Args, RunE: actionCmd.RunE}
+			cmd.Flags().StringVar(&actionEvent, "event", "", "")
+			cmd.Flags().BoolVar(&actionNotify, "notify", false, "")
+			cmd.Flags().StringVar(&actionProject, "project", "", "")
+			cmd.SetArgs(tt.args)
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestResolveActionRepository(t *testing.T) {
+	originalRepo := repoFlag
+	defer func() { repoFlag = originalRepo }()
+
+	event := &pkg.ActionEvent{Repository: &pkg.ActionEventRepository{FullName: "event/repo"}}
+
+	repoFlag = ""
+	t.Setenv(pkg.EnvGitHubRepository, "")
+	owner, repo, err := resolveActionRepository(event)
+	require.NoError(t, err)
+	assert.Equal(t, "event/repo", owner+"/"+repo)
+
+	t.Setenv(pkg.EnvGitHubRepository, "env/repo")
+	owner, repo, err = resolveActionRepository(event)
+	require.NoError(t, err)
+	assert.Equal(t, "env/repo", owner+"/"+repo)
+
+	repoFlag = "flag/repo"
+	owner, repo, err = resolveActionRepository(event)
+	require.NoError(t, err)
+	assert.Equal(t, "flag/repo", owner+"/"+repo)
+
+	repoFlag = ""
+	t.Setenv(pkg.EnvGitHubRepository, "")
+	_, _, err = resolveActionRepository(&pkg.ActionEvent{})
+	assert.Error(t, err)
+}
+
+func TestWriteActionSummary(t *testing.T) {
+	result := &pkg.ActionResult{Event: "edited", Issue: "org/app#42"}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeActionSummary(&buf, "", result))
+	assert.Equal(t, "\n## Issue dependencies: org/app#42 edited\n\nNothing to do for edited events.\n", buf.String())
+
+	buf.Reset()
+	path := filepath.Join(t.TempDir(), "summary.md")
+	require.NoError(t, writeActionSummary(&buf, path, result))
+	assert.Empty(t, buf.String())
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "## Issue dependencies")
+}
@@ -11,7 +11,7 @@ import (
 
 // addCmd represents the add command
 var addCmd = &cobra.Command{
-	Use:   "add <issue-number>",
+	Use:   "add [<issue-number>]",
 	Short: "Add dependency relationships between issues",
 	Long: `Add a dependency relationship between two issues using GitHub's native dependency API.
 
@@ -24,6 +24,16 @@ You must specify exactly one of the following relationship types:
   --blocks       The specified issue blocks other issues
                  (this issue must be completed before those issues)
 
+BULK CHANGES WITH --query
+Instead of an issue number, --query selects every issue matching a GitHub search
+query. The matching issues are listed for confirmation, then the relationship is
+created for each of them in parallel with a per-issue result summary.
+
+ATOMIC BATCHES
+By default a batch keeps every relationship that was created even if others
+fail. With --atomic the relationships are created one at a time, and on the
+first failure every relationship already created by the batch is removed again.
+
 ISSUE REFERENCES
 Issues can be referenced in multiple ways:
   • Simple number: 123 (same repository)
@@ -38,7 +48,11 @@ The command validates that:
 
 FLAGS
   --blocked-by string   Issue number(s) that block this issue (comma-separated)
-  --blocks string       Issue number(s) that this issue blocks (comma-separated)`,
+  --blocks string       Issue number(s) that this issue blocks (comma-separated)
+  --query string        Apply to every issue matching a GitHub search query
+  --dry-run             Show what would be added without making changes
+  --force               Skip confirmation prompts
+  --atomic              Roll back the whole batch if any addition fails`,
 	Example: `  # Make issue #123 depend on issue #456
   gh issue-dependency add 123 --blocked-by 456
 
@@ -52,17 +66,30 @@ FLAGS
   gh issue-dependency add 123 --blocked-by 456,789,101
 
   # Work with issues in a different repository
-  gh issue-dependency add 123 --blocks 456 --repo owner/other-repo`,
-	Args: cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		issueNumber := args[0]
+  gh issue-dependency add 123 --blocks 456 --repo owner/other-repo
 
-		// Parse and validate the main issue number
-		_, issueNum, err := pkg.ParseIssueReference(issueNumber)
-		if err != nil {
+  # Make every open area:api issue blocked by #500
+  gh issue-dependency add --blocked-by 500 --query 'repo:org/app label:area:api is:open'
+
+  # Add several blockers, all or nothing
+  gh issue-dependency add 123 --blocked-by 456,789 --atomic`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// The source issue comes either from the argument or from --query
+		if err := validateSourceSelection(args, addQuery); err != nil {
 			return err
 		}
 
+		issueNumber := ""
+		if len(args) == 1 {
+			issueNumber = args[0]
+
+			// Parse and validate the main issue number
+			if _, _, err := pkg.ParseIssueReference(issueNumber); err != nil {
+				return err
+			}
+		}
+
 		// Validate that exactly one of --blocked-by or --blocks is specified
 		if addBlockedBy == "" && addBlocks == "" {
 			return pkg.NewAppError(
@@ -82,37 +109,58 @@ FLAGS
 		}
 
 		// Parse dependency issue references
-		var dependencyRefs []string
-		if addBlockedBy != "" {
-			dependencyRefs = strings.Split(addBlockedBy, ",")
-		} else {
-			dependencyRefs = strings.Split(addBlocks, ",")
+		dependencyRefs := addBlockedBy
+		relationType := "blocked-by"
+		if addBlocks != "" {
+			dependencyRefs = addBlocks
+			relationType = "blocks"
 		}
 
 		// Validate all dependency references
-		for _, ref := range dependencyRefs {
-			ref = strings.TrimSpace(ref)
-			if ref == "" {
-				continue
-			}
-			_, _, err := pkg.ParseIssueReference(ref)
-			if err != nil {
-				return err
-			}
+		if err := validateDependencyRefs(dependencyRefs); err != nil {
+			return err
 		}
 
-		// TODO: Implement add functionality
-		if addBlockedBy != "" {
-			return pkg.WrapInternalError(
-				"adding blocked-by dependencies",
-				fmt.Errorf("add command not implemented yet: issue %d blocked by %s", issueNum, addBlockedBy),
-			).WithSuggestion("This feature is currently under development")
+		// Resolve repository context for unqualified issue numbers
+		var owner, repo string
+		var err error
+		if addQuery != "" {
+			owner, repo, err = resolveQueryRepository(addQuery)
 		} else {
-			return pkg.WrapInternalError(
-				"adding blocks dependencies",
-				fmt.Errorf("add command not implemented yet: issue %d blocks %s", issueNum, addBlocks),
-			).WithSuggestion("This feature is currently under development")
+			owner, repo, err = pkg.ResolveRepository(repoFlag, issueNumber)
+		}
+		if err != nil {
+			return err
+		}
+
+		targets, err := parseDependencyTargets(dependencyRefs, owner, repo)
+		if err != nil {
+			return err
 		}
+
+		adder, err := pkg.NewDependencyAdder()
+		if err != nil {
+			return err
+		}
+
+		if addQuery != "" {
+			return adder.AddForQuery(addQuery, targets, relationType, pkg.BulkOptions{
+				DryRun: addDryRun,
+				Force:  addForce,
+				Atomic: addAtomic,
+			})
+		}
+
+		source, err := pkg.ParseIssueRefWithRepo(issueNumber, owner, repo)
+		if err != nil {
+			return err
+		}
+
+		return adder.AddBatchRelationships(source, targets, relationType, pkg.AddOptions{
+			DryRun: addDryRun,
+			Force:  addForce,
+			Atomic: addAtomic,
+		})
 	},
 }
 
@@ -125,8 +173,105 @@ var (
 	// addBlocks contains a comma-separated list of issue references that are blocked
 	// by the target issue. The target must be completed before these can be worked on.
 	addBlocks string
+
+	// addQuery contains a GitHub search query selecting the issues to modify.
+	// Every matching issue becomes a source issue for the relationship.
+	addQuery string
+
+	// addDryRun indicates whether to show what would be added without making changes
+	addDryRun bool
+
+	// addForce indicates whether to skip confirmation prompts for query-driven additions
+	addForce bool
+
+	// addAtomic indicates whether a failed addition rolls back the rest of the batch
+	addAtomic bool
 )
 
+// validateSourceSelection ensures exactly one of an issue argument or --query was given
+func validateSourceSelection(args []string, query string) error {
+	if len(args) == 0 && query == "" {
+		return pkg.NewAppError(
+			pkg.ErrorTypeValidation,
+			"Must specify an issue number or --query",
+			nil,
+		).WithSuggestion("Pass the issue number as an argument (e.g., 123)").
+			WithSuggestion("Use --query to select issues with a GitHub search query")
+	}
+
+	if len(args) > 0 && query != "" {
+		return pkg.NewAppError(
+			pkg.ErrorTypeValidation,
+			"Cannot specify both an issue number and --query",
+			nil,
+		).WithSuggestion("Use either an issue number or --query, not both")
+	}
+
+	return nil
+}
+
+// validateDependencyRefs checks the syntax of a comma-separated list of issue references
+func validateDependencyRefs(refs string) error {
+	for _, ref := range strings.Split(refs, ",") {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		if _, _, err := pkg.ParseIssueReference(ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseDependencyTargets converts a comma-separated list of issue references into
+// IssueRefs, qualifying plain numbers with the given repository.
+func parseDependencyTargets(refs, owner, repo string) ([]pkg.IssueRef, error) {
+	var targets []pkg.IssueRef
+	for _, ref := range strings.Split(refs, ",") {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		target, err := pkg.ParseIssueRefWithRepo(ref, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		if target.FullName == "" {
+			target.FullName = fmt.Sprintf("%s/%s", target.Owner, target.Repo)
+		}
+		targets = append(targets, target)
+	}
+
+	if len(targets) == 0 {
+		return nil, pkg.NewEmptyValueError("dependency references")
+	}
+
+	return targets, nil
+}
+
+// resolveQueryRepository returns the repository of unqualified target issue
+// numbers under --query, where there is no issue argument to take it from:
+// --repo, then the query's repo: qualifier, then the current repository
+func resolveQueryRepository(query string) (string, string, error) {
+	if repoFlag == "" {
+		if repository := pkg.QueryRepository(query); repository != "" {
+			return pkg.ResolveRepository(repository, "")
+		}
+	}
+
+	owner, repo, err := pkg.ResolveRepository(repoFlag, "")
+	if err != nil && repoFlag == "" {
+		return "", "", pkg.NewAppError(
+			pkg.ErrorTypeRepository,
+			"Cannot tell which repository the target issues are in",
+			err,
+		).WithContext("query", query).
+			WithSuggestion("Pass --repo OWNER/REPO, or add a repo:OWNER/REPO qualifier to the query")
+	}
+	return owner, repo, err
+}
+
 // init registers the add command with the root command and sets up its flags.
 func init() {
 	rootCmd.AddCommand(addCmd)
@@ -135,4 +280,8 @@ func init() {
 	// Note: These flags are mutually exclusive - validation happens in the command logic
 	addCmd.Flags().StringVar(&addBlockedBy, "blocked-by", "", "Issue number(s) that block this issue (comma-separated)")
 	addCmd.Flags().StringVar(&addBlocks, "blocks", "", "Issue number(s) that this issue blocks (comma-separated)")
+	addCmd.Flags().StringVar(&addQuery, "query", "", "Apply the relationship to every issue matching a GitHub search query")
+	addCmd.Flags().BoolVar(&addDryRun, "dry-run", false, "Show what would be added without making changes")
+	addCmd.Flags().BoolVar(&addForce, "force", false, "Skip confirmation prompts")
+	addCmd.Flags().BoolVar(&addAtomic, "atomic", false, "Roll back the whole batch if any addition fails")
 }
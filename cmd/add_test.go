@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+func TestValidateSourceSelection(t *testing.T) {
+	assert.NoError(t, validateSourceSelection([]string{"123"}, ""))
+	assert.NoError(t, validateSourceSelection(nil, "repo:org/app is:open"))
+
+	err := validateSourceSelection(nil, "")
+	require.Error(t, err)
+	assert.True(t, pkg.IsErrorType(err, pkg.ErrorTypeValidation))
+
+	err = validateSourceSelection([]string{"123"}, "repo:org/app")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Cannot specify both")
+}
+
+func TestParseDependencyTargets(t *testing.T) {
+	targets, err := parseDependencyTargets("500, other/repo#7,,", "org", "app")
+	require.NoError(t, err)
+	require.Len(t, targets, 2)
+	assert.Equal(t, "org/app#500", targets[0].String())
+	assert.Equal(t, "org/app", targets[0].FullName)
+	assert.Equal(t, "other/repo#7", targets[1].String())
+
+	_, err = parseDependencyTargets(" , ", "org", "app")
+	assert.Error(t, err)
+
+	_, err = parseDependencyTargets("abc", "org", "app")
+	assert.Error(t, err)
+}
+
+func TestResolveQueryRepositoryOutsideRepository(t *testing.T) {
+	originalRepo := repoFlag
+	defer func() { repoFlag = originalRepo }()
+	repoFlag = ""
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	_, _, err = resolveQueryRepository("label:bug is:open")
+	require.Error(t, err)
+	assert.True(t, pkg.IsErrorType(err, pkg.ErrorTypeRepository))
+	assert.Contains(t, err.Error(), "Cannot tell which repository the target issues are in")
+}
+
+func TestAddCommandValidation(t *testing.T) {
+	original := []string{addBlockedBy, addBlocks, addQuery}
+	defer func() {
+		addBlockedBy, addBlocks, addQuery = original[0], original[1], original[2]
+	}()
+
+	tests := []struct {
+		name          string
+		args          []string
+		blockedBy     string
+		blocks        string
+		query         string
+		errorContains string
+	}{
+		{"no issue or query", nil, "500", "", "", "Must specify an issue number or --query"},
+		{"issue and query", []string{"12"}, "500", "", "is:open", "Cannot specify both an issue number and --query"},
+		{"no relationship", []string{"12"}, "", "", "", "Must specify either --blocked-by or --blocks"},
+		{"both relationships", nil, "500", "501", "is:open", "Cannot specify both --blocked-by and --blocks"},
+		{"invalid reference", nil, "abc", "", "is:open", "Invalid issue number format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addBlockedBy, addBlocks, addQuery = tt.blockedBy, tt.blocks, tt.query
+
+			cmd := &cobra.Command{Use: "add", Args: addCmd.Args, RunE: addCmd.RunE}
+			cmd.SetArgs(tt.args)
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
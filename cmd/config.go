@@ -0,0 +1,173 @@
+// Package cmd provides command-line interface commands for gh-issue-dependency.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show and change settings and aliases",
+	Long: `Show and change the settings that supply flag defaults, and the aliases.
+
+Settings are read from, in increasing precedence:
+
+  1. Built-in defaults
+  2. ~/.config/gh-issue-dependency/config.yml (the user file)
+  3. .github/issue-dependency.yml in the current repository (the repo file)
+  4. GH_ISSUE_DEPENDENCY_<KEY> environment variables, e.g. GH_ISSUE_DEPENDENCY_FORMAT
+
+Flags given on the command line override them all.
+
+SETTINGS
+  format        Default --format for list: table, json, csv, markdown
+  sort          Default --sort for list, e.g. state,-updated
+  state         Default --state for list: all, open, closed
+  cache_ttl     How long dependency data is cached, e.g. 10m; 0 disables the cache
+  concurrency   API requests made in parallel by batch and status commands (1-20)
+  color         Colored terminal output: auto, always, never
+  label         Default --label for sync-labels and action
+  label_color   Default --color for sync-labels and action
+
+ALIASES
+Aliases are set as aliases.<name> and expand to a command line, so with
+"deps: list --state open --sort state" running 'gh issue-dependency deps 123'
+runs 'gh issue-dependency list --state open --sort state 123'. Aliases cannot
+replace built-in commands.`,
+	Example: `  # Show every setting and where its value comes from
+  gh issue-dependency config list
+
+  # Make list show JSON by default
+  gh issue-dependency config set format json
+
+  # Use a different blocked label in this repository only
+  gh issue-dependency config set label "status: blocked" --local
+
+  # Add an alias
+  gh issue-dependency config set aliases.deps "list --state open --sort state"`,
+}
+
+// configGetCmd represents the config get command
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the value of a setting or alias",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfigForCommand()
+		if err != nil {
+			return err
+		}
+
+		value, err := cfg.Get(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), value.Value)
+		return nil
+	},
+}
+
+// configSetCmd represents the config set command
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Save a setting or alias; an empty value removes it",
+	Long: `Save a setting or alias in the user file, or with --local in the repository file.
+
+An empty value removes the key from the file, so the next source down applies.
+Comments and other entries in the file are kept.
+
+FLAGS
+  --local   Save in .github/issue-dependency.yml of the current repository`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, value := args[0], args[1]
+
+		if name, ok := strings.CutPrefix(key, "aliases."); ok && value != "" {
+			if found, _, err := rootCmd.Find([]string{name}); err == nil && found != rootCmd {
+				return pkg.NewAppError(
+					pkg.ErrorTypeValidation,
+					fmt.Sprintf("Alias %q would replace the built-in %s command", name, found.Name()),
+					nil,
+				).WithSuggestion("Choose a different alias name")
+			}
+		}
+
+		path := pkg.UserConfigPath()
+		if configSetLocal {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return pkg.WrapInternalError("finding the current directory", err)
+			}
+			path = pkg.FindRepoConfigPath(cwd)
+			if path == "" {
+				return pkg.NewAppError(
+					pkg.ErrorTypeValidation,
+					"Not inside a git repository",
+					nil,
+				).WithSuggestion("Run the command inside the repository, or drop --local to change the user file")
+			}
+		}
+
+		if err := pkg.SetConfigValue(path, key, value); err != nil {
+			return err
+		}
+		if value == "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed %s from %s\n", key, path)
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "Set %s to %q in %s\n", key, value, path)
+		}
+		return nil
+	},
+}
+
+// configListCmd represents the config list command
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show every setting and alias with its source",
+	Long: `Show the effective value of every setting and alias.
+
+OUTPUT
+  KEY      Setting name, or aliases.<name>
+  VALUE    Effective value
+  SOURCE   default, user, repo, or env`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfigForCommand()
+		if err != nil {
+			return err
+		}
+		return pkg.FormatConfigValues(cmd.OutOrStdout(), cfg.Values())
+	},
+}
+
+// Flags for config command
+var (
+	// configSetLocal saves to the repository file instead of the user file
+	configSetLocal bool
+)
+
+// loadConfigForCommand returns the settings loaded before the command ran,
+// reading them again when Execute did not load them
+func loadConfigForCommand() (*pkg.Config, error) {
+	if configErr != nil {
+		return nil, configErr
+	}
+	if config != nil {
+		return config, nil
+	}
+	return pkg.LoadConfig()
+}
+
+// init registers the config command with the root command and sets up its flags.
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configGetCmd, configSetCmd, configListCmd)
+
+	configSetCmd.Flags().BoolVar(&configSetLocal, "local", false, "Save in .github/issue-dependency.yml of the current repository")
+}
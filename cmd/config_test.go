@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+// loadTestConfig points the user settings file at a temporary directory,
+// writes content to it, and loads it into config
+func loadTestConfig(t *testing.T, content string) {
+	t.Helper()
+	originalConfig, originalErr := config, configErr
+	t.Cleanup(func() { config, configErr = originalConfig, originalErr })
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	if content != "" {
+		require.NoError(t, os.MkdirAll(filepath.Dir(pkg.UserConfigPath()), 0750))
+		require.NoError(t, os.WriteFile(pkg.UserConfigPath(), []byte(content), 0600))
+	}
+	config, configErr = pkg.LoadConfig()
+}
+
+func TestExpandAlias(t *testing.T) {
+	loadTestConfig(t, `aliases:
+  deps: list --state open --sort state
+  list: status
+`)
+	require.NoError(t, configErr)
+
+	tests := []struct {
+		name     string
+		args     []string
+		want     []string
+		expanded bool
+	}{
+		{"alias with arguments", []string{"deps", "123", "--repo", "o/r"}, []string{"list", "--state", "open", "--sort", "state", "123", "--repo", "o/r"}, true},
+		{"built-in command wins", []string{"list", "123"}, nil, false},
+		{"not an alias", []string{"nope"}, nil, false},
+		{"no arguments", nil, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, ok := expandAlias(rootCmd, config, tt.args)
+			assert.Equal(t, tt.expanded, ok)
+			assert.Equal(t, tt.want, args)
+		})
+	}
+}
+
+func TestApplyConfigFlagDefaults(t *testing.T) {
+	loadTestConfig(t, "format: json\nstate: open\n")
+
+	var format, state, sort string
+	cmd := &cobra.Command{Use: "list"}
+	cmd.Flags().StringVar(&format, "format", "table", "")
+	cmd.Flags().StringVar(&state, "state", "all", "")
+	cmd.Flags().StringVar(&sort, "sort", "number", "")
+	require.NoError(t, cmd.Flags().Parse([]string{"--state", "closed"}))
+
+	require.NoError(t, applyConfig(cmd, nil))
+	assert.Equal(t, "json", format)
+	assert.Equal(t, "closed", state, "an explicit flag overrides the settings")
+	assert.Equal(t, "number", sort)
+	assert.False(t, cmd.Flags().Changed("format"))
+}
+
+func TestApplyConfigError(t *testing.T) {
+	loadTestConfig(t, "format: xml\n")
+	require.Error(t, configErr)
+
+	assert.Error(t, applyConfig(&cobra.Command{Use: "list"}, nil))
+	assert.NoError(t, applyConfig(configListCmd, nil), "config still runs so the file can be fixed")
+}
+
+func TestApplyConfigRepoWarnings(t *testing.T) {
+	loadTestConfig(t, "")
+	dir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0750))
+	repoPath := filepath.Join(dir, filepath.FromSlash(pkg.RepoConfigPath))
+	require.NoError(t, os.MkdirAll(filepath.Dir(repoPath), 0750))
+	require.NoError(t, os.WriteFile(repoPath, []byte("format: json\ntheme: dark\n"), 0600))
+	config, configErr = pkg.LoadConfig()
+	require.NoError(t, configErr)
+
+	var format string
+	cmd := &cobra.Command{Use: "list"}
+	cmd.Flags().StringVar(&format, "format", "table", "")
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+
+	require.NoError(t, applyConfig(cmd, nil))
+	assert.Equal(t, "json", format, "known keys still apply")
+	assert.Contains(t, stderr.String(), "Warning: Ignoring unknown setting theme in ")
+}
+
+func TestConfigCommands(t *testing.T) {
+	loadTestConfig(t, "")
+
+	run := func(cmd *cobra.Command, args ...string) (string, error) {
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		err := cmd.RunE(cmd, args)
+		return buf.String(), err
+	}
+
+	_, err := run(configSetCmd, "format", "csv")
+	require.NoError(t, err)
+	_, err = run(configSetCmd, "aliases.deps", "list --state open")
+	require.NoError(t, err)
+
+	config, configErr = pkg.LoadConfig()
+	require.NoError(t, configErr)
+
+	out, err := run(configGetCmd, "format")
+	require.NoError(t, err)
+	assert.Equal(t, "csv\n", out)
+
+	out, err = run(configListCmd)
+	require.NoError(t, err)
+	assert.Contains(t, out, "format        csv                user")
+	assert.Contains(t, out, "aliases.deps  list --state open  user")
+
+	_, err = run(configSetCmd, "aliases.add", "list")
+	assert.ErrorContains(t, err, "built-in add command")
+	_, err = run(configSetCmd, "format", "xml")
+	assert.ErrorContains(t, err, "Invalid value for format")
+
+	configSetLocal = true
+	defer func() { configSetLocal = false }()
+	_, err = run(configSetCmd, "format", "json")
+	assert.ErrorContains(t, err, "Not inside a git repository")
+}
@@ -0,0 +1,69 @@
+// Package cmd provides command-line interface commands for gh-issue-dependency.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+// exportFlags holds the structured output flags shared by every command that emits JSON
+type exportFlags struct {
+	// template is a Go template applied to the JSON output, as with `gh --template`
+	template string
+
+	// jq is a jq expression applied to the JSON output, as with `gh --jq`
+	jq string
+}
+
+// addExportFlags registers the shared structured output flags on cmd
+func addExportFlags(cmd *cobra.Command, flags *exportFlags) {
+	cmd.Flags().StringVarP(&flags.template, "template", "t", "", "Format JSON output using a Go template; see \"gh help formatting\"")
+	cmd.Flags().StringVarP(&flags.jq, "jq", "q", "", "Filter JSON output using a jq expression")
+}
+
+// enabled reports whether any structured output flag was given
+func (e *exportFlags) enabled() bool {
+	return e.template != "" || e.jq != ""
+}
+
+// options converts the flags into pkg export options
+func (e *exportFlags) options() pkg.ExportOptions {
+	return pkg.ExportOptions{Template: e.template, JQ: e.jq}
+}
+
+// validate checks the structured output flags before any API calls are made
+func (e *exportFlags) validate() error {
+	return e.options().Validate()
+}
+
+// resolveReportFormat validates the --format flag of commands that write either a
+// table or JSON, together with the shared structured output flags, and returns the
+// format to write. --template and --jq operate on the JSON output, so they select
+// JSON unless another format was asked for explicitly.
+func resolveReportFormat(cmd *cobra.Command, format string, flags *exportFlags) (string, error) {
+	if format != "table" && format != "json" {
+		return "", pkg.NewAppError(
+			pkg.ErrorTypeValidation,
+			fmt.Sprintf("Invalid format: %s", format),
+			nil,
+		).WithContext("format", format).
+			WithSuggestion("Use --format table or --format json")
+	}
+
+	if err := flags.validate(); err != nil {
+		return "", err
+	}
+	if !flags.enabled() {
+		return format, nil
+	}
+	if cmd.Flags().Changed("format") && format != "json" {
+		return "", pkg.NewAppError(
+			pkg.ErrorTypeValidation,
+			fmt.Sprintf("Cannot combine --template or --jq with --format %s", format),
+			nil,
+		).WithSuggestion("Remove --format; --template and --jq always operate on the JSON output")
+	}
+	return "json", nil
+}
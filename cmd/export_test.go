@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveReportFormat(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		flags         exportFlags
+		expected      string
+		errorContains string
+	}{
+		{"default table", nil, exportFlags{}, "table", ""},
+		{"explicit json", []string{"--format", "json"}, exportFlags{}, "json", ""},
+		{"jq selects json", nil, exportFlags{jq: ".[]"}, "json", ""},
+		{"template with json format", []string{"--format", "json"}, exportFlags{template: "{{.}}"}, "json", ""},
+		{"template with table format", []string{"--format", "table"}, exportFlags{template: "{{.}}"}, "", "Cannot combine --template or --jq with --format table"},
+		{"unknown format", []string{"--format", "csv"}, exportFlags{}, "", "Invalid format: csv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var format string
+			cmd := &cobra.Command{Use: "report"}
+			cmd.Flags().StringVar(&format, "format", "table", "")
+			require.NoError(t, cmd.Flags().Parse(tt.args))
+
+			resolved, err := resolveReportFormat(cmd, format, &tt.flags)
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, resolved)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Package cmd provides command-line interface commands for gh-issue-dependency.
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the local journal of dependency changes",
+	Long: `Show the dependency changes made by this extension on this machine.
+
+Every relationship added or removed with the add, remove, and undo commands is
+recorded in an append-only journal under the config directory. Each entry
+includes an operation ID that can be passed to 'gh issue-dependency undo'.
+
+OUTPUT
+  ID             Operation ID used by the undo command
+  TIME           When the change was made, in local time
+  USER           GitHub user who made the change
+  ACTION         add or remove; undo operations name the entry they reverted
+  RELATIONSHIP   The source and target issues and relationship type
+  BEFORE         Whether the relationship was present, and the target issue's
+                 state and title, just before the change
+
+FLAGS
+  --since string   Only show operations since a duration (24h, 7d), date, or timestamp`,
+	Example: `  # Show every recorded operation
+  gh issue-dependency history
+
+  # Show operations from the last day
+  gh issue-dependency history --since 24h
+
+  # Show operations since a date
+  gh issue-dependency history --since 2024-03-01`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := pkg.ReadJournal()
+		if err != nil {
+			return err
+		}
+
+		if historySince != "" {
+			since, err := pkg.ParseJournalSince(historySince, time.Now())
+			if err != nil {
+				return err
+			}
+			entries = pkg.FilterJournalSince(entries, since)
+		}
+
+		if len(entries) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No operations recorded")
+			return nil
+		}
+
+		return pkg.FormatJournalEntries(cmd.OutOrStdout(), entries)
+	},
+}
+
+// Flags for history command
+var (
+	// historySince limits the journal to operations recorded after this point.
+	// Accepts durations (24h, 7d), dates (2006-01-02), and RFC 3339 timestamps.
+	historySince string
+)
+
+// init registers the history command with the root command and sets up its flags.
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().StringVar(&historySince, "since", "", "Only show operations since a duration (24h, 7d), date, or timestamp")
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -24,19 +25,40 @@ DEPENDENCIES SHOWN
   • Cross-repository dependencies when applicable
 
 OUTPUT FORMATS
-  • table (default): Human-readable table format with issue titles and states
+  • table (default): Human-readable table sized to the terminal; tab-separated when piped
   • json: Machine-readable JSON for scripting and integration
   • csv: Comma-separated values for spreadsheet import
+  • markdown: Task lists or tables for pasting into issues and pull requests
 
 The output includes issue numbers, repository information, titles, current status,
 and relationship type (blocking vs blocked).
 
 FLAGS
-  --detailed       Show detailed dependency information including dates and users
-  --format string  Output format: table, json, csv (default "table")
-  --state string   Filter dependencies by issue state: all, open, closed (default "all")
-  --sort string    Sort dependencies by: number, title, state, repository (default "number")
-  --json string    Output JSON with specific fields (e.g., "blocked_by,blocks")`,
+  --columns string         Table columns: number, title, state, reason, assignees, labels,
+                           author, milestone, type, comments, repo, created, updated,
+                           closed, url, added, added_by, parent, sub_issues, prs
+  --detailed               Show detailed dependency information including dates and users
+  --with-hierarchy         Show parent issues and sub-issues alongside dependencies
+  --rollup                 Include open blockers of sub-issues from outside the issue's tree
+                           (implies --with-hierarchy)
+  --with-prs               Show pull requests that will close each related issue, with review
+                           and CI status
+  --format string          Output format: table, json, csv, markdown (default "table")
+  --markdown-style string  Markdown layout: tasklist, table (default "tasklist")
+  --state string           Filter dependencies by issue state: all, open, closed (default "all")
+  --label strings          Show dependencies with all of these labels
+  --assignee strings       Show dependencies assigned to any of these users ("@me" for yourself)
+  --author strings         Show dependencies opened by any of these users
+  --milestone strings      Show dependencies in any of these milestones
+  --repo-filter strings    Show dependencies in any of these repositories (owner/repo or name)
+                           Filters are repeatable; prefix a value with - to exclude it
+  --sort string            Sort by comma-separated keys; prefix a key with - to reverse it:
+                           number, title, state, repository, created, updated, closed,
+                           assignee, label:<prefix>, milestone (default "number")
+  --json string            Output JSON with specific fields (e.g., "blocked_by.number,summary");
+                           use --json without a value to list available fields
+  -q, --jq string          Filter JSON output using a jq expression
+  -t, --template string    Format JSON output using a Go template (see "gh help formatting")`,
 	Example: `  # List all dependencies for issue #123
   gh issue-dependency list 123
 
@@ -46,26 +68,68 @@ FLAGS
   # Show detailed dependency information
   gh issue-dependency list 789 --detailed
 
+  # Show the epic's parent and sub-issues alongside its dependencies
+  gh issue-dependency list 123 --with-hierarchy
+
+  # Treat an epic as blocked by whatever blocks its sub-issues
+  gh issue-dependency list 123 --rollup
+
+  # See which blockers already have a pull request in review
+  gh issue-dependency list 123 --with-prs
+
+  # Choose the table columns
+  gh issue-dependency list 123 --columns number,title,state,updated
+
   # Output dependencies as JSON for scripting
   gh issue-dependency list 123 --format json
 
   # Output specific JSON fields
   gh issue-dependency list 123 --json blocked_by,summary
 
+  # Output nested JSON fields
+  gh issue-dependency list 123 --json blocked_by.number,blocked_by.state,summary.total_count
+
   # Export dependencies to CSV for analysis
   gh issue-dependency list 456 --format csv > dependencies.csv
 
+  # Render a Markdown task list for an epic description
+  gh issue-dependency list 123 --format markdown
+
+  # Render Markdown tables instead of task lists
+  gh issue-dependency list 123 --format markdown --markdown-style table
+
   # Show only open dependencies
   gh issue-dependency list 123 --state open
 
   # List closed dependencies in JSON format
   gh issue-dependency list 456 --state closed --format json
 
+  # Show only blockers assigned to your team
+  gh issue-dependency list 123 --state open --assignee alice,bob --assignee carol
+
+  # Exclude dependencies labeled wontfix or living in the docs repository
+  gh issue-dependency list 123 --label -wontfix --repo-filter -docs
+
+  # Show dependencies in the v2.0 milestone opened by you
+  gh issue-dependency list 123 --milestone v2.0 --author @me
+
   # Sort dependencies by title
   gh issue-dependency list 123 --sort title
 
   # Sort cross-repository dependencies by repository name
-  gh issue-dependency list 456 --sort repository`,
+  gh issue-dependency list 456 --sort repository
+
+  # Open issues first, most recently updated first within each state
+  gh issue-dependency list 123 --sort state,-updated,number
+
+  # Group by priority label, then by milestone due date
+  gh issue-dependency list 123 --sort label:priority/,milestone
+
+  # Print the numbers of open blocking issues without installing jq
+  gh issue-dependency list 123 --json blocked_by --jq '.blocked_by[] | select(.state=="open") | .number'
+
+  # Print one line per blocking issue using a Go template
+  gh issue-dependency list 123 --template '{{range .blocked_by}}{{.number}} {{.title}}{{"\n"}}{{end}}'`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		issueNumber := args[0]
@@ -86,8 +150,8 @@ FLAGS
 		}
 
 		// Validate the output format option against supported formats.
-		// We support table (default), JSON, and CSV formats for different use cases.
-		validFormats := []string{"table", "json", "csv"}
+		// We support table (default), JSON, CSV, and Markdown formats for different use cases.
+		validFormats := []string{"table", "json", "csv", "markdown"}
 		isValidFormat := false
 		for _, format := range validFormats {
 			if listFormat == format {
@@ -101,7 +165,38 @@ FLAGS
 				fmt.Sprintf("Invalid format: %s", listFormat),
 				nil,
 			).WithContext("format", listFormat).
-				WithSuggestion("Use one of: table, json, csv")
+				WithSuggestion("Use one of: table, json, csv, markdown")
+		}
+
+		// Validate the Markdown style, which only applies to Markdown output
+		if listMarkdownStyle != pkg.MarkdownTaskList && listMarkdownStyle != pkg.MarkdownTable {
+			return pkg.NewAppError(
+				pkg.ErrorTypeValidation,
+				fmt.Sprintf("Invalid markdown style: %s", listMarkdownStyle),
+				nil,
+			).WithContext("markdown_style", listMarkdownStyle).
+				WithSuggestion("Use one of: tasklist, table")
+		}
+		if cmd.Flags().Changed("markdown-style") && listFormat != "markdown" {
+			return pkg.NewAppError(
+				pkg.ErrorTypeValidation,
+				"--markdown-style requires --format markdown",
+				nil,
+			).WithSuggestion("Add --format markdown")
+		}
+
+		// Validate column selection, which only applies to table output
+		if cmd.Flags().Changed("columns") {
+			if err := pkg.ValidateColumns(parseJSONFields(listColumns)); err != nil {
+				return err
+			}
+			if listFormat != "table" || listJSON != "" || listExport.enabled() {
+				return pkg.NewAppError(
+					pkg.ErrorTypeValidation,
+					"--columns requires table output",
+					nil,
+				).WithSuggestion("Remove --format, --json, --jq, and --template when using --columns")
+			}
 		}
 
 		// Validate the state filter option against supported states.
@@ -123,23 +218,35 @@ FLAGS
 				WithSuggestion("Use one of: all, open, closed")
 		}
 
-		// Validate the sort option against supported sort orders.
-		// We support number (default), title, state, and repository for sorting dependencies.
-		validSorts := []string{"number", "title", "state", "repository"}
-		isValidSort := false
-		for _, sort := range validSorts {
-			if listSort == sort {
-				isValidSort = true
-				break
+		// Validate attribute filters such as --label and --assignee
+		if err := listDependencyFilter().Validate(); err != nil {
+			return err
+		}
+
+		// Validate the sort spec, which may combine several keys such as
+		// "state,-updated,number"
+		if _, err := pkg.ParseSortSpec(listSort); err != nil {
+			return err
+		}
+
+		// Validate JSON field selection before making any API calls
+		if listJSON != "" {
+			if err := pkg.ValidateJSONFields(parseJSONFields(listJSON)); err != nil {
+				return err
 			}
 		}
-		if !isValidSort {
+
+		// Templates and jq filters operate on the JSON structure, so they cannot be
+		// combined with other formats
+		if err := listExport.validate(); err != nil {
+			return err
+		}
+		if listExport.enabled() && cmd.Flags().Changed("format") && listFormat != "json" {
 			return pkg.NewAppError(
 				pkg.ErrorTypeValidation,
-				fmt.Sprintf("Invalid sort: %s", listSort),
+				fmt.Sprintf("Cannot combine --template or --jq with --format %s", listFormat),
 				nil,
-			).WithContext("sort", listSort).
-				WithSuggestion("Use one of: number, title, state, repository")
+			).WithSuggestion("Remove --format; --template and --jq always operate on the JSON output")
 		}
 
 		// Fetch dependency data from GitHub API and display results
@@ -155,22 +262,80 @@ var (
 	listDetailed bool
 
 	// listFormat specifies the output format for dependency information.
-	// Supported formats: table (default), json, csv
+	// Supported formats: table (default), json, csv, markdown
 	listFormat string
 
+	// listMarkdownStyle selects the Markdown layout.
+	// Supported styles: tasklist (default), table
+	listMarkdownStyle string
+
+	// listColumns selects the table columns as a comma-separated list.
+	// Supported columns: see pkg.TableColumnNames
+	listColumns string
+
 	// listState filters dependencies by issue state.
 	// Supported states: all (default), open, closed
 	listState string
 
-	// listSort specifies the sort order for dependencies.
-	// Supported orders: number (default), title, state, repository
+	// listSort specifies the sort order for dependencies as a comma-separated
+	// list of keys; a leading - sorts that key descending.
+	// Supported keys: number (default), title, state, repository, created,
+	// updated, closed, assignee, label:<prefix>, milestone
 	listSort string
 
+	// listLabels, listAssignees, listAuthors, listMilestones, and listRepoFilters
+	// filter dependencies by issue attributes. Each is repeatable, and a value
+	// prefixed with - excludes matching issues.
+	listLabels      []string
+	listAssignees   []string
+	listAuthors     []string
+	listMilestones  []string
+	listRepoFilters []string
+
+	// listWithHierarchy fetches the parent and sub-issues of the source issue
+	// and of every related issue
+	listWithHierarchy bool
+
+	// listRollup adds open blockers of the source issue's sub-issues from
+	// outside its tree; it implies listWithHierarchy
+	listRollup bool
+
+	// listWithPRs fetches the pull requests linked to each related issue
+	listWithPRs bool
+
 	// listJSON specifies JSON fields for selective output
 	// When set, overrides listFormat to use JSON with specific fields
 	listJSON string
+
+	// listExport holds the shared structured output flags (--template)
+	listExport exportFlags
 )
 
+// requestsRelationshipEvents reports whether --json selected a field that is
+// populated from issue timeline events
+func requestsRelationshipEvents(jsonFields string) bool {
+	if jsonFields == "" {
+		return false
+	}
+	for _, field := range parseJSONFields(jsonFields) {
+		if strings.HasSuffix(field, ".created_at") || strings.HasSuffix(field, ".created_by") {
+			return true
+		}
+	}
+	return false
+}
+
+// listDependencyFilter builds the attribute filter from the list flags
+func listDependencyFilter() pkg.DependencyFilter {
+	return pkg.DependencyFilter{
+		Labels:       listLabels,
+		Assignees:    listAssignees,
+		Authors:      listAuthors,
+		Milestones:   listMilestones,
+		Repositories: listRepoFilters,
+	}
+}
+
 // fetchAndDisplayDependencies fetches real dependency data from GitHub API and displays it
 // This function replaces the placeholder output with actual GitHub API integration
 func fetchAndDisplayDependencies(owner, repo string, issueNum int, format, state, sortOrder string, detailed bool) error {
@@ -178,13 +343,23 @@ func fetchAndDisplayDependencies(owner, repo string, issueNum int, format, state
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
-	// Clean expired cache entries periodically (fail silently if error)
-	go func() {
-		if err := pkg.CleanExpiredCache(); err != nil {
-			// Log error but don't fail main operation since this is background cleanup
-			fmt.Fprintf(os.Stderr, "Warning: failed to clean expired cache: %v\n", err)
+	// Clean expired cache entries before fetching, at most once an hour. This
+	// runs to completion rather than in the background, where exiting could
+	// interrupt it.
+	if err := pkg.CleanExpiredCache(); err != nil {
+		// Log error but don't fail main operation since this is only cleanup
+		fmt.Fprintf(os.Stderr, "Warning: failed to clean expired cache: %v\n", err)
+	}
+
+	// Resolve @me before fetching so an authentication problem fails fast
+	filter := listDependencyFilter()
+	if filter.UsesMe() {
+		login, err := pkg.CurrentUserLogin()
+		if err != nil {
+			return err
 		}
-	}()
+		filter = filter.WithMe(login)
+	}
 
 	// Fetch dependency data from GitHub API
 	originalData, err := pkg.FetchIssueDependencies(ctx, owner, repo, issueNum)
@@ -192,9 +367,35 @@ func fetchAndDisplayDependencies(owner, repo string, issueNum int, format, state
 		return err
 	}
 
+	// Relationship creation time and actor come from the issue timeline, so
+	// only fetch it when they will be shown
+	if detailed || requestsRelationshipEvents(listJSON) {
+		if err := pkg.EnrichWithRelationshipEvents(ctx, originalData); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to fetch relationship history: %v\n", err)
+		}
+	}
+
+	// Sub-issue hierarchy was explicitly requested, so failing to read it is an error
+	if listWithHierarchy || listRollup {
+		opts := pkg.HierarchyOptions{RollUp: listRollup}
+		if err := pkg.EnrichWithHierarchy(ctx, originalData, opts); err != nil {
+			return err
+		}
+	}
+
+	// Linked pull requests were explicitly requested too
+	if listWithPRs {
+		if err := pkg.EnrichWithPullRequests(ctx, originalData); err != nil {
+			return err
+		}
+	}
+
 	// Apply state filtering, keeping reference to original data
 	filteredData := applyStateFilter(originalData, state)
 
+	// Apply attribute filters such as --label and --assignee
+	filteredData = filter.Apply(filteredData)
+
 	// Apply sorting to the filtered data
 	filteredData = applySorting(filteredData, sortOrder)
 
@@ -202,15 +403,15 @@ func fetchAndDisplayDependencies(owner, repo string, issueNum int, format, state
 	outputOptions := pkg.DefaultOutputOptions()
 	outputOptions.Detailed = detailed
 	outputOptions.StateFilter = state
+	outputOptions.Filter = filter
 	outputOptions.OriginalData = originalData
 
 	// Handle JSON field selection
 	if listJSON != "" {
 		outputOptions.Format = pkg.FormatJSON
-		if listJSON != "true" { // If not just --json, parse fields
-			fields := parseJSONFields(listJSON)
-			outputOptions.JSONFields = fields
-		}
+		outputOptions.JSONFields = parseJSONFields(listJSON)
+	} else if listExport.enabled() {
+		outputOptions.Format = pkg.FormatJSON
 	} else {
 		// Use regular format flag
 		switch format {
@@ -218,18 +419,44 @@ func fetchAndDisplayDependencies(owner, repo string, issueNum int, format, state
 			outputOptions.Format = pkg.FormatJSON
 		case "csv":
 			outputOptions.Format = pkg.FormatCSV
+		case "markdown":
+			outputOptions.Format = pkg.FormatMarkdown
+			outputOptions.MarkdownStyle = listMarkdownStyle
 		case "table":
 			outputOptions.Format = pkg.FormatAuto // Auto-detect TTY vs plain
+			outputOptions.Columns = parseJSONFields(listColumns)
 		default:
 			outputOptions.Format = pkg.FormatAuto
 		}
 	}
 
+	outputOptions.Export = listExport.options()
+
 	// Create formatter and display results
 	formatter := pkg.NewOutputFormatter(outputOptions)
 	return formatter.FormatOutput(filteredData)
 }
 
+// listFlagError lists the available JSON fields when --json is given without a
+// value, the same way `gh issue list --json` does
+func listFlagError(cmd *cobra.Command, err error) error {
+	if strings.Contains(err.Error(), "flag needs an argument: --json") {
+		return jsonFieldListError(pkg.JSONFieldNames())
+	}
+	return err
+}
+
+// jsonFieldListError builds the error shown for a bare --json flag
+func jsonFieldListError(fields []string) error {
+	var message strings.Builder
+	message.WriteString("Specify one or more comma-separated fields for `--json`:")
+	for _, field := range fields {
+		message.WriteString("\n  " + field)
+	}
+
+	return pkg.NewAppError(pkg.ErrorTypeValidation, message.String(), nil)
+}
+
 // parseJSONFields parses the JSON fields specification
 func parseJSONFields(fieldsStr string) []string {
 	if fieldsStr == "" {
@@ -255,10 +482,12 @@ func applyStateFilter(data *pkg.DependencyData, state string) *pkg.DependencyDat
 
 	// Create a copy to avoid modifying the original
 	filtered := &pkg.DependencyData{
-		SourceIssue: data.SourceIssue,
-		BlockedBy:   []pkg.DependencyRelation{},
-		Blocking:    []pkg.DependencyRelation{},
-		FetchedAt:   data.FetchedAt,
+		SourceIssue:       data.SourceIssue,
+		BlockedBy:         []pkg.DependencyRelation{},
+		Blocking:          []pkg.DependencyRelation{},
+		FetchedAt:         data.FetchedAt,
+		SourceHierarchy:   data.SourceHierarchy,
+		RolledUpBlockedBy: data.RolledUpBlockedBy,
 	}
 
 	// Filter blocked_by relationships
@@ -283,9 +512,10 @@ func applyStateFilter(data *pkg.DependencyData, state string) *pkg.DependencyDat
 
 // applySorting sorts dependencies based on the specified sort order
 func applySorting(data *pkg.DependencyData, sortOrder string) *pkg.DependencyData {
-	if sortOrder == "" || sortOrder == "number" {
-		// Default is already sorted by number from API, no need to re-sort
-		return data
+	// Always sort, even by the default number order: the API returns issues in
+	// its own order and cross-repo listings need the repository tie-break
+	if sortOrder == "" {
+		sortOrder = pkg.SortNumber
 	}
 
 	// Create a copy to avoid modifying the original
@@ -297,11 +527,15 @@ func applySorting(data *pkg.DependencyData, sortOrder string) *pkg.DependencyDat
 		TotalCount:             data.TotalCount,
 		OriginalBlockedByCount: data.OriginalBlockedByCount,
 		OriginalBlockingCount:  data.OriginalBlockingCount,
+		SourceHierarchy:        data.SourceHierarchy,
 	}
 
 	// Copy dependencies for sorting
 	copy(sorted.BlockedBy, data.BlockedBy)
 	copy(sorted.Blocking, data.Blocking)
+	if data.RolledUpBlockedBy != nil {
+		sorted.RolledUpBlockedBy = slices.Clone(data.RolledUpBlockedBy)
+	}
 
 	// Sort blocked_by relationships
 	sortDependencySlice(sorted.BlockedBy, sortOrder)
@@ -309,61 +543,20 @@ func applySorting(data *pkg.DependencyData, sortOrder string) *pkg.DependencyDat
 	// Sort blocking relationships
 	sortDependencySlice(sorted.Blocking, sortOrder)
 
+	// Sort blockers rolled up from sub-issues
+	sortDependencySlice(sorted.RolledUpBlockedBy, sortOrder)
+
 	return sorted
 }
 
-// sortDependencySlice sorts a slice of dependencies based on the sort order
+// sortDependencySlice stably sorts a slice of dependencies by a sort spec.
+// Invalid specs leave the slice unchanged; they are rejected before fetching.
 func sortDependencySlice(deps []pkg.DependencyRelation, sortOrder string) {
-	switch sortOrder {
-	case "title":
-		// Sort by issue title alphabetically
-		for i := 0; i < len(deps)-1; i++ {
-			for j := i + 1; j < len(deps); j++ {
-				if strings.ToLower(deps[i].Issue.Title) > strings.ToLower(deps[j].Issue.Title) {
-					deps[i], deps[j] = deps[j], deps[i]
-				}
-			}
-		}
-	case "state":
-		// Sort by state (open first, then closed)
-		for i := 0; i < len(deps)-1; i++ {
-			for j := i + 1; j < len(deps); j++ {
-				if stateOrder(deps[i].Issue.State) > stateOrder(deps[j].Issue.State) {
-					deps[i], deps[j] = deps[j], deps[i]
-				}
-			}
-		}
-	case "repository":
-		// Sort by repository name alphabetically
-		for i := 0; i < len(deps)-1; i++ {
-			for j := i + 1; j < len(deps); j++ {
-				if strings.ToLower(deps[i].Repository) > strings.ToLower(deps[j].Repository) {
-					deps[i], deps[j] = deps[j], deps[i]
-				}
-			}
-		}
-	case "number":
-		// Sort by issue number (ascending)
-		for i := 0; i < len(deps)-1; i++ {
-			for j := i + 1; j < len(deps); j++ {
-				if deps[i].Issue.Number > deps[j].Issue.Number {
-					deps[i], deps[j] = deps[j], deps[i]
-				}
-			}
-		}
-	}
-}
-
-// stateOrder returns a numeric value for state sorting (open = 0, closed = 1)
-func stateOrder(state string) int {
-	switch strings.ToLower(state) {
-	case "open":
-		return 0
-	case "closed":
-		return 1
-	default:
-		return 2
+	keys, err := pkg.ParseSortSpec(sortOrder)
+	if err != nil {
+		return
 	}
+	pkg.SortDependencies(deps, keys)
 }
 
 // init registers the list command with the root command and sets up its flags.
@@ -372,8 +565,20 @@ func init() {
 
 	// Local flags specific to the list command
 	listCmd.Flags().BoolVar(&listDetailed, "detailed", false, "Show detailed dependency information including dates and users")
-	listCmd.Flags().StringVar(&listFormat, "format", "table", "Output format: table (default), json, csv")
+	listCmd.Flags().BoolVar(&listWithHierarchy, "with-hierarchy", false, "Show parent issues and sub-issues alongside dependencies")
+	listCmd.Flags().BoolVar(&listRollup, "rollup", false, "Include open blockers of sub-issues from outside the issue's tree (implies --with-hierarchy)")
+	listCmd.Flags().BoolVar(&listWithPRs, "with-prs", false, "Show pull requests that will close each related issue, with review and CI status")
+	listCmd.Flags().StringVar(&listFormat, "format", "table", "Output format: table (default), json, csv, markdown")
+	listCmd.Flags().StringVar(&listColumns, "columns", "", "Table columns to show, e.g. 'number,title,state,updated'")
+	listCmd.Flags().StringVar(&listMarkdownStyle, "markdown-style", pkg.MarkdownTaskList, "Markdown layout: tasklist (default), table")
 	listCmd.Flags().StringVar(&listState, "state", "all", "Filter dependencies by issue state: all (default), open, closed")
-	listCmd.Flags().StringVar(&listSort, "sort", "number", "Sort dependencies by: number (default), title, state, repository")
-	listCmd.Flags().StringVar(&listJSON, "json", "", "Output JSON with specific fields: e.g. 'blocked_by,blocks' or 'summary'")
+	listCmd.Flags().StringSliceVar(&listLabels, "label", nil, "Show dependencies with all of these labels; prefix with - to exclude")
+	listCmd.Flags().StringSliceVar(&listAssignees, "assignee", nil, "Show dependencies assigned to any of these users; prefix with - to exclude")
+	listCmd.Flags().StringSliceVar(&listAuthors, "author", nil, "Show dependencies opened by any of these users; prefix with - to exclude")
+	listCmd.Flags().StringSliceVar(&listMilestones, "milestone", nil, "Show dependencies in any of these milestones; prefix with - to exclude")
+	listCmd.Flags().StringSliceVar(&listRepoFilters, "repo-filter", nil, "Show dependencies in any of these repositories; prefix with - to exclude")
+	listCmd.Flags().StringVar(&listSort, "sort", "number", "Sort by comma-separated keys, e.g. 'state,-updated,number'")
+	listCmd.Flags().StringVar(&listJSON, "json", "", "Output JSON with specific fields, e.g. 'blocked_by.number,summary'; omit the value to list fields")
+	addExportFlags(listCmd, &listExport)
+	listCmd.SetFlagErrorFunc(listFlagError)
 }
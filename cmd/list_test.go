@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"fmt"
 	"testing"
 	"time"
 
@@ -207,14 +208,14 @@ func TestApplySorting(t *testing.T) {
 		{
 			name:                   "sort by number (default)",
 			sortOrder:              "number",
-			expectedBlockedByOrder: []int{3, 1, 2}, // Should maintain original API order
-			expectedBlockingOrder:  []int{30, 10},  // Should maintain original API order
+			expectedBlockedByOrder: []int{1, 2, 3},
+			expectedBlockingOrder:  []int{10, 30},
 		},
 		{
 			name:                   "empty sort order defaults to number",
 			sortOrder:              "",
-			expectedBlockedByOrder: []int{3, 1, 2}, // Should maintain original API order
-			expectedBlockingOrder:  []int{30, 10},  // Should maintain original API order
+			expectedBlockedByOrder: []int{1, 2, 3},
+			expectedBlockingOrder:  []int{10, 30},
 		},
 		{
 			name:                   "sort by title",
@@ -261,6 +262,27 @@ func TestApplySorting(t *testing.T) {
 	}
 }
 
+func TestApplySortingDefaultIsDeterministic(t *testing.T) {
+	// The API returns cross-repo blockers in its own order; the default sort
+	// must still order them by number and break ties on repository
+	data := &pkg.DependencyData{
+		BlockedBy: []pkg.DependencyRelation{
+			{Issue: pkg.Issue{Number: 7}, Repository: "zebra/repo"},
+			{Issue: pkg.Issue{Number: 2}, Repository: "zebra/repo"},
+			{Issue: pkg.Issue{Number: 7}, Repository: "alpha/repo"},
+		},
+	}
+
+	for _, sortOrder := range []string{"", "number"} {
+		sorted := applySorting(data, sortOrder)
+		var got []string
+		for _, dep := range sorted.BlockedBy {
+			got = append(got, fmt.Sprintf("%s#%d", dep.Repository, dep.Issue.Number))
+		}
+		assert.Equal(t, []string{"zebra/repo#2", "alpha/repo#7", "zebra/repo#7"}, got, "sort %q", sortOrder)
+	}
+}
+
 func TestSortDependencySlice(t *testing.T) {
 	deps := []pkg.DependencyRelation{
 		{Issue: pkg.Issue{Number: 3, Title: "Charlie", State: "closed", Repository: pkg.RepositoryInfo{FullName: "zebra/repo"}}, Repository: "zebra/repo"},
@@ -314,29 +336,6 @@ func TestSortDependencySlice(t *testing.T) {
 	})
 }
 
-func TestStateOrder(t *testing.T) {
-	tests := []struct {
-		state    string
-		expected int
-	}{
-		{"open", 0},
-		{"Open", 0}, // Case insensitive
-		{"OPEN", 0}, // Case insensitive
-		{"closed", 1},
-		{"Closed", 1},  // Case insensitive
-		{"CLOSED", 1},  // Case insensitive
-		{"unknown", 2}, // Unknown states get highest priority
-		{"", 2},        // Empty state gets highest priority
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.state, func(t *testing.T) {
-			result := stateOrder(tt.state)
-			assert.Equal(t, tt.expected, result, "stateOrder(%q) should return %d", tt.state, tt.expected)
-		})
-	}
-}
-
 // Test list command argument validation
 func TestListCommandValidation(t *testing.T) {
 	// Save original global variables
@@ -712,3 +711,74 @@ func TestEdgeCases(t *testing.T) {
 		assert.Equal(t, 0, len(deps))
 	})
 }
+
+func TestListFlagError(t *testing.T) {
+	err := listFlagError(listCmd, fmt.Errorf("flag needs an argument: --json"))
+	require.Error(t, err)
+	assert.True(t, pkg.IsErrorType(err, pkg.ErrorTypeValidation))
+	assert.Contains(t, err.Error(), "Specify one or more comma-separated fields for `--json`:")
+	assert.Contains(t, err.Error(), "\n  blocked_by.number\n")
+
+	other := fmt.Errorf("unknown flag: --bogus")
+	assert.Equal(t, other, listFlagError(listCmd, other))
+}
+
+func TestRequestsRelationshipEvents(t *testing.T) {
+	assert.False(t, requestsRelationshipEvents(""))
+	assert.False(t, requestsRelationshipEvents("blocked_by.number,blocks"))
+	assert.True(t, requestsRelationshipEvents("blocked_by.number,blocked_by.created_by"))
+	assert.True(t, requestsRelationshipEvents("blocks.created_at"))
+}
+
+func TestListFilterFlags(t *testing.T) {
+	originals := [][]string{listLabels, listAssignees, listAuthors, listMilestones, listRepoFilters}
+	defer func() {
+		listLabels, listAssignees, listAuthors, listMilestones, listRepoFilters =
+			originals[0], originals[1], originals[2], originals[3], originals[4]
+	}()
+
+	cmd := &cobra.Command{Use: "list"}
+	cmd.Flags().StringSliceVar(&listLabels, "label", nil, "")
+	cmd.Flags().StringSliceVar(&listAssignees, "assignee", nil, "")
+	cmd.Flags().StringSliceVar(&listAuthors, "author", nil, "")
+	cmd.Flags().StringSliceVar(&listMilestones, "milestone", nil, "")
+	cmd.Flags().StringSliceVar(&listRepoFilters, "repo-filter", nil, "")
+
+	// Negated values look like flags but are taken as values
+	require.NoError(t, cmd.ParseFlags([]string{
+		"--label", "-wontfix", "--label", "bug",
+		"--assignee", "alice,bob", "--assignee", "carol",
+		"--repo-filter", "-docs",
+	}))
+
+	filter := listDependencyFilter()
+	assert.Equal(t, []string{"-wontfix", "bug"}, filter.Labels)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, filter.Assignees)
+	assert.Equal(t, []string{"-docs"}, filter.Repositories)
+	assert.Empty(t, filter.Authors)
+	assert.NoError(t, filter.Validate())
+}
+
+func TestFiltersKeepHierarchy(t *testing.T) {
+	data := &pkg.DependencyData{
+		SourceIssue: pkg.Issue{Number: 1},
+		BlockedBy: []pkg.DependencyRelation{
+			{Issue: pkg.Issue{Number: 2, State: "open"}},
+		},
+		SourceHierarchy: &pkg.Hierarchy{SubIssues: []pkg.DependencyRelation{{Issue: pkg.Issue{Number: 3}}}},
+		RolledUpBlockedBy: []pkg.DependencyRelation{
+			{Issue: pkg.Issue{Number: 9, Title: "b"}}, {Issue: pkg.Issue{Number: 8, Title: "a"}},
+		},
+	}
+
+	filtered := applyStateFilter(data, "open")
+	assert.Same(t, data.SourceHierarchy, filtered.SourceHierarchy)
+	assert.Len(t, filtered.RolledUpBlockedBy, 2)
+
+	sorted := applySorting(filtered, "title")
+	assert.Same(t, data.SourceHierarchy, sorted.SourceHierarchy)
+	assert.Equal(t, 8, sorted.RolledUpBlockedBy[0].Issue.Number, "rolled-up blockers are sorted too")
+	assert.Equal(t, 9, data.RolledUpBlockedBy[0].Issue.Number, "the input is not modified")
+
+	assert.Nil(t, applySorting(&pkg.DependencyData{}, "title").RolledUpBlockedBy, "not computed stays nil")
+}
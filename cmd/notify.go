@@ -0,0 +1,156 @@
+// Package cmd provides command-line interface commands for gh-issue-dependency.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+// notifyCmd represents the notify command
+var notifyCmd = &cobra.Command{
+	Use:   "notify <issue>",
+	Short: "Comment on the issues a closed blocker was blocking",
+	Long: `Post a comment on every open issue blocked by a closed issue.
+
+Each comment mentions the dependent's assignees and says whether the issue is
+now unblocked or which blockers remain:
+
+  @alice #42 closed; you are now unblocked.
+  @bob #42 closed; still blocked by #7, #9.
+
+Comments carry a hidden marker naming the blocker, and dependents that already
+have one are skipped, so the command can be run again safely, for example from
+a workflow triggered when issues close. Closed dependents are not commented on.
+
+COMMENT TEMPLATE
+The comment is a Go template with the same helpers as --template. It can use:
+  .blocker            The closed issue, e.g. #42 or owner/repo#42
+  .blocker_title      Title of the closed issue
+  .blocker_url        URL of the closed issue
+  .issue              The dependent, as owner/repo#number
+  .title              Title of the dependent
+  .mentions           @login of each assignee of the dependent
+  .unblocked          Whether the dependent has no other open blockers
+  .still_blocked_by   The dependent's other open blockers
+
+FLAGS
+  --comment-template file   Read the comment template from a file
+  --dry-run                 Show the comments without posting them
+  --format string           Output format: table, json (default "table")
+  -q, --jq string           Filter JSON output using a jq expression
+  -t, --template string     Format JSON output using a Go template (see "gh help formatting")`,
+	Example: `  # Tell everyone waiting on #42 that it is closed
+  gh issue-dependency notify 42
+
+  # Preview the comments
+  gh issue-dependency notify 42 --dry-run
+
+  # Use your own wording
+  gh issue-dependency notify owner/repo#42 --comment-template .github/unblocked.tmpl
+
+  # List the dependents that would be unblocked
+  gh issue-dependency notify 42 --dry-run --jq '.dependents[] | select(.unblocked) | .issue'`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := resolveReportFormat(cmd, notifyFormat, &notifyExport)
+		if err != nil {
+			return err
+		}
+
+		tmpl, err := readCommentTemplate(notifyCommentTemplate)
+		if err != nil {
+			return err
+		}
+
+		owner, repo, err := pkg.ResolveRepository(repoFlag, args[0])
+		if err != nil {
+			return err
+		}
+		_, issueNum, err := pkg.ParseIssueReference(args[0])
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		report, err := pkg.NotifyDependents(ctx, owner, repo, issueNum, pkg.NotifyOptions{
+			Template: tmpl,
+			DryRun:   notifyDryRun,
+		})
+		if report != nil {
+			if writeErr := writeNotifyReport(cmd.OutOrStdout(), report, format, notifyExport.options()); writeErr != nil {
+				return writeErr
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		if failed := report.Failed(); failed > 0 {
+			return pkg.NewAppError(
+				pkg.ErrorTypeAPI,
+				fmt.Sprintf("Failed to comment on %d of %d dependents", failed, len(report.Dependents)),
+				nil,
+			).WithSuggestion("Run the command again to retry; dependents already notified are skipped")
+		}
+		return nil
+	},
+}
+
+// Flags for notify command
+var (
+	// notifyCommentTemplate is the path of a file holding the comment template
+	notifyCommentTemplate string
+
+	// notifyDryRun shows the comments without posting them
+	notifyDryRun bool
+
+	// notifyFormat selects table or JSON output
+	notifyFormat string
+
+	// notifyExport holds the shared structured output flags (--template, --jq)
+	notifyExport exportFlags
+)
+
+// readCommentTemplate reads the comment template file at path; an empty path
+// selects the default template
+func readCommentTemplate(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	content, err := os.ReadFile(path) // #nosec G304 -- path is the user's own --comment-template value
+	if err != nil {
+		return "", pkg.NewAppError(
+			pkg.ErrorTypeValidation,
+			fmt.Sprintf("Cannot read comment template: %s", path),
+			err,
+		).WithSuggestion("Check that the --comment-template file exists and is readable")
+	}
+	return string(content), nil
+}
+
+// writeNotifyReport writes report in the requested format
+func writeNotifyReport(w io.Writer, report *pkg.NotifyReport, format string, opts pkg.ExportOptions) error {
+	if format == "json" {
+		return pkg.ExportJSON(w, report, opts)
+	}
+	return pkg.FormatNotifyReport(w, report)
+}
+
+// init registers the notify command with the root command and sets up its flags.
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+
+	notifyCmd.Flags().StringVar(&notifyCommentTemplate, "comment-template", "", "Read the comment template from a file")
+	notifyCmd.Flags().BoolVar(&notifyDryRun, "dry-run", false, "Show the comments without posting them")
+	notifyCmd.Flags().StringVar(&notifyFormat, "format", "table", "Output format: table, json")
+	addExportFlags(notifyCmd, &notifyExport)
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+func TestNotifyCommandValidation(t *testing.T) {
+	originalTemplate, originalFormat, originalExport := notifyCommentTemplate, notifyFormat, notifyExport
+	defer func() {
+		notifyCommentTemplate, notifyFormat, notifyExport = originalTemplate, originalFormat, originalExport
+	}()
+
+	missing := filepath.Join(t.TempDir(), "missing.tmpl")
+
+	tests := []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{"invalid format", []string{"42", "--format", "csv"}, "Invalid format: csv"},
+		{"template with table format", []string{"42", "--format", "table", "--template", "{{.blocker}}"}, "Cannot combine --template or --jq with --format table"},
+		{"missing template", []string{"42", "--comment-template", missing}, "Cannot read comment template"},
+		{"missing issue", []string{}, "accepts 1 arg(s)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifyExport = exportFlags{}
+			cmd := &cobra.Command{Use: "notify", Args: notifyCmd.Args, RunE: notifyCmd.RunE}
+			cmd.Flags().StringVar(&notifyCommentTemplate, "comment-template", "", "")
+			cmd.Flags().StringVar(&notifyFormat, "format", "table", "")
+			addExportFlags(cmd, &notifyExport)
+			cmd.SetArgs(tt.args)
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestReadCommentTemplate(t *testing.T) {
+	tmpl, err := readCommentTemplate("")
+	require.NoError(t, err)
+	assert.Empty(t, tmpl)
+
+	path := filepath.Join(t.TempDir(), "unblocked.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte("{{.blocker}} is done"), 0600))
+	tmpl, err = readCommentTemplate(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{{.blocker}} is done", tmpl)
+}
+
+func TestWriteNotifyReport(t *testing.T) {
+	report := &pkg.NotifyReport{Blocker: "org/app#42", Title: "Schema", Dependents: []pkg.NotifyResult{}}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeNotifyReport(&buf, report, "table", pkg.ExportOptions{}))
+	assert.Equal(t, "org/app#42 (Schema): 0 open dependents\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeNotifyReport(&buf, report, "json", pkg.ExportOptions{}))
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, []interface{}{}, decoded["dependents"])
+
+	buf.Reset()
+	require.NoError(t, writeNotifyReport(&buf, report, "json", pkg.ExportOptions{Template: "{{.blocker}} {{len .dependents}}"}))
+	assert.Equal(t, "org/app#42 0", buf.String())
+}
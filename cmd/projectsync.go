@@ -0,0 +1,129 @@
+// Package cmd provides command-line interface commands for gh-issue-dependency.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+// projectSyncCmd represents the project-sync command
+var projectSyncCmd = &cobra.Command{
+	Use:   "project-sync",
+	Short: "Reflect blocked state into a GitHub Project field",
+	Long: `Set a project field to a "blocked" value on every issue that has an open blocker.
+
+Every issue on the project is checked for open blocked-by relationships. Blocked
+issues get the blocked value in the chosen single-select or text field, and the
+value it replaced is remembered in a state file under the config directory.
+Once an issue is no longer blocked, the remembered value is put back. If the
+field was changed by hand in the meantime, the issue is left alone.
+
+Run it on a schedule, or after changing dependencies, to keep the board current.
+Draft issues and pull requests on the project are skipped.
+
+Reading and updating projects needs the project scope:
+  gh auth refresh -s project
+
+FLAGS
+  --project string         Project as owner/number or project URL (required)
+  --field string           Single-select or text field to update (default "Status")
+  --blocked-value string   Field value for blocked issues (default "Blocked")
+  --dry-run                Show the changes without making them
+  --format string          Output format: table, json (default "table")
+  -q, --jq string          Filter JSON output using a jq expression
+  -t, --template string    Format JSON output using a Go template (see "gh help formatting")`,
+	Example: `  # Mark blocked issues on an organization project
+  gh issue-dependency project-sync --project my-org/5 --field Status --blocked-value Blocked
+
+  # Preview the changes
+  gh issue-dependency project-sync --project my-org/5 --dry-run
+
+  # Report the changes as JSON
+  gh issue-dependency project-sync --project https://github.com/orgs/my-org/projects/5 --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := resolveReportFormat(cmd, projectSyncFormat, &projectSyncExport)
+		if err != nil {
+			return err
+		}
+
+		project, err := pkg.ParseProjectRef(projectSyncProject)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		report, err := pkg.SyncProject(ctx, pkg.ProjectSyncOptions{
+			Project:      project,
+			Field:        projectSyncField,
+			BlockedValue: projectSyncBlockedValue,
+			DryRun:       projectSyncDryRun,
+		})
+		if report != nil {
+			if writeErr := writeProjectSyncReport(cmd.OutOrStdout(), report, format, projectSyncExport.options()); writeErr != nil {
+				return writeErr
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		if failed := report.Failed(); failed > 0 {
+			return pkg.NewAppError(
+				pkg.ErrorTypeAPI,
+				fmt.Sprintf("Failed to update %d of %d project items", failed, len(report.Changes)),
+				nil,
+			).WithSuggestion("Run the command again to retry the failed items")
+		}
+		return nil
+	},
+}
+
+// Flags for project-sync command
+var (
+	// projectSyncProject is the project to sync, as owner/number or URL
+	projectSyncProject string
+
+	// projectSyncField is the name of the single-select or text field to update
+	projectSyncField string
+
+	// projectSyncBlockedValue is the field value given to blocked issues
+	projectSyncBlockedValue string
+
+	// projectSyncDryRun reports the changes without making them
+	projectSyncDryRun bool
+
+	// projectSyncFormat selects table or JSON output
+	projectSyncFormat string
+
+	// projectSyncExport holds the shared structured output flags (--template, --jq)
+	projectSyncExport exportFlags
+)
+
+// writeProjectSyncReport writes report in the requested format
+func writeProjectSyncReport(w io.Writer, report *pkg.ProjectSyncReport, format string, opts pkg.ExportOptions) error {
+	if format == "json" {
+		return pkg.ExportJSON(w, report, opts)
+	}
+	return pkg.FormatProjectSyncReport(w, report)
+}
+
+// init registers the project-sync command with the root command and sets up its flags.
+func init() {
+	rootCmd.AddCommand(projectSyncCmd)
+
+	projectSyncCmd.Flags().StringVar(&projectSyncProject, "project", "", "Project as owner/number or project URL")
+	projectSyncCmd.Flags().StringVar(&projectSyncField, "field", "Status", "Single-select or text field to update")
+	projectSyncCmd.Flags().StringVar(&projectSyncBlockedValue, "blocked-value", "Blocked", "Field value for blocked issues")
+	projectSyncCmd.Flags().BoolVar(&projectSyncDryRun, "dry-run", false, "Show the changes without making them")
+	projectSyncCmd.Flags().StringVar(&projectSyncFormat, "format", "table", "Output format: table, json")
+	addExportFlags(projectSyncCmd, &projectSyncExport)
+	_ = projectSyncCmd.MarkFlagRequired("project")
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+func TestProjectSyncCommandValidation(t *testing.T) {
+	originalProject, originalFormat, originalExport := projectSyncProject, projectSyncFormat, projectSyncExport
+	defer func() {
+		projectSyncProject, projectSyncFormat, projectSyncExport = originalProject, originalFormat, originalExport
+	}()
+
+	tests := []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{"invalid format", []string{"--project", "org/5", "--format", "csv"}, "Invalid format: csv"},
+		{"template with table format", []string{"--project", "org/5", "--format", "table", "--template", "{{.}}"}, "Cannot combine --template or --jq with --format table"},
+		{"invalid project", []string{"--project", "org"}, "Invalid project: org"},
+		{"project number", []string{"--project", "org/first"}, "Invalid project: org/first"},
+		{"positional argument", []string{"extra"}, "unknown command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectSyncExport = exportFlags{}
+			cmd := &cobra.Command{Use: "project-sync", Args: projectSyncCmd.Args, RunE: projectSyncCmd.RunE}
+			cmd.Flags().StringVar(&projectSyncProject, "project", "", "")
+			cmd.Flags().StringVar(&projectSyncFormat, "format", "table", "")
+			addExportFlags(cmd, &projectSyncExport)
+			cmd.SetArgs(tt.args)
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestWriteProjectSyncReport(t *testing.T) {
+	report := &pkg.ProjectSyncReport{
+		Project:      "org/5",
+		Title:        "Roadmap",
+		Field:        "Status",
+		BlockedValue: "Blocked",
+		ItemsChecked: 2,
+		Changes:      []pkg.ProjectSyncChange{},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeProjectSyncReport(&buf, report, "table", pkg.ExportOptions{}))
+	assert.Equal(t, "Project org/5 (Roadmap): 2 issues checked, 0 blocked\nStatus is up to date\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeProjectSyncReport(&buf, report, "json", pkg.ExportOptions{}))
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, []interface{}{}, decoded["changes"])
+	assert.Equal(t, float64(2), decoded["items_checked"])
+
+	buf.Reset()
+	require.NoError(t, writeProjectSyncReport(&buf, report, "json", pkg.ExportOptions{Template: "{{.title}}: {{.items_checked}}"}))
+	assert.Equal(t, "Roadmap: 2", buf.String())
+}
@@ -0,0 +1,103 @@
+// Package cmd provides command-line interface commands for gh-issue-dependency.
+package cmd
+
+import (
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+// rateLimitCmd represents the rate-limit command
+var rateLimitCmd = &cobra.Command{
+	Use:   "rate-limit",
+	Short: "Show the remaining GitHub API request budgets",
+	Long: `Show how many GitHub API requests are left before the rate limits reset.
+
+The REST API budget is the core resource and the GraphQL budget the graphql
+resource; most commands use one or both. Checking does not use any budget.
+
+When a budget drops below 10%, commands slow down and spread their remaining
+requests until it resets. Use --max-requests on any command to stop it after
+a number of API requests.
+
+OUTPUT
+  RESOURCE    core (REST), graphql, or another rate limit resource
+  USED        Requests used in the current window
+  REMAINING   Requests left in the current window
+  LIMIT       Requests allowed per window
+  RESETS      When the window resets
+
+FLAGS
+  --all                   Show every resource, not only core and graphql
+  --format string         Output format: table, json (default "table")
+  -q, --jq string         Filter JSON output using a jq expression
+  -t, --template string   Format JSON output using a Go template (see "gh help formatting")`,
+	Example: `  # Show the REST and GraphQL budgets
+  gh issue-dependency rate-limit
+
+  # Show every resource as JSON
+  gh issue-dependency rate-limit --all --format json
+
+  # Print the remaining REST requests
+  gh issue-dependency rate-limit --jq '.[] | select(.resource=="core") | .remaining'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := resolveReportFormat(cmd, rateLimitFormat, &rateLimitExport)
+		if err != nil {
+			return err
+		}
+
+		budgets, err := pkg.FetchRateLimits()
+		if err != nil {
+			return err
+		}
+		if !rateLimitAll {
+			budgets = coreRateLimits(budgets)
+		}
+
+		return writeRateLimits(cmd.OutOrStdout(), budgets, format, rateLimitExport.options(), time.Now())
+	},
+}
+
+// Flags for rate-limit command
+var (
+	// rateLimitAll shows every resource instead of only core and graphql
+	rateLimitAll bool
+
+	// rateLimitFormat selects table or JSON output
+	rateLimitFormat string
+
+	// rateLimitExport holds the shared structured output flags (--template, --jq)
+	rateLimitExport exportFlags
+)
+
+// coreRateLimits keeps the REST and GraphQL budgets
+func coreRateLimits(budgets []pkg.RateLimitBudget) []pkg.RateLimitBudget {
+	var kept []pkg.RateLimitBudget
+	for _, budget := range budgets {
+		if budget.Resource == pkg.RateLimitResourceCore || budget.Resource == pkg.RateLimitResourceGraphQL {
+			kept = append(kept, budget)
+		}
+	}
+	return kept
+}
+
+// writeRateLimits writes budgets in the requested format; JSON output goes
+// through the shared export options
+func writeRateLimits(w io.Writer, budgets []pkg.RateLimitBudget, format string, opts pkg.ExportOptions, now time.Time) error {
+	if format == "json" {
+		return pkg.ExportJSON(w, budgets, opts)
+	}
+	return pkg.FormatRateLimits(w, budgets, now)
+}
+
+// init registers the rate-limit command with the root command and sets up its flags.
+func init() {
+	rootCmd.AddCommand(rateLimitCmd)
+
+	rateLimitCmd.Flags().BoolVar(&rateLimitAll, "all", false, "Show every resource, not only core and graphql")
+	rateLimitCmd.Flags().StringVar(&rateLimitFormat, "format", "table", "Output format: table, json")
+	addExportFlags(rateLimitCmd, &rateLimitExport)
+}
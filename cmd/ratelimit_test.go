@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+func TestRateLimitCommandValidation(t *testing.T) {
+	originalFormat, originalExport := rateLimitFormat, rateLimitExport
+	defer func() { rateLimitFormat, rateLimitExport = originalFormat, originalExport }()
+
+	tests := []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{"invalid format", []string{"--format", "csv"}, "Invalid format: csv"},
+		{"jq with table format", []string{"--format", "table", "--jq", ".[]"}, "Cannot combine --template or --jq with --format table"},
+		{"jq with template", []string{"--jq", ".[]", "--template", "{{.}}"}, "Cannot combine --jq with --template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rateLimitExport = exportFlags{}
+			cmd := &cobra.Command{Use: "rate-limit", Args: rateLimitCmd.Args, RunE: rateLimitCmd.RunE}
+			cmd.Flags().StringVar(&rateLimitFormat, "format", "table", "")
+			addExportFlags(cmd, &rateLimitExport)
+			cmd.SetArgs(tt.args)
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestWriteRateLimits(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	budgets := []pkg.RateLimitBudget{
+		{Resource: "core", Limit: 5000, Used: 100, Remaining: 4900, Reset: now.Add(30 * time.Minute)},
+		{Resource: "graphql", Limit: 5000, Used: 0, Remaining: 5000, Reset: now.Add(time.Hour)},
+		{Resource: "search", Limit: 30, Used: 0, Remaining: 30, Reset: now.Add(time.Minute)},
+	}
+
+	kept := coreRateLimits(budgets)
+	require.Len(t, kept, 2)
+	assert.Equal(t, "graphql", kept[1].Resource)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeRateLimits(&buf, kept[:1], "json", pkg.ExportOptions{}, now))
+	assert.Contains(t, buf.String(), `"resource": "core"`)
+	assert.Contains(t, buf.String(), `"remaining": 4900`)
+
+	buf.Reset()
+	require.NoError(t, writeRateLimits(&buf, kept, "json", pkg.ExportOptions{JQ: `.[] | select(.resource=="core") | .remaining`}, now))
+	assert.Equal(t, "4900\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeRateLimits(&buf, kept, "table", pkg.ExportOptions{}, now))
+	assert.Contains(t, buf.String(), "RESOURCE")
+	assert.Contains(t, buf.String(), "graphql")
+}
+
+func TestPrepareCommandMaxRequests(t *testing.T) {
+	originalMax := maxRequests
+	defer func() { maxRequests = originalMax }()
+
+	maxRequests = -1
+	err := prepareCommand(&cobra.Command{Use: "report"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid --max-requests")
+}
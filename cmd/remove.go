@@ -2,16 +2,13 @@
 package cmd
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/spf13/cobra"
 	"github.com/torynet/gh-issue-dependency/pkg"
 )
 
 // removeCmd represents the remove command
 var removeCmd = &cobra.Command{
-	Use:   "remove <issue-number>",
+	Use:   "remove [<issue-number>]",
 	Short: "Remove dependency relationships between GitHub issues",
 	Long: `Remove existing dependency relationships between GitHub issues with validation and confirmation.
 
@@ -27,6 +24,16 @@ You must specify exactly one of the following relationship types:
   --all          Remove all dependency relationships for the specified issue
                  (removes both "blocked by" and "blocks" relationships)
 
+BULK CHANGES WITH --query
+Instead of an issue number, --query selects every issue matching a GitHub search
+query. The matching issues are listed for confirmation, then the relationship is
+removed from each of them in parallel with a per-issue result summary.
+
+ATOMIC BATCHES
+By default a batch keeps every removal that succeeded even if others fail.
+With --atomic the relationships are removed one at a time, and on the first
+failure every relationship already removed by the batch is restored.
+
 ISSUE REFERENCES
 Issues can be referenced in multiple ways:
   • Simple number: 123 (same repository)
@@ -49,8 +56,10 @@ FLAGS
   --blocked-by string   Issue number(s) to remove from blocking this issue (comma-separated)
   --blocks string       Issue number(s) to remove from being blocked by this issue (comma-separated)  
   --all                 Remove all dependency relationships for this issue
+  --query string        Remove from every issue matching a GitHub search query
   --dry-run            Show what would be removed without making changes
-  --force              Skip confirmation prompts`,
+  --force              Skip confirmation prompts
+  --atomic             Restore the whole batch if any removal fails`,
 	Example: `  # Remove issue #456 from blocking issue #123
   gh issue-dependency remove 123 --blocked-by 456
 
@@ -73,17 +82,30 @@ FLAGS
   gh issue-dependency remove 123 --blocked-by 456,789,101
 
   # Work with issues in a different repository
-  gh issue-dependency remove 123 --blocks 456 --repo owner/other-repo`,
-	Args: cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		issueNumber := args[0]
+  gh issue-dependency remove 123 --blocks 456 --repo owner/other-repo
 
-		// Parse and validate the main issue number
-		_, issueNum, err := pkg.ParseIssueReference(issueNumber)
-		if err != nil {
+  # Remove #500 as a blocker from every closed area:api issue
+  gh issue-dependency remove --blocked-by 500 --query 'repo:org/app label:area:api is:closed'
+
+  # Remove all relationships, restoring them if any removal fails
+  gh issue-dependency remove 123 --all --atomic`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// The source issue comes either from the argument or from --query
+		if err := validateSourceSelection(args, removeQuery); err != nil {
 			return err
 		}
 
+		issueNumber := ""
+		if len(args) == 1 {
+			issueNumber = args[0]
+
+			// Parse and validate the main issue number
+			if _, _, err := pkg.ParseIssueReference(issueNumber); err != nil {
+				return err
+			}
+		}
+
 		// Validate flag mutual exclusion
 		flagCount := 0
 		if removeBlockedBy != "" {
@@ -114,67 +136,85 @@ FLAGS
 			).WithSuggestion("Choose exactly one of --blocked-by, --blocks, or --all")
 		}
 
+		if removeAll && removeQuery != "" {
+			return pkg.NewAppError(
+				pkg.ErrorTypeValidation,
+				"Cannot combine --all with --query",
+				nil,
+			).WithSuggestion("Use --query with --blocked-by or --blocks to remove specific relationships")
+		}
+
 		// Parse and validate dependency references (only for specific removals)
-		var dependencyRefs []string
+		var dependencyRefs string
 		var relationType string
 
 		if removeBlockedBy != "" {
-			dependencyRefs = strings.Split(removeBlockedBy, ",")
+			dependencyRefs = removeBlockedBy
 			relationType = "blocked-by"
-			// Validate all dependency references
-			for _, ref := range dependencyRefs {
-				ref = strings.TrimSpace(ref)
-				if ref == "" {
-					continue
-				}
-				_, _, err := pkg.ParseIssueReference(ref)
-				if err != nil {
-					return err
-				}
-			}
 		} else if removeBlocks != "" {
-			dependencyRefs = strings.Split(removeBlocks, ",")
+			dependencyRefs = removeBlocks
 			relationType = "blocks"
-			// Validate all dependency references
-			for _, ref := range dependencyRefs {
-				ref = strings.TrimSpace(ref)
-				if ref == "" {
-					continue
-				}
-				_, _, err := pkg.ParseIssueReference(ref)
-				if err != nil {
-					return err
-				}
-			}
 		} else if removeAll {
 			relationType = "all"
 		}
 
-		// Display removal preview if dry-run mode
-		if dryRun {
-			return pkg.WrapInternalError(
-				"dry-run mode",
-				fmt.Errorf("dry-run mode not implemented yet: would remove %s relationships for issue %d", relationType, issueNum),
-			).WithSuggestion("This feature is currently under development")
+		if err := validateDependencyRefs(dependencyRefs); err != nil {
+			return err
 		}
 
-		// TODO: Implement remove functionality based on relationType and force flag
-		if removeAll {
-			return pkg.WrapInternalError(
-				"removing all dependencies",
-				fmt.Errorf("remove command not implemented yet: removing all relationships for issue %d", issueNum),
-			).WithSuggestion("This feature is currently under development")
-		} else if removeBlockedBy != "" {
-			return pkg.WrapInternalError(
-				"removing blocked-by dependencies",
-				fmt.Errorf("remove command not implemented yet: removing issue %d blocked by %s", issueNum, removeBlockedBy),
-			).WithSuggestion("This feature is currently under development")
+		// Resolve repository context for unqualified issue numbers
+		var owner, repo string
+		var err error
+		if removeQuery != "" {
+			owner, repo, err = resolveQueryRepository(removeQuery)
 		} else {
-			return pkg.WrapInternalError(
-				"removing blocks dependencies",
-				fmt.Errorf("remove command not implemented yet: removing issue %d blocks %s", issueNum, removeBlocks),
-			).WithSuggestion("This feature is currently under development")
+			owner, repo, err = pkg.ResolveRepository(repoFlag, issueNumber)
+		}
+		if err != nil {
+			return err
 		}
+
+		remover, err := pkg.NewDependencyRemover()
+		if err != nil {
+			return err
+		}
+
+		opts := pkg.RemoveOptions{
+			DryRun: dryRun,
+			Force:  force,
+			Atomic: removeAtomic,
+		}
+
+		if relationType == "all" {
+			source, err := pkg.ParseIssueRefWithRepo(issueNumber, owner, repo)
+			if err != nil {
+				return err
+			}
+			return remover.RemoveAllRelationships(source, opts)
+		}
+
+		targets, err := parseDependencyTargets(dependencyRefs, owner, repo)
+		if err != nil {
+			return err
+		}
+
+		if removeQuery != "" {
+			return remover.RemoveForQuery(removeQuery, targets, relationType, pkg.BulkOptions{
+				DryRun: dryRun,
+				Force:  force,
+				Atomic: removeAtomic,
+			})
+		}
+
+		source, err := pkg.ParseIssueRefWithRepo(issueNumber, owner, repo)
+		if err != nil {
+			return err
+		}
+
+		if len(targets) == 1 {
+			return remover.RemoveRelationship(source, targets[0], relationType, opts)
+		}
+		return remover.RemoveBatchRelationships(source, targets, relationType, opts)
 	},
 }
 
@@ -196,6 +236,13 @@ var (
 
 	// force indicates whether to skip confirmation prompts during removal
 	force bool
+
+	// removeQuery contains a GitHub search query selecting the issues to modify.
+	// Every matching issue becomes a source issue for the removal.
+	removeQuery string
+
+	// removeAtomic indicates whether a failed removal restores the rest of the batch
+	removeAtomic bool
 )
 
 // init registers the remove command with the root command and sets up its flags.
@@ -209,4 +256,6 @@ func init() {
 	removeCmd.Flags().BoolVar(&removeAll, "all", false, "Remove all dependency relationships for this issue")
 	removeCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be removed without making changes")
 	removeCmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompts")
+	removeCmd.Flags().StringVar(&removeQuery, "query", "", "Remove the relationship from every issue matching a GitHub search query")
+	removeCmd.Flags().BoolVar(&removeAtomic, "atomic", false, "Restore the whole batch if any removal fails")
 }
@@ -0,0 +1,127 @@
+// Package cmd provides command-line interface commands for gh-issue-dependency.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate a self-contained HTML dependency report",
+	Long: `Crawl every issue in a repository and write a static HTML dependency report.
+
+The report is a single file that needs neither gh nor network access to view, so
+it can be attached to release tickets or published with GitHub Pages. It contains:
+
+  • A graph view of every blocking relationship
+  • A sortable table of blocked issues and their blockers
+  • Warnings for dependency cycles and stale blockers
+  • Links to every issue
+
+The crawled graph is inlined as JSON in a <script id="report-data"> element for
+use by other tools.
+
+FLAGS
+  -o, --output string    Write the report to a file instead of standard output
+  --stale-days int       Flag open blockers not updated for this many days (default 30)
+  --limit int            Maximum number of issues to crawl (default 1000)
+  -R, --repo OWNER/REPO  Repository to report on`,
+	Example: `  # Write a report for the current repository
+  gh issue-dependency report -o deps.html
+
+  # Report on another repository, flagging blockers idle for two weeks
+  gh issue-dependency report --repo owner/repo --stale-days 14 -o deps.html`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if reportStaleDays <= 0 {
+			return pkg.NewAppError(
+				pkg.ErrorTypeValidation,
+				fmt.Sprintf("Invalid --stale-days: %d", reportStaleDays),
+				nil,
+			).WithSuggestion("Use a positive number of days")
+		}
+		if reportLimit <= 0 {
+			return pkg.NewAppError(
+				pkg.ErrorTypeValidation,
+				fmt.Sprintf("Invalid --limit: %d", reportLimit),
+				nil,
+			).WithSuggestion("Use a positive number of issues")
+		}
+
+		owner, repo, err := pkg.ResolveRepository(repoFlag, "")
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		graph, err := pkg.CrawlRepository(ctx, owner, repo, pkg.CrawlOptions{Limit: reportLimit})
+		if err != nil {
+			return err
+		}
+
+		report := pkg.BuildReport(graph, pkg.ReportOptions{
+			StaleAfter: time.Duration(reportStaleDays) * 24 * time.Hour,
+		}, time.Now())
+
+		return writeReport(cmd.OutOrStdout(), reportOutput, report)
+	},
+}
+
+// Flags for report command
+var (
+	// reportOutput is the file the report is written to; standard output when empty
+	reportOutput string
+
+	// reportStaleDays is how long an open blocker can go without updates before it is flagged
+	reportStaleDays int
+
+	// reportLimit caps the number of issues crawled
+	reportLimit int
+)
+
+// writeReport writes report to path, or to stdout when path is empty or "-"
+func writeReport(stdout io.Writer, path string, report *pkg.Report) error {
+	if path == "" || path == "-" {
+		return pkg.WriteHTMLReport(stdout, report)
+	}
+
+	file, err := os.Create(path) // #nosec G304 -- path is the user's chosen output file
+	if err != nil {
+		return pkg.NewAppError(
+			pkg.ErrorTypeInternal,
+			fmt.Sprintf("Cannot create report file: %s", path),
+			err,
+		).WithContext("path", path).
+			WithSuggestion("Check that the directory exists and is writable")
+	}
+
+	if err := pkg.WriteHTMLReport(file, report); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return pkg.WrapInternalError("writing report file", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote dependency report for %s to %s\n", report.Graph.Repository, path)
+	return nil
+}
+
+// init registers the report command with the root command and sets up its flags.
+func init() {
+	rootCmd.AddCommand(reportCmd)
+
+	reportCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "Write the report to a file instead of standard output")
+	reportCmd.Flags().IntVar(&reportStaleDays, "stale-days", 30, "Flag open blockers not updated for this many days")
+	reportCmd.Flags().IntVar(&reportLimit, "limit", pkg.DefaultCrawlLimit, "Maximum number of issues to crawl")
+}
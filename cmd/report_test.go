@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+func TestReportCommandValidation(t *testing.T) {
+	originalStale, originalLimit := reportStaleDays, reportLimit
+	defer func() { reportStaleDays, reportLimit = originalStale, originalLimit }()
+
+	tests := []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{"zero stale days", []string{"--stale-days", "0"}, "Invalid --stale-days: 0"},
+		{"negative limit", []string{"--limit", "-5"}, "Invalid --limit: -5"},
+		{"positional argument", []string{"extra"}, "unknown command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "report", Args: reportCmd.Args, RunE: reportCmd.RunE}
+			cmd.Flags().IntVar(&reportStaleDays, "stale-days", 30, "")
+			cmd.Flags().IntVar(&reportLimit, "limit", pkg.DefaultCrawlLimit, "")
+			cmd.SetArgs(tt.args)
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	graph := pkg.NewDependencyGraph("owner/repo")
+	report := pkg.BuildReport(graph, pkg.ReportOptions{}, time.Now())
+
+	t.Run("stdout", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeReport(&buf, "-", report))
+		assert.Contains(t, buf.String(), "<!DOCTYPE html>")
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "deps.html")
+		var buf bytes.Buffer
+		require.NoError(t, writeReport(&buf, path, report))
+		assert.Empty(t, buf.String())
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "Dependency report for owner/repo")
+	})
+
+	t.Run("unwritable path", func(t *testing.T) {
+		err := writeReport(&bytes.Buffer{}, filepath.Join(t.TempDir(), "missing", "deps.html"), report)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Cannot create report file")
+	})
+}
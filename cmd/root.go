@@ -1,7 +1,7 @@
 // Package cmd implements all CLI commands for the gh-issue-dependency extension.
 //
 // This package contains the command-line interface built with Cobra, including
-// the root command and all subcommands (list, add, remove). Each command
+// the root command and all subcommands (list, add, remove, status, watch, history, undo, report, project-sync, sync-labels, notify, action, config, rate-limit). Each command
 // handles user input validation, interacts with the GitHub API through the
 // pkg package, and provides structured error messages.
 package cmd
@@ -34,9 +34,27 @@ CORE COMMANDS
   list     List issue dependencies and relationships
   add      Add dependency relationships between issues
   remove   Remove existing dependency relationships
+  status   Show what your issues are blocked on and who is waiting on you
+  watch    Poll an issue's dependencies and report changes
+
+JOURNAL COMMANDS
+  history  Show the local journal of dependency changes
+  undo     Undo journaled dependency changes
+
+REPORTING COMMANDS
+  report        Generate a self-contained HTML dependency report
+  project-sync  Reflect blocked state into a GitHub Project field
+  sync-labels   Keep a "blocked" label in step with open blockers
+  notify        Comment on the issues a closed blocker was blocking
+  action        React to an issues event inside a GitHub Actions workflow
+
+SETTINGS COMMANDS
+  config       Show and change settings and aliases
+  rate-limit   Show the remaining GitHub API request budgets
 
 FLAGS
   -R, --repo OWNER/REPO   Select repository using OWNER/REPO format
+  --max-requests int      Stop after this many GitHub API requests (default no limit)
 
 EXAMPLES
   # List all dependencies for issue #123
@@ -48,9 +66,17 @@ EXAMPLES
   # Remove a dependency relationship
   gh issue-dependency remove 123 --blocked-by 456
 
+  # Undo the most recent change
+  gh issue-dependency undo --last 1
+
   # Work with issues in a different repository
   gh issue-dependency list 123 --repo owner/other-repo
 
+SETTINGS
+  Defaults for flags such as list --format come from ~/.config/gh-issue-dependency/config.yml,
+  overridden by the repository's .github/issue-dependency.yml, then by GH_ISSUE_DEPENDENCY_*
+  environment variables, then by flags. Run 'gh issue-dependency config list' to see them.
+
 AUTHENTICATION
   This extension uses the same authentication as the GitHub CLI. Run 'gh auth status' 
   to check your authentication status. Use 'gh auth login' if you need to authenticate.
@@ -65,7 +91,29 @@ LEARN MORE
 }
 
 // Global flags accessible to all commands
-var repoFlag string
+var (
+	repoFlag string
+
+	// maxRequests caps the GitHub API requests a command may make; zero for no cap
+	maxRequests int
+)
+
+// Settings loaded by Execute before the command runs
+var (
+	// config holds the effective settings and aliases
+	config *pkg.Config
+
+	// configErr is why the settings could not be loaded; only the config
+	// command runs despite it, so the bad file can be fixed
+	configErr error
+)
+
+// configFlagDefaults maps command names to the flags whose defaults come from settings
+var configFlagDefaults = map[string]map[string]string{
+	"list":        {"format": "format", "sort": "sort", "state": "state"},
+	"sync-labels": {"label": "label", "color": "label_color"},
+	"action":      {"label": "label", "color": "label_color"},
+}
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
@@ -77,6 +125,13 @@ var repoFlag string
 //   - 3: Permission denied
 //   - 4: Authentication required
 func Execute() int {
+	config, configErr = pkg.LoadConfig()
+	if config != nil {
+		if args, ok := expandAlias(rootCmd, config, os.Args[1:]); ok {
+			rootCmd.SetArgs(args)
+		}
+	}
+
 	if err := rootCmd.Execute(); err != nil {
 		// Use our structured error formatting for user-friendly messages
 		fmt.Fprintf(os.Stderr, "%s\n", pkg.FormatUserError(err))
@@ -96,12 +151,90 @@ func Execute() int {
 	return 0
 }
 
+// expandAlias replaces an alias at the start of args with its expansion.
+// Aliases never shadow built-in commands.
+func expandAlias(root *cobra.Command, cfg *pkg.Config, args []string) ([]string, bool) {
+	if len(args) == 0 {
+		return nil, false
+	}
+	root.InitDefaultHelpCmd()
+	root.InitDefaultCompletionCmd()
+	if cmd, _, err := root.Find(args[:1]); err == nil && cmd != root {
+		return nil, false
+	}
+
+	expansion, ok := cfg.Alias(args[0])
+	if !ok {
+		return nil, false
+	}
+	expanded, err := pkg.SplitAliasArgs(expansion)
+	if err != nil {
+		return nil, false
+	}
+	return append(expanded, args[1:]...), true
+}
+
+// prepareCommand applies the settings and installs rate limiting before any command runs
+func prepareCommand(cmd *cobra.Command, args []string) error {
+	if err := applyConfig(cmd, args); err != nil {
+		return err
+	}
+	if maxRequests < 0 {
+		return pkg.NewAppError(
+			pkg.ErrorTypeValidation,
+			fmt.Sprintf("Invalid --max-requests: %d", maxRequests),
+			nil,
+		).WithSuggestion("Use a positive number, or leave the flag out for no limit")
+	}
+
+	pkg.LimitRequests(maxRequests)
+	return nil
+}
+
+// applyConfig puts the loaded settings into effect before a command runs and
+// fills in the flags the user did not set. Skipped entries of the repository
+// file are reported as warnings.
+func applyConfig(cmd *cobra.Command, args []string) error {
+	if configErr != nil {
+		if cmd.Name() == "config" || (cmd.Parent() != nil && cmd.Parent().Name() == "config") {
+			return nil
+		}
+		return configErr
+	}
+	if config == nil {
+		return nil
+	}
+
+	for _, warning := range config.Warnings {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", warning)
+	}
+
+	config.Apply()
+	for flagName, key := range configFlagDefaults[cmd.Name()] {
+		flag := cmd.Flags().Lookup(flagName)
+		if flag == nil || flag.Changed {
+			continue
+		}
+		// Set the value without marking the flag changed, so commands can
+		// still tell an explicit flag from a default
+		if err := flag.Value.Set(config.Value(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // init initializes the root command with global flags and configuration.
 // This function is called automatically when the package is imported.
 func init() {
 	// Global flags available to all commands
 	rootCmd.PersistentFlags().StringVarP(&repoFlag, "repo", "R", "", "Select another repository using the [HOST/]OWNER/REPO format")
 
+	rootCmd.PersistentFlags().IntVar(&maxRequests, "max-requests", 0, "Stop after this many GitHub API requests")
+
+	// Settings and rate limiting apply to every command
+	rootCmd.PersistentPreRunE = prepareCommand
+
 	// Configure version output template to match GitHub CLI style
 	rootCmd.SetVersionTemplate("gh-issue-dependency version {{.Version}}\n")
 }
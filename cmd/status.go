@@ -0,0 +1,133 @@
+// Package cmd provides command-line interface commands for gh-issue-dependency.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show what your issues are blocked on and who is waiting on you",
+	Long: `Show the dependency status of the open issues assigned to a user.
+
+Two questions are answered in one view:
+
+  WAITING ON       Open issues blocking your assigned issues, with their
+                   assignees and last activity
+  WAITING ON YOU   Open issues, assigned to other people or to nobody, that
+                   your assigned issues are blocking
+
+Issues are grouped under the assigned issue they relate to. When the output is
+not a terminal, one tab-separated row is written per relationship: type
+(blocked_by or blocking), your issue, the related issue, its title, assignees,
+and last update time.
+
+FLAGS
+  --user string           User whose assigned issues are checked (default "@me")
+  --org string            Only check issues in this organization or user account
+  --limit int             Maximum number of assigned issues to check (default 100)
+  --format string         Output format: table, json (default "table")
+  -q, --jq string         Filter JSON output using a jq expression
+  -t, --template string   Format JSON output using a Go template (see "gh help formatting")`,
+	Example: `  # What am I waiting on, and who is waiting on me?
+  gh issue-dependency status
+
+  # Only issues in one organization
+  gh issue-dependency status --org my-org
+
+  # Check a teammate's issues and get JSON for a standup bot
+  gh issue-dependency status --user octocat --format json
+
+  # Count my blocked issues
+  gh issue-dependency status --jq '.summary.blocked_count'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := resolveReportFormat(cmd, statusFormat, &statusExport)
+		if err != nil {
+			return err
+		}
+		if statusLimit <= 0 {
+			return pkg.NewAppError(
+				pkg.ErrorTypeValidation,
+				fmt.Sprintf("Invalid --limit: %d", statusLimit),
+				nil,
+			).WithSuggestion("Use a positive number of issues")
+		}
+
+		user, err := resolveStatusUser(statusUser)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		report, err := pkg.FetchStatus(ctx, pkg.StatusOptions{User: user, Org: statusOrg, Limit: statusLimit})
+		if err != nil {
+			return err
+		}
+
+		return writeStatusReport(cmd.OutOrStdout(), report, format, statusExport.options(), pkg.IsTerminal())
+	},
+}
+
+// Flags for status command
+var (
+	// statusUser is the user whose assigned issues are checked; "@me" for the authenticated user
+	statusUser string
+
+	// statusOrg limits the search to one organization or user account
+	statusOrg string
+
+	// statusLimit caps the number of assigned issues checked
+	statusLimit int
+
+	// statusFormat selects table or JSON output
+	statusFormat string
+
+	// statusExport holds the shared structured output flags (--template, --jq)
+	statusExport exportFlags
+)
+
+// resolveStatusUser returns the login for --user, looking up the
+// authenticated user for "@me"
+func resolveStatusUser(user string) (string, error) {
+	user = strings.TrimSpace(user)
+	if strings.EqualFold(user, pkg.FilterMe) {
+		return pkg.CurrentUserLogin()
+	}
+
+	user = strings.TrimPrefix(user, "@")
+	if user == "" {
+		return "", pkg.NewEmptyValueError("--user")
+	}
+	return user, nil
+}
+
+// writeStatusReport writes report in the requested format; table output is
+// grouped for terminals and tab-separated otherwise
+func writeStatusReport(w io.Writer, report *pkg.StatusReport, format string, opts pkg.ExportOptions, tty bool) error {
+	if format == "json" {
+		return pkg.ExportJSON(w, report, opts)
+	}
+	return pkg.FormatStatusReport(w, report, tty, pkg.TableWidth())
+}
+
+// init registers the status command with the root command and sets up its flags.
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().StringVar(&statusUser, "user", pkg.FilterMe, "User whose assigned issues are checked")
+	statusCmd.Flags().StringVar(&statusOrg, "org", "", "Only check issues in this organization or user account")
+	statusCmd.Flags().IntVar(&statusLimit, "limit", pkg.DefaultStatusLimit, "Maximum number of assigned issues to check")
+	statusCmd.Flags().StringVar(&statusFormat, "format", "table", "Output format: table, json")
+	addExportFlags(statusCmd, &statusExport)
+}
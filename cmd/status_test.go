@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+func TestStatusCommandValidation(t *testing.T) {
+	originalFormat, originalLimit, originalExport := statusFormat, statusLimit, statusExport
+	defer func() { statusFormat, statusLimit, statusExport = originalFormat, originalLimit, originalExport }()
+
+	tests := []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{"invalid format", []string{"--format", "csv"}, "Invalid format: csv"},
+		{"jq with template", []string{"--jq", ".user", "--template", "{{.user}}"}, "Cannot combine --jq with --template"},
+		{"zero limit", []string{"--limit", "0"}, "Invalid --limit: 0"},
+		{"positional argument", []string{"extra"}, "unknown command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusExport = exportFlags{}
+			cmd := &cobra.Command{Use: "status", Args: statusCmd.Args, RunE: statusCmd.RunE}
+			cmd.Flags().StringVar(&statusFormat, "format", "table", "")
+			cmd.Flags().IntVar(&statusLimit, "limit", 100, "")
+			addExportFlags(cmd, &statusExport)
+			cmd.SetArgs(tt.args)
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestResolveStatusUser(t *testing.T) {
+	user, err := resolveStatusUser("@octocat")
+	require.NoError(t, err)
+	assert.Equal(t, "octocat", user)
+
+	_, err = resolveStatusUser(" @ ")
+	assert.Error(t, err)
+}
+
+func TestWriteStatusReport(t *testing.T) {
+	report := &pkg.StatusReport{
+		User:    "octocat",
+		Issues:  []pkg.StatusEntry{},
+		Summary: pkg.StatusSummary{AssignedCount: 4, BlockedCount: 1},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeStatusReport(&buf, report, "json", pkg.ExportOptions{JQ: ".summary.blocked_count"}, false))
+	assert.Equal(t, "1\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeStatusReport(&buf, report, "json", pkg.ExportOptions{Template: "{{.user}} has {{.summary.assigned_count}} issues"}, false))
+	assert.Equal(t, "octocat has 4 issues", buf.String())
+}
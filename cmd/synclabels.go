@@ -0,0 +1,127 @@
+// Package cmd provides command-line interface commands for gh-issue-dependency.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+// syncLabelsCmd represents the sync-labels command
+var syncLabelsCmd = &cobra.Command{
+	Use:   "sync-labels",
+	Short: "Keep a \"blocked\" label in step with open blockers",
+	Long: `Add a label to every open issue that has an open blocker, and remove it from
+open issues whose blockers are all closed.
+
+Every open issue in the repository is checked. Only issues whose label does
+not match their blocked state are changed, so the command can be run as often
+as you like, for example from cron or a scheduled GitHub Actions workflow.
+If the repository has no label with the given name, it is created with the
+given color the first time an issue needs it.
+
+Closed issues are not changed. Managing labels needs triage or write access.
+
+FLAGS
+  --label string          Label marking blocked issues (default "blocked")
+  --color string          Hex color for the label if it has to be created (default "b60205")
+  --dry-run               Show the changes without making them
+  --format string         Output format: table, json (default "table")
+  -q, --jq string         Filter JSON output using a jq expression
+  -t, --template string   Format JSON output using a Go template (see "gh help formatting")`,
+	Example: `  # Label blocked issues in the current repository
+  gh issue-dependency sync-labels
+
+  # Preview the changes in another repository
+  gh issue-dependency sync-labels --repo owner/repo --dry-run
+
+  # Use a different label
+  gh issue-dependency sync-labels --label "status: blocked" --color "#fbca04"
+
+  # List the issue numbers that would change
+  gh issue-dependency sync-labels --dry-run --jq '.changes[].number'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := resolveReportFormat(cmd, syncLabelsFormat, &syncLabelsExport)
+		if err != nil {
+			return err
+		}
+		if _, err := pkg.NormalizeLabelColor(syncLabelsColor); err != nil {
+			return err
+		}
+
+		owner, repo, err := pkg.ResolveRepository(repoFlag, "")
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		report, err := pkg.SyncLabels(ctx, pkg.LabelSyncOptions{
+			Owner:  owner,
+			Repo:   repo,
+			Label:  syncLabelsLabel,
+			Color:  syncLabelsColor,
+			DryRun: syncLabelsDryRun,
+		})
+		if report != nil {
+			if writeErr := writeLabelSyncReport(cmd.OutOrStdout(), report, format, syncLabelsExport.options()); writeErr != nil {
+				return writeErr
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		if failed := report.Failed(); failed > 0 {
+			return pkg.NewAppError(
+				pkg.ErrorTypeAPI,
+				fmt.Sprintf("Failed to update the label on %d of %d issues", failed, len(report.Changes)),
+				nil,
+			).WithSuggestion("Run the command again to retry the failed issues")
+		}
+		return nil
+	},
+}
+
+// Flags for sync-labels command
+var (
+	// syncLabelsLabel is the label marking blocked issues
+	syncLabelsLabel string
+
+	// syncLabelsColor is the color used when the label has to be created
+	syncLabelsColor string
+
+	// syncLabelsDryRun reports the changes without making them
+	syncLabelsDryRun bool
+
+	// syncLabelsFormat selects table or JSON output
+	syncLabelsFormat string
+
+	// syncLabelsExport holds the shared structured output flags (--template, --jq)
+	syncLabelsExport exportFlags
+)
+
+// writeLabelSyncReport writes report in the requested format
+func writeLabelSyncReport(w io.Writer, report *pkg.LabelSyncReport, format string, opts pkg.ExportOptions) error {
+	if format == "json" {
+		return pkg.ExportJSON(w, report, opts)
+	}
+	return pkg.FormatLabelSyncReport(w, report)
+}
+
+// init registers the sync-labels command with the root command and sets up its flags.
+func init() {
+	rootCmd.AddCommand(syncLabelsCmd)
+
+	syncLabelsCmd.Flags().StringVar(&syncLabelsLabel, "label", pkg.DefaultBlockedLabel, "Label marking blocked issues")
+	syncLabelsCmd.Flags().StringVar(&syncLabelsColor, "color", pkg.DefaultBlockedLabelColor, "Hex color for the label if it has to be created")
+	syncLabelsCmd.Flags().BoolVar(&syncLabelsDryRun, "dry-run", false, "Show the changes without making them")
+	syncLabelsCmd.Flags().StringVar(&syncLabelsFormat, "format", "table", "Output format: table, json")
+	addExportFlags(syncLabelsCmd, &syncLabelsExport)
+}
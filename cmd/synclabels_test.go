@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+func TestSyncLabelsCommandValidation(t *testing.T) {
+	originalColor, originalFormat, originalExport := syncLabelsColor, syncLabelsFormat, syncLabelsExport
+	defer func() {
+		syncLabelsColor, syncLabelsFormat, syncLabelsExport = originalColor, originalFormat, originalExport
+	}()
+
+	tests := []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{"invalid format", []string{"--format", "csv"}, "Invalid format: csv"},
+		{"jq with table format", []string{"--format", "table", "--jq", ".changes"}, "Cannot combine --template or --jq with --format table"},
+		{"invalid color", []string{"--color", "red"}, "Invalid label color: red"},
+		{"positional argument", []string{"extra"}, "unknown command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncLabelsExport = exportFlags{}
+			cmd := &cobra.Command{Use: "sync-labels", Args: syncLabelsCmd.Args, RunE: syncLabelsCmd.RunE}
+			cmd.Flags().StringVar(&syncLabelsColor, "color", pkg.DefaultBlockedLabelColor, "")
+			cmd.Flags().StringVar(&syncLabelsFormat, "format", "table", "")
+			addExportFlags(cmd, &syncLabelsExport)
+			cmd.SetArgs(tt.args)
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestWriteLabelSyncReport(t *testing.T) {
+	report := &pkg.LabelSyncReport{
+		Repository:    "org/app",
+		Label:         "blocked",
+		IssuesChecked: 3,
+		Changes:       []pkg.LabelSyncChange{},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeLabelSyncReport(&buf, report, "table", pkg.ExportOptions{}))
+	assert.Equal(t, "org/app: 3 open issues checked, 0 blocked\nLabel \"blocked\" is up to date\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeLabelSyncReport(&buf, report, "json", pkg.ExportOptions{}))
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, []interface{}{}, decoded["changes"])
+	assert.Equal(t, false, decoded["label_created"])
+
+	buf.Reset()
+	require.NoError(t, writeLabelSyncReport(&buf, report, "json", pkg.ExportOptions{JQ: ".issues_checked"}))
+	assert.Equal(t, "3\n", buf.String())
+}
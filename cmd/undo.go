@@ -0,0 +1,118 @@
+// Package cmd provides command-line interface commands for gh-issue-dependency.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+// undoCmd represents the undo command
+var undoCmd = &cobra.Command{
+	Use:   "undo [<op-id>]",
+	Short: "Undo journaled dependency changes",
+	Long: `Undo dependency changes recorded in the local journal by applying their inverse.
+
+An undone addition removes the relationship again, and an undone removal
+re-creates it. Use 'gh issue-dependency history' to find operation IDs.
+
+SELECTING OPERATIONS
+You must specify exactly one of:
+
+  <op-id>     Undo a single operation by its ID
+  --last N    Undo the N most recent operations that have not been undone
+
+Operations are undone newest first. Undo operations are journaled too, so an
+undo can itself be reverted by passing its ID.
+
+SAFETY AND CONFIRMATION
+The command will:
+  • Check that each operation changed the relationship from the prior state
+    the journal recorded, and that the relationship is still as it left it
+  • Show which relationships will change before making changes
+  • Prompt for confirmation unless --force is specified
+  • Support dry-run mode with --dry-run to preview changes without executing
+
+FLAGS
+  --last int    Undo the N most recent operations
+  --dry-run     Show what would be undone without making changes
+  --force       Skip confirmation prompts
+  --atomic      Re-apply every undone operation if any undo step fails`,
+	Example: `  # Undo the most recent operation
+  gh issue-dependency undo --last 1
+
+  # Undo a specific operation from the history
+  gh issue-dependency undo 3f9a2c1e
+
+  # Preview undoing the last three operations
+  gh issue-dependency undo --last 3 --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opID := ""
+		if len(args) == 1 {
+			opID = args[0]
+		}
+
+		if opID == "" && !cmd.Flags().Changed("last") {
+			return pkg.NewAppError(
+				pkg.ErrorTypeValidation,
+				"Must specify an operation ID or --last",
+				nil,
+			).WithSuggestion("Use 'gh issue-dependency history' to find operation IDs").
+				WithSuggestion("Use --last 1 to undo the most recent operation")
+		}
+
+		if opID != "" && cmd.Flags().Changed("last") {
+			return pkg.NewAppError(
+				pkg.ErrorTypeValidation,
+				"Cannot specify both an operation ID and --last",
+				nil,
+			).WithSuggestion("Use either an operation ID or --last, not both")
+		}
+
+		entries, err := pkg.ReadJournal()
+		if err != nil {
+			return err
+		}
+
+		selected, err := pkg.SelectUndoEntries(entries, opID, undoLast)
+		if err != nil {
+			return err
+		}
+
+		undoer, err := pkg.NewUndoer()
+		if err != nil {
+			return err
+		}
+
+		return undoer.Undo(selected, pkg.UndoOptions{
+			DryRun: undoDryRun,
+			Force:  undoForce,
+			Atomic: undoAtomic,
+		})
+	},
+}
+
+// Flags for undo command
+var (
+	// undoLast is the number of most recent operations to undo
+	undoLast int
+
+	// undoDryRun indicates whether to show what would be undone without making changes
+	undoDryRun bool
+
+	// undoForce indicates whether to skip confirmation prompts
+	undoForce bool
+
+	// undoAtomic indicates whether a failed undo step re-applies the rest of the batch
+	undoAtomic bool
+)
+
+// init registers the undo command with the root command and sets up its flags.
+func init() {
+	rootCmd.AddCommand(undoCmd)
+
+	undoCmd.Flags().IntVar(&undoLast, "last", 0, "Undo the N most recent operations")
+	undoCmd.Flags().BoolVar(&undoDryRun, "dry-run", false, "Show what would be undone without making changes")
+	undoCmd.Flags().BoolVar(&undoForce, "force", false, "Skip confirmation prompts")
+	undoCmd.Flags().BoolVar(&undoAtomic, "atomic", false, "Re-apply every undone operation if any undo step fails")
+}
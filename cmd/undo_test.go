@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUndoCommandValidation(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	original := undoLast
+	defer func() { undoLast = original }()
+
+	tests := []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{"nothing selected", nil, "Must specify an operation ID or --last"},
+		{"id and last", []string{"abcd", "--last", "1"}, "Cannot specify both"},
+		{"unknown id", []string{"abcd"}, "Operation not found in journal"},
+		{"empty journal", []string{"--last", "1"}, "No operations to undo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "undo", Args: undoCmd.Args, RunE: undoCmd.RunE}
+			cmd.Flags().IntVar(&undoLast, "last", 0, "")
+			cmd.SetArgs(tt.args)
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestHistoryCommand(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cmd := &cobra.Command{Use: "history", Args: historyCmd.Args, RunE: historyCmd.RunE}
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs(nil)
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, buf.String(), "No operations recorded")
+}
@@ -0,0 +1,174 @@
+// Package cmd provides command-line interface commands for gh-issue-dependency.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch <issue>",
+	Short: "Poll an issue's dependencies and report changes",
+	Long: `Poll an issue's dependencies and report when they change.
+
+A line is printed whenever a blocker is closed or reopened, or a blocked-by or
+blocking relationship is added or removed. Each poll bypasses the dependency
+cache but uses conditional requests, so polls that find nothing new do not
+count against your API rate limit.
+
+NOTIFICATIONS
+  --bell    Ring the terminal bell on every change
+  --exec    Run a shell command on every change. The change is described in
+            environment variables:
+              GH_ISSUE_DEPENDENCY_SOURCE    The watched issue (owner/repo#number)
+              GH_ISSUE_DEPENDENCY_EVENT     closed, reopened, added, or removed
+              GH_ISSUE_DEPENDENCY_RELATION  blocked_by or blocking
+              GH_ISSUE_DEPENDENCY_ISSUE     The related issue (owner/repo#number)
+              GH_ISSUE_DEPENDENCY_TITLE     The related issue's title
+              GH_ISSUE_DEPENDENCY_STATE     The related issue's state
+              GH_ISSUE_DEPENDENCY_MESSAGE   A one-line description of the change
+
+GATING SCRIPTS
+With --until-unblocked the command exits with status 0 as soon as the issue
+has no open blockers, including right away when it has none. Press Ctrl+C to
+stop watching otherwise; with --until-unblocked an interrupted watch exits
+with status 1, since the issue is still blocked.
+
+FLAGS
+  --interval duration   Time between polls, at least 10s (default 2m0s)
+  --until-unblocked     Exit once the issue has no open blockers
+  --bell                Ring the terminal bell on every change
+  --exec string         Run a shell command on every change`,
+	Example: `  # Watch issue #123 until you stop it
+  gh issue-dependency watch 123
+
+  # Check every 30 seconds and get a desktop notification
+  gh issue-dependency watch 123 --interval 30s --exec 'notify-send "$GH_ISSUE_DEPENDENCY_MESSAGE"'
+
+  # Start the deploy once every blocker is closed
+  gh issue-dependency watch 123 --until-unblocked && ./deploy.sh`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if watchInterval < pkg.MinWatchInterval {
+			return pkg.NewAppError(
+				pkg.ErrorTypeValidation,
+				fmt.Sprintf("Invalid --interval: %s", watchInterval),
+				nil,
+			).WithSuggestion(fmt.Sprintf("Use an interval of at least %s", pkg.MinWatchInterval))
+		}
+
+		owner, repo, err := pkg.ResolveRepository(repoFlag, args[0])
+		if err != nil {
+			return err
+		}
+		_, issueNum, err := pkg.ParseIssueReference(args[0])
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		source := fmt.Sprintf("%s/%s#%d", owner, repo, issueNum)
+		watcher := &dependencyWatcher{
+			ctx:            ctx,
+			out:            cmd.OutOrStdout(),
+			source:         source,
+			bell:           watchBell,
+			exec:           watchExec,
+			untilUnblocked: watchUntilUnblocked,
+			interval:       watchInterval,
+		}
+
+		return pkg.WatchIssueDependencies(ctx, owner, repo, issueNum, pkg.WatchOptions{
+			Interval:       watchInterval,
+			UntilUnblocked: watchUntilUnblocked,
+			OnUpdate:       watcher.update,
+			OnError: func(err error) {
+				fmt.Fprintf(os.Stderr, "Warning: poll failed, retrying in %s: %v\n", watchInterval, err)
+			},
+		})
+	},
+}
+
+// Flags for watch command
+var (
+	// watchInterval is the time between polls
+	watchInterval time.Duration
+
+	// watchUntilUnblocked exits once the issue has no open blockers
+	watchUntilUnblocked bool
+
+	// watchBell rings the terminal bell on every change
+	watchBell bool
+
+	// watchExec is a shell command run on every change
+	watchExec string
+)
+
+// dependencyWatcher reports the updates of a running watch
+type dependencyWatcher struct {
+	ctx            context.Context // Stops --exec commands when the watch is interrupted
+	out            io.Writer
+	source         string
+	bell           bool
+	exec           string
+	untilUnblocked bool
+	interval       time.Duration
+	now            func() time.Time
+}
+
+// update prints the initial state, then one line per change, and runs the
+// configured notifications. --exec failures are warnings, so a broken
+// notifier does not stop the watch.
+func (w *dependencyWatcher) update(events []pkg.WatchEvent, data *pkg.DependencyData) error {
+	now := time.Now
+	if w.now != nil {
+		now = w.now
+	}
+	ctx := w.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	stamp := now().Format("15:04:05")
+	open := pkg.OpenBlockerCount(data)
+
+	if events == nil {
+		fmt.Fprintf(w.out, "%s  Watching %s: %d open blockers, checking every %s\n", stamp, w.source, open, w.interval)
+	}
+
+	for _, event := range events {
+		fmt.Fprintf(w.out, "%s  %s\n", stamp, event.String())
+		if w.exec != "" {
+			if err := pkg.RunWatchCommand(ctx, w.exec, w.source, event); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+	}
+	if len(events) > 0 && w.bell {
+		fmt.Fprint(w.out, "\a")
+	}
+
+	if w.untilUnblocked && open == 0 {
+		fmt.Fprintf(w.out, "%s  %s has no open blockers\n", stamp, w.source)
+	}
+	return nil
+}
+
+// init registers the watch command with the root command and sets up its flags.
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 2*time.Minute, "Time between polls, at least 10s")
+	watchCmd.Flags().BoolVar(&watchUntilUnblocked, "until-unblocked", false, "Exit once the issue has no open blockers")
+	watchCmd.Flags().BoolVar(&watchBell, "bell", false, "Ring the terminal bell on every change")
+	watchCmd.Flags().StringVar(&watchExec, "exec", "", "Run a shell command on every change")
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/torynet/gh-issue-dependency/pkg"
+)
+
+func TestWatchCommandValidation(t *testing.T) {
+	originalInterval := watchInterval
+	defer func() { watchInterval = originalInterval }()
+
+	tests := []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{"interval too short", []string{"123", "--interval", "5s"}, "Invalid --interval: 5s"},
+		{"missing issue", []string{}, "accepts 1 arg(s)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "watch", Args: watchCmd.Args, RunE: watchCmd.RunE}
+			cmd.Flags().DurationVar(&watchInterval, "interval", 2*time.Minute, "")
+			cmd.SetArgs(tt.args)
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestDependencyWatcherUpdate(t *testing.T) {
+	var buf bytes.Buffer
+	watcher := &dependencyWatcher{
+		out:            &buf,
+		source:         "owner/repo#123",
+		bell:           true,
+		untilUnblocked: true,
+		interval:       time.Minute,
+		now:            func() time.Time { return time.Date(2024, 6, 1, 9, 30, 0, 0, time.UTC) },
+	}
+
+	blocked := &pkg.DependencyData{BlockedBy: []pkg.DependencyRelation{{Issue: pkg.Issue{Number: 45, State: "open"}}}}
+	require.NoError(t, watcher.update(nil, blocked))
+	assert.Equal(t, "09:30:00  Watching owner/repo#123: 1 open blockers, checking every 1m0s\n", buf.String())
+
+	buf.Reset()
+	unblocked := &pkg.DependencyData{BlockedBy: []pkg.DependencyRelation{{Issue: pkg.Issue{Number: 45, State: "closed"}}}}
+	events := []pkg.WatchEvent{{Type: pkg.WatchEventClosed, Relation: "blocked_by", Issue: "owner/repo#45", Title: "Schema", State: "closed"}}
+	require.NoError(t, watcher.update(events, unblocked))
+	assert.Equal(t,
+		"09:30:00  Blocker owner/repo#45 closed: Schema\n\a09:30:00  owner/repo#123 has no open blockers\n",
+		buf.String())
+}
+
+func TestDependencyWatcherExec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	path := filepath.Join(t.TempDir(), "event")
+	watcher := &dependencyWatcher{
+		out:    &bytes.Buffer{},
+		source: "owner/repo#123",
+		exec:   `printf '%s %s %s' "$GH_ISSUE_DEPENDENCY_SOURCE" "$GH_ISSUE_DEPENDENCY_EVENT" "$GH_ISSUE_DEPENDENCY_ISSUE" > ` + path,
+	}
+
+	events := []pkg.WatchEvent{{Type: pkg.WatchEventAdded, Relation: "blocked_by", Issue: "owner/repo#46", State: "open"}}
+	require.NoError(t, watcher.update(events, &pkg.DependencyData{}))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "owner/repo#123 added owner/repo#46", string(content))
+}
+
+func TestDependencyWatcherExecInterrupted(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	path := filepath.Join(t.TempDir(), "event")
+	watcher := &dependencyWatcher{
+		ctx:    ctx,
+		out:    &bytes.Buffer{},
+		source: "owner/repo#123",
+		exec:   "touch " + path,
+	}
+
+	events := []pkg.WatchEvent{{Type: pkg.WatchEventClosed, Relation: "blocked_by", Issue: "owner/repo#45", State: "closed"}}
+	require.NoError(t, watcher.update(events, &pkg.DependencyData{}))
+
+	// The watch context was canceled, so the command is never started
+	assert.NoFileExists(t, path)
+}
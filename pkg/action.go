@@ -0,0 +1,411 @@
+// Package pkg provides a GitHub Actions mode driven by the event payload.
+//
+// A workflow triggered when issues close or reopen runs the action command,
+// which reads the event, runs the configured reactions (label sync, unblock
+// comments, and project field updates), reports each outcome as a workflow
+// annotation, and renders a Markdown step summary.
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Actions environment variables read by the action command
+const (
+	EnvGitHubEventPath   = "GITHUB_EVENT_PATH"
+	EnvGitHubRepository  = "GITHUB_REPOSITORY"
+	EnvGitHubStepSummary = "GITHUB_STEP_SUMMARY"
+)
+
+// Reactions run by the action command
+const (
+	ActionReactionLabels  = "sync-labels"
+	ActionReactionNotify  = "notify"
+	ActionReactionProject = "project-sync"
+)
+
+// Outcomes of a reaction
+const (
+	ActionStatusOK      = "ok"
+	ActionStatusSkipped = "skipped"
+	ActionStatusFailed  = "failed"
+)
+
+// ActionEvent is the part of an issues event payload used by the action command
+type ActionEvent struct {
+	Action     string                 `json:"action"` // closed, reopened, ...
+	Issue      *ActionEventIssue      `json:"issue"`
+	Repository *ActionEventRepository `json:"repository"`
+}
+
+// ActionEventIssue is the issue an event is about
+type ActionEventIssue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+}
+
+// ActionEventRepository is the repository an event happened in
+type ActionEventRepository struct {
+	FullName string `json:"full_name"` // owner/repo
+}
+
+// ReadActionEvent reads and checks the event payload at path
+func ReadActionEvent(path string) (*ActionEvent, error) {
+	if path == "" {
+		return nil, NewAppError(
+			ErrorTypeValidation,
+			"No event payload",
+			nil,
+		).WithSuggestion(fmt.Sprintf("Run inside a GitHub Actions workflow, where %s is set", EnvGitHubEventPath)).
+			WithSuggestion("Or pass a saved event payload with --event")
+	}
+
+	content, err := os.ReadFile(path) // #nosec G304 -- path comes from the runner or the user's --event value
+	if err != nil {
+		return nil, NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Cannot read event payload: %s", path),
+			err,
+		)
+	}
+
+	var event ActionEvent
+	if err := json.Unmarshal(content, &event); err != nil {
+		return nil, NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Event payload is not valid JSON: %s", path),
+			err,
+		)
+	}
+	if event.Issue == nil || event.Issue.Number <= 0 {
+		return nil, NewAppError(
+			ErrorTypeValidation,
+			"Event payload has no issue",
+			nil,
+		).WithSuggestion("Trigger the workflow on issues events, e.g. on: issues: types: [closed, reopened]")
+	}
+
+	return &event, nil
+}
+
+// ActionOptions configures the reactions run by an ActionRunner
+type ActionOptions struct {
+	Owner string
+	Repo  string
+
+	SyncLabels bool   // Run label sync on the repository
+	Label      string // Label for label sync
+	Color      string // Color for label sync
+
+	Notify          bool   // Comment on dependents when the issue closes
+	CommentTemplate string // Comment template for notify
+
+	Project      *ProjectRef // Run project sync on this project when set
+	Field        string      // Field for project sync
+	BlockedValue string      // Blocked value for project sync
+
+	DryRun bool // Report the changes without making them
+}
+
+// ActionReaction is the outcome of one reaction
+type ActionReaction struct {
+	Name    string // One of the ActionReaction constants
+	Status  string // One of the ActionStatus constants
+	Summary string // One-line description of what happened
+	Details []string
+	Errors  []string
+}
+
+// ActionResult is the outcome of running the action for one event
+type ActionResult struct {
+	Event     string // closed, reopened, ...
+	Issue     string // owner/repo#number
+	Title     string
+	DryRun    bool
+	Reactions []ActionReaction
+}
+
+// Failed reports whether any reaction failed
+func (r *ActionResult) Failed() bool {
+	for _, reaction := range r.Reactions {
+		if reaction.Status == ActionStatusFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// ActionRunner runs the configured reactions for an issues event
+type ActionRunner struct {
+	opts ActionOptions
+	out  io.Writer // Workflow commands are written here
+
+	syncLabels  func(context.Context, LabelSyncOptions) (*LabelSyncReport, error)
+	notify      func(context.Context, string, string, int, NotifyOptions) (*NotifyReport, error)
+	syncProject func(context.Context, ProjectSyncOptions) (*ProjectSyncReport, error)
+}
+
+// NewActionRunner returns a runner that writes workflow commands to out
+func NewActionRunner(opts ActionOptions, out io.Writer) *ActionRunner {
+	return &ActionRunner{
+		opts:        opts,
+		out:         out,
+		syncLabels:  SyncLabels,
+		notify:      NotifyDependents,
+		syncProject: SyncProject,
+	}
+}
+
+// Run performs the reactions for event. Label and project sync run for
+// closed and reopened issues, and dependents are notified for closed ones.
+// Other events are acknowledged with a notice and do nothing.
+func (r *ActionRunner) Run(ctx context.Context, event *ActionEvent) *ActionResult {
+	issue := fmt.Sprintf("%s/%s#%d", r.opts.Owner, r.opts.Repo, event.Issue.Number)
+	result := &ActionResult{Event: event.Action, Issue: issue, Title: event.Issue.Title, DryRun: r.opts.DryRun}
+
+	if event.Action != "closed" && event.Action != "reopened" {
+		r.annotate("notice", "gh-issue-dependency", fmt.Sprintf("Nothing to do for %s %s", issue, event.Action))
+		return result
+	}
+
+	if r.opts.SyncLabels {
+		result.Reactions = append(result.Reactions, r.runLabelSync(ctx))
+	}
+	if r.opts.Notify {
+		if event.Action == "closed" {
+			result.Reactions = append(result.Reactions, r.runNotify(ctx, event.Issue.Number))
+		} else {
+			result.Reactions = append(result.Reactions, ActionReaction{
+				Name:    ActionReactionNotify,
+				Status:  ActionStatusSkipped,
+				Summary: "Dependents are only notified when an issue closes",
+			})
+		}
+	}
+	if r.opts.Project != nil {
+		result.Reactions = append(result.Reactions, r.runProjectSync(ctx))
+	}
+
+	for _, reaction := range result.Reactions {
+		for _, message := range reaction.Errors {
+			r.annotate("error", reaction.Name, message)
+		}
+		if reaction.Status != ActionStatusFailed {
+			r.annotate("notice", reaction.Name, reaction.Summary)
+		}
+	}
+	return result
+}
+
+// runLabelSync runs label sync on the repository
+func (r *ActionRunner) runLabelSync(ctx context.Context) ActionReaction {
+	reaction := ActionReaction{Name: ActionReactionLabels}
+	report, err := r.syncLabels(ctx, LabelSyncOptions{
+		Owner:  r.opts.Owner,
+		Repo:   r.opts.Repo,
+		Label:  r.opts.Label,
+		Color:  r.opts.Color,
+		DryRun: r.opts.DryRun,
+	})
+	if err != nil {
+		return failedReaction(reaction, err)
+	}
+
+	added, removed := 0, 0
+	for _, change := range report.Changes {
+		switch {
+		case change.Status == StatusFailed:
+			reaction.Errors = append(reaction.Errors, fmt.Sprintf("%s: %s", change.Issue, change.Error))
+		case change.Action == LabelSyncActionAdd:
+			added++
+			reaction.Details = append(reaction.Details, fmt.Sprintf("%s `%s` to %s",
+				actionVerb(r.opts.DryRun, "Added", "Would add"), report.Label, change.Issue))
+		default:
+			removed++
+			reaction.Details = append(reaction.Details, fmt.Sprintf("%s `%s` from %s",
+				actionVerb(r.opts.DryRun, "Removed", "Would remove"), report.Label, change.Issue))
+		}
+	}
+
+	reaction.Summary = fmt.Sprintf("Label %q %s to %d issues and removed from %d (%d open issues, %d blocked)",
+		report.Label, actionVerb(r.opts.DryRun, "added", "would be added"), added, removed, report.IssuesChecked, report.Blocked)
+	return finishReaction(reaction)
+}
+
+// runNotify comments on the dependents of the closed issue
+func (r *ActionRunner) runNotify(ctx context.Context, number int) ActionReaction {
+	reaction := ActionReaction{Name: ActionReactionNotify}
+	report, err := r.notify(ctx, r.opts.Owner, r.opts.Repo, number, NotifyOptions{
+		Template: r.opts.CommentTemplate,
+		DryRun:   r.opts.DryRun,
+	})
+	if err != nil {
+		return failedReaction(reaction, err)
+	}
+
+	commented, skipped := 0, 0
+	for _, result := range report.Dependents {
+		switch result.Status {
+		case StatusFailed:
+			reaction.Errors = append(reaction.Errors, fmt.Sprintf("%s: %s", result.Issue, result.Error))
+		case StatusAlreadyNotified:
+			skipped++
+		default:
+			commented++
+			reaction.Details = append(reaction.Details, fmt.Sprintf("%s on %s: %s",
+				actionVerb(r.opts.DryRun, "Commented", "Would comment"), result.Issue, result.Comment))
+		}
+	}
+
+	reaction.Summary = fmt.Sprintf("%s on %d dependents, %d already notified",
+		actionVerb(r.opts.DryRun, "Commented", "Would comment"), commented, skipped)
+	return finishReaction(reaction)
+}
+
+// runProjectSync runs project sync on the configured project
+func (r *ActionRunner) runProjectSync(ctx context.Context) ActionReaction {
+	reaction := ActionReaction{Name: ActionReactionProject}
+	report, err := r.syncProject(ctx, ProjectSyncOptions{
+		Project:      *r.opts.Project,
+		Field:        r.opts.Field,
+		BlockedValue: r.opts.BlockedValue,
+		DryRun:       r.opts.DryRun,
+	})
+	if err != nil {
+		return failedReaction(reaction, err)
+	}
+
+	updated := 0
+	for _, change := range report.Changes {
+		if change.Status == StatusFailed {
+			reaction.Errors = append(reaction.Errors, fmt.Sprintf("%s: %s", change.Issue, change.Error))
+			continue
+		}
+		updated++
+		reaction.Details = append(reaction.Details, fmt.Sprintf("%s: %s → %s",
+			change.Issue, displayFieldValue(change.From), displayFieldValue(change.To)))
+	}
+
+	reaction.Summary = fmt.Sprintf("%s %s on %d items of project %s (%d items, %d blocked)",
+		actionVerb(r.opts.DryRun, "Updated", "Would update"), report.Field, updated, report.Project,
+		report.ItemsChecked, report.Blocked)
+	return finishReaction(reaction)
+}
+
+// failedReaction records an error that stopped a reaction
+func failedReaction(reaction ActionReaction, err error) ActionReaction {
+	reaction.Status = ActionStatusFailed
+	reaction.Summary = err.Error()
+	reaction.Errors = append(reaction.Errors, err.Error())
+	return reaction
+}
+
+// finishReaction sets the status from the errors collected
+func finishReaction(reaction ActionReaction) ActionReaction {
+	reaction.Status = ActionStatusOK
+	if len(reaction.Errors) > 0 {
+		reaction.Status = ActionStatusFailed
+	}
+	return reaction
+}
+
+// actionVerb picks the verb for a real or dry run
+func actionVerb(dryRun bool, verb, dryRunVerb string) string {
+	if dryRun {
+		return dryRunVerb
+	}
+	return verb
+}
+
+// annotate writes a workflow command such as ::notice title=notify::message
+func (r *ActionRunner) annotate(level, title, message string) {
+	fmt.Fprintf(r.out, "::%s title=%s::%s\n", level, escapeWorkflowProperty(title), escapeWorkflowData(message))
+}
+
+// escapeWorkflowData escapes a workflow command message
+func escapeWorkflowData(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(value)
+}
+
+// escapeWorkflowProperty escapes a workflow command property value
+func escapeWorkflowProperty(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(value)
+}
+
+// FormatActionSummary writes result as Markdown for the workflow step summary
+func FormatActionSummary(w io.Writer, result *ActionResult) error {
+	title := fmt.Sprintf("%s %s", result.Issue, result.Event)
+	if result.DryRun {
+		title += " (dry run)"
+	}
+	fmt.Fprintf(w, "## Issue dependencies: %s\n\n", title)
+
+	if len(result.Reactions) == 0 {
+		fmt.Fprintf(w, "Nothing to do for %s events.\n", result.Event)
+		return nil
+	}
+
+	fmt.Fprintln(w, "| Reaction | Result | Details |")
+	fmt.Fprintln(w, "|----------|--------|---------|")
+	for _, reaction := range result.Reactions {
+		fmt.Fprintf(w, "| %s | %s %s | %s |\n",
+			reaction.Name, actionStatusSymbol(reaction.Status), reaction.Status, escapeMarkdownCell(reaction.Summary))
+	}
+
+	for _, reaction := range result.Reactions {
+		if len(reaction.Details) == 0 && len(reaction.Errors) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n### %s\n\n", reaction.Name)
+		for _, detail := range reaction.Details {
+			fmt.Fprintf(w, "- %s\n", detail)
+		}
+		for _, message := range reaction.Errors {
+			fmt.Fprintf(w, "- ❌ %s\n", message)
+		}
+	}
+	return nil
+}
+
+// actionStatusSymbol returns the marker for a reaction status
+func actionStatusSymbol(status string) string {
+	switch status {
+	case ActionStatusOK:
+		return "✅"
+	case ActionStatusSkipped:
+		return "⏭️"
+	default:
+		return "❌"
+	}
+}
+
+// escapeMarkdownCell keeps text on one line inside a Markdown table cell
+func escapeMarkdownCell(value string) string {
+	return strings.NewReplacer("|", `\|`, "\r", " ", "\n", " ").Replace(value)
+}
+
+// AppendStepSummary appends the Markdown summary to the file named by
+// GITHUB_STEP_SUMMARY; it does nothing when path is empty
+func AppendStepSummary(path string, result *ActionResult) error {
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) // #nosec G304 -- path comes from the runner
+	if err != nil {
+		return WrapInternalError("writing step summary", err)
+	}
+	defer file.Close()
+
+	if err := FormatActionSummary(file, result); err != nil {
+		return WrapInternalError("writing step summary", err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestEvent saves an issues event payload and returns its path
+func writeTestEvent(t *testing.T, payload string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "event.json")
+	require.NoError(t, os.WriteFile(path, []byte(payload), 0600))
+	return path
+}
+
+func TestReadActionEvent(t *testing.T) {
+	event, err := ReadActionEvent(writeTestEvent(t, `{"action": "closed",
+		"issue": {"number": 42, "title": "Schema", "state": "closed"},
+		"repository": {"full_name": "org/app"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "closed", event.Action)
+	assert.Equal(t, 42, event.Issue.Number)
+	assert.Equal(t, "org/app", event.Repository.FullName)
+
+	tests := []struct {
+		name          string
+		path          string
+		errorContains string
+	}{
+		{"no path", "", "No event payload"},
+		{"missing file", filepath.Join(t.TempDir(), "missing.json"), "Cannot read event payload"},
+		{"invalid JSON", writeTestEvent(t, `{"action":`), "not valid JSON"},
+		{"no issue", writeTestEvent(t, `{"action": "opened", "pull_request": {"number": 1}}`), "has no issue"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadActionEvent(tt.path)
+			require.Error(t, err)
+			assert.True(t, IsErrorType(err, ErrorTypeValidation))
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+// newTestActionRunner returns a runner with every reaction enabled and faked
+func newTestActionRunner(out *bytes.Buffer) *ActionRunner {
+	runner := NewActionRunner(ActionOptions{
+		Owner:      "org",
+		Repo:       "app",
+		SyncLabels: true,
+		Label:      "blocked",
+		Notify:     true,
+		Project:    &ProjectRef{Owner: "org", Number: 5},
+		Field:      "Status",
+	}, out)
+
+	runner.syncLabels = func(ctx context.Context, opts LabelSyncOptions) (*LabelSyncReport, error) {
+		return &LabelSyncReport{Label: opts.Label, IssuesChecked: 4, Blocked: 1, Changes: []LabelSyncChange{
+			{Issue: "org/app#10", Action: LabelSyncActionRemove, Status: StatusApplied},
+			{Issue: "org/app#11", Action: LabelSyncActionAdd, Status: StatusFailed, Error: "forbidden"},
+		}}, nil
+	}
+	runner.notify = func(ctx context.Context, owner, repo string, number int, opts NotifyOptions) (*NotifyReport, error) {
+		return &NotifyReport{Blocker: "org/app#42", Dependents: []NotifyResult{
+			{Issue: "org/app#10", Comment: "#42 closed; you are now unblocked.", Status: StatusApplied},
+			{Issue: "org/app#12", Status: StatusAlreadyNotified},
+		}}, nil
+	}
+	runner.syncProject = func(ctx context.Context, opts ProjectSyncOptions) (*ProjectSyncReport, error) {
+		return nil, errors.New("project scope missing")
+	}
+	return runner
+}
+
+func TestActionRunner(t *testing.T) {
+	closed := &ActionEvent{Action: "closed", Issue: &ActionEventIssue{Number: 42, Title: "Schema"}}
+
+	t.Run("closed issue runs every reaction", func(t *testing.T) {
+		var out bytes.Buffer
+		result := newTestActionRunner(&out).Run(context.Background(), closed)
+
+		require.Len(t, result.Reactions, 3)
+		assert.Equal(t, ActionStatusFailed, result.Reactions[0].Status)
+		assert.Equal(t, ActionStatusOK, result.Reactions[1].Status)
+		assert.Equal(t, "Commented on 1 dependents, 1 already notified", result.Reactions[1].Summary)
+		assert.Equal(t, ActionStatusFailed, result.Reactions[2].Status)
+		assert.True(t, result.Failed())
+
+		assert.Equal(t, `::error title=sync-labels::org/app#11: forbidden
+::notice title=notify::Commented on 1 dependents, 1 already notified
+::error title=project-sync::project scope missing
+`, out.String())
+	})
+
+	t.Run("reopened issue skips notify", func(t *testing.T) {
+		reopened := *closed
+		reopened.Action = "reopened"
+
+		runner := newTestActionRunner(&bytes.Buffer{})
+		runner.opts.SyncLabels, runner.opts.Project = false, nil
+		result := runner.Run(context.Background(), &reopened)
+
+		require.Len(t, result.Reactions, 1)
+		assert.Equal(t, ActionStatusSkipped, result.Reactions[0].Status)
+		assert.False(t, result.Failed())
+	})
+
+	t.Run("other events do nothing", func(t *testing.T) {
+		edited := *closed
+		edited.Action = "edited"
+
+		var out bytes.Buffer
+		result := newTestActionRunner(&out).Run(context.Background(), &edited)
+		assert.Empty(t, result.Reactions)
+		assert.Equal(t, "::notice title=gh-issue-dependency::Nothing to do for org/app#42 edited\n", out.String())
+	})
+}
+
+func TestEscapeWorkflowCommand(t *testing.T) {
+	assert.Equal(t, "100%25 done%0Anext", escapeWorkflowData("100% done\nnext"))
+	assert.Equal(t, "a%3Ab%2Cc", escapeWorkflowProperty("a:b,c"))
+}
+
+func TestFormatActionSummary(t *testing.T) {
+	result := &ActionResult{
+		Event: "closed",
+		Issue: "org/app#42",
+		Reactions: []ActionReaction{
+			{Name: ActionReactionLabels, Status: ActionStatusOK, Summary: "Label \"blocked\" added to 0 issues and removed from 1",
+				Details: []string{"Removed `blocked` from org/app#10"}},
+			{Name: ActionReactionNotify, Status: ActionStatusFailed, Summary: "a | b", Errors: []string{"a | b"}},
+		},
+	}
+
+	var buf strings.Builder
+	require.NoError(t, FormatActionSummary(&buf, result))
+	assert.Equal(t, `## Issue dependencies: org/app#42 closed
+
+| Reaction | Result | Details |
+|----------|--------|---------|
+| sync-labels | ✅ ok | Label "blocked" added to 0 issues and removed from 1 |
+| notify | ❌ failed | a \| b |
+
+### sync-labels
+
+- Removed `+"`blocked`"+` from org/app#10
+
+### notify
+
+- ❌ a | b
+`, buf.String())
+
+	path := filepath.Join(t.TempDir(), "summary.md")
+	require.NoError(t, AppendStepSummary(path, result))
+	require.NoError(t, AppendStepSummary(path, result))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(content), "## Issue dependencies"))
+
+	assert.NoError(t, AppendStepSummary("", result))
+}
@@ -0,0 +1,383 @@
+// Package pkg provides query-driven bulk dependency mutations.
+//
+// This file implements the shared workflow behind `add --query` and `remove --query`:
+// resolve a GitHub search into issues, preview and confirm the affected
+// relationships, apply them in parallel, and report a per-issue result summary.
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBulkConcurrency is the number of relationship changes applied in parallel
+const DefaultBulkConcurrency = 4
+
+// bulkConcurrency is the number of requests made in parallel when no count is
+// given; the concurrency setting changes it
+var bulkConcurrency = DefaultBulkConcurrency
+
+// BulkOperation identifies a single relationship change in a bulk mutation
+type BulkOperation struct {
+	Source  IssueRef
+	Target  IssueRef
+	RelType string
+	Title   string // Title of the issue matched by the query, for previews
+}
+
+// String returns a display form of the relationship, e.g. "org/app#12 ← org/app#500"
+func (op BulkOperation) String() string {
+	return fmt.Sprintf("%s %s %s", op.Source.String(), relationshipSymbol(op.RelType), op.Target.String())
+}
+
+// BulkResult records the outcome of one bulk operation
+type BulkResult struct {
+	Operation   BulkOperation
+	Status      string // One of the Status* constants
+	Err         error  // Why the operation failed, for StatusFailed
+	RollbackErr error  // Why reverting failed, for StatusRollbackFailed
+}
+
+// BulkOptions contains options for query-driven bulk operations
+type BulkOptions struct {
+	DryRun      bool
+	Force       bool
+	Atomic      bool // Apply sequentially and roll back everything on the first failure
+	Concurrency int  // Parallel API calls; the concurrency setting when zero
+}
+
+// BuildQueryOperations pairs every issue matched by a query with every target.
+// Pairs where the matched issue is the target itself are skipped, since an issue
+// cannot depend on itself.
+func BuildQueryOperations(issues []Issue, targets []IssueRef, relType string) []BulkOperation {
+	var ops []BulkOperation
+
+	for _, issue := range issues {
+		source := IssueRefFromIssue(issue)
+		for _, target := range targets {
+			if source.Owner == target.Owner && source.Repo == target.Repo && source.Number == target.Number {
+				continue
+			}
+			ops = append(ops, BulkOperation{
+				Source:  source,
+				Target:  target,
+				RelType: relType,
+				Title:   issue.Title,
+			})
+		}
+	}
+
+	return ops
+}
+
+// AddForQuery creates the relationship to each target for every issue matching query
+func (a *DependencyAdder) AddForQuery(query string, targets []IssueRef, relType string, opts BulkOptions) error {
+	ops, err := resolveQueryOperations(query, targets, relType)
+	if err != nil {
+		return err
+	}
+
+	return executeBulkOperations("add", query, ops, opts, a.validateOperation, a.applyOperation, a.remover().applyOperation)
+}
+
+// RemoveForQuery removes the relationship to each target for every issue matching query
+func (r *DependencyRemover) RemoveForQuery(query string, targets []IssueRef, relType string, opts BulkOptions) error {
+	ops, err := resolveQueryOperations(query, targets, relType)
+	if err != nil {
+		return err
+	}
+
+	return executeBulkOperations("remove", query, ops, opts, nil, r.applyOperation, r.adder().applyOperation)
+}
+
+// resolveQueryOperations runs the search and expands it into bulk operations
+func resolveQueryOperations(query string, targets []IssueRef, relType string) ([]BulkOperation, error) {
+	if len(targets) == 0 {
+		return nil, NewEmptyValueError("dependency references")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	issues, err := SearchIssues(ctx, query, SearchResultLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := BuildQueryOperations(issues, targets, relType)
+	if len(ops) == 0 {
+		return nil, NewAppError(
+			ErrorTypeIssue,
+			"No issues match the search query",
+			nil,
+		).WithContext("query", query).
+			WithSuggestion("Preview the matches with 'gh search issues' and adjust the query")
+	}
+
+	return ops, nil
+}
+
+// executeBulkOperations validates, previews, confirms, applies, and reports a
+// bulk mutation. Operations rejected by validate, which may be nil, are reported
+// as skipped and never applied. revert undoes apply and is only used in atomic mode.
+func executeBulkOperations(action, query string, ops []BulkOperation, opts BulkOptions, validate, apply, revert func(BulkOperation) error) error {
+	// 1. Set aside the operations that cannot be applied
+	var skipped []BulkResult
+	if validate != nil {
+		var err error
+		ops, skipped, err = validateBulkOperations(ops, opts.Concurrency, validate)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 2. Handle dry run mode
+	if opts.DryRun {
+		return showBulkDryRunPreview(action, query, ops, skipped)
+	}
+
+	// 3. Get user confirmation for the whole batch
+	if !opts.Force && len(ops) > 0 {
+		confirmed, err := requestBulkConfirmation(action, query, ops, skipped)
+		if err != nil {
+			return fmt.Errorf("bulk confirmation failed: %w", err)
+		}
+		if !confirmed {
+			return NewAppError(
+				ErrorTypeValidation,
+				fmt.Sprintf("Bulk dependency %s cancelled by user", action),
+				nil,
+			).WithSuggestion("Use --force to skip confirmation prompts")
+		}
+	}
+
+	// 4. Apply and report per-issue results
+	var results []BulkResult
+	if opts.Atomic {
+		results = runAtomicOperations(ops, apply, revert)
+	} else {
+		results = runBulkOperations(ops, opts.Concurrency, apply)
+	}
+	return reportBatchResults(action, append(skipped, results...), opts.Atomic)
+}
+
+// validateBulkOperations checks ops with bounded parallelism and splits them
+// into the ones to apply and skipped results for the ones rejected as invalid,
+// both in input order. Any other failure, such as a network error, is returned.
+func validateBulkOperations(ops []BulkOperation, concurrency int, validate func(BulkOperation) error) ([]BulkOperation, []BulkResult, error) {
+	if concurrency <= 0 {
+		concurrency = bulkConcurrency
+	}
+
+	errs := make([]error, len(ops))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, op := range ops {
+		wg.Add(1)
+		go func(i int, op BulkOperation) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			errs[i] = validate(op)
+		}(i, op)
+	}
+	wg.Wait()
+
+	var valid []BulkOperation
+	var skipped []BulkResult
+	for i, op := range ops {
+		switch err := errs[i]; {
+		case err == nil:
+			valid = append(valid, op)
+		case IsErrorType(err, ErrorTypeValidation) || IsErrorType(err, ErrorTypeIssue):
+			skipped = append(skipped, BulkResult{Operation: op, Status: StatusSkipped, Err: err})
+		default:
+			return nil, nil, err
+		}
+	}
+	return valid, skipped, nil
+}
+
+// runBulkOperations applies ops with bounded parallelism. Results are returned
+// in the same order as ops regardless of completion order.
+func runBulkOperations(ops []BulkOperation, concurrency int, apply func(BulkOperation) error) []BulkResult {
+	if concurrency <= 0 {
+		concurrency = bulkConcurrency
+	}
+
+	results := make([]BulkResult, len(ops))
+
+	// A single worker applies ops strictly in order
+	if concurrency == 1 {
+		for i, op := range ops {
+			results[i] = BulkResult{Operation: op, Status: StatusApplied}
+			if err := apply(op); err != nil {
+				results[i].Status = StatusFailed
+				results[i].Err = err
+			}
+		}
+		return results
+	}
+
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, op := range ops {
+		wg.Add(1)
+		go func(i int, op BulkOperation) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[i] = BulkResult{Operation: op, Status: StatusApplied}
+			if err := apply(op); err != nil {
+				results[i].Status = StatusFailed
+				results[i].Err = err
+			}
+		}(i, op)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// showBulkDryRunPreview displays the relationships a bulk mutation would change
+// and the ones it would skip
+func showBulkDryRunPreview(action, query string, ops []BulkOperation, skipped []BulkResult) error {
+	fmt.Printf("Dry run: bulk dependency %s preview\n\n", action)
+	if query != "" {
+		fmt.Printf("Query: %s\n", query)
+	}
+	fmt.Printf("Would %s %d relationships:\n", action, len(ops))
+
+	for _, op := range ops {
+		fmt.Printf("  %s %s %s\n", bulkActionSymbol(action), op.String(), op.Title)
+	}
+
+	if len(skipped) > 0 {
+		fmt.Printf("\nWould skip %d relationships:\n", len(skipped))
+		for _, result := range skipped {
+			fmt.Printf("  %s %s: %v\n", resultSymbol(StatusSkipped), result.Operation.String(), result.Err)
+		}
+	}
+
+	fmt.Printf("\nNo changes made. Use --force to skip confirmation or remove --dry-run to execute.\n")
+
+	return nil
+}
+
+// requestBulkConfirmation prompts user for confirmation before a bulk mutation
+func requestBulkConfirmation(action, query string, ops []BulkOperation, skipped []BulkResult) (bool, error) {
+	fmt.Printf("%s %d dependency relationships?\n", capitalize(action), len(ops))
+	if query != "" {
+		fmt.Printf("  Query: %s\n", query)
+	}
+	if relType := commonRelType(ops); relType != "" {
+		fmt.Printf("  Type: %s\n", relType)
+	}
+	fmt.Printf("  Issues:\n")
+
+	for _, op := range ops {
+		fmt.Printf("    - %s %s\n", op.String(), op.Title)
+	}
+
+	if len(skipped) > 0 {
+		fmt.Printf("  Skipped:\n")
+		for _, result := range skipped {
+			fmt.Printf("    - %s: %v\n", result.Operation.String(), result.Err)
+		}
+	}
+
+	fmt.Printf("\nThis will %s %d dependency relationships.\n", action, len(ops))
+	fmt.Printf("Continue? (y/N): ")
+
+	var response string
+	if _, err := fmt.Scanln(&response); err != nil {
+		// Default to "no" on input error for safety
+		response = "n"
+	} else {
+		response = strings.ToLower(strings.TrimSpace(response))
+	}
+	return response == "y" || response == "yes", nil
+}
+
+// reportBatchResults prints one line per operation and a summary. It returns a
+// BatchError carrying every result when any operation did not stick, so the
+// command exits non-zero.
+func reportBatchResults(action string, results []BulkResult, atomic bool) error {
+	for _, result := range results {
+		line := fmt.Sprintf("  %s %s", resultSymbol(result.Status), result.Operation.String())
+		switch result.Status {
+		case StatusFailed:
+			line += fmt.Sprintf(": %v", result.Err)
+		case StatusRollbackFailed:
+			line += fmt.Sprintf(" (applied, rollback failed: %v)", result.RollbackErr)
+		case StatusRolledBack:
+			line += " (rolled back)"
+		case StatusSkipped:
+			if result.Err != nil {
+				line += fmt.Sprintf(" (skipped: %v)", result.Err)
+			} else {
+				line += " (skipped)"
+			}
+		}
+		fmt.Println(line)
+	}
+
+	applied := countResults(results, StatusApplied)
+	skipped := countResults(results, StatusSkipped)
+	fmt.Printf("\nBatch %s: %d applied, %d failed", action, applied, countResults(results, StatusFailed))
+	if atomic {
+		fmt.Printf(", %d rolled back", countResults(results, StatusRolledBack))
+	}
+	if skipped > 0 {
+		fmt.Printf(", %d skipped", skipped)
+	}
+	fmt.Println()
+
+	// Skipped operations were never attempted; in atomic mode they only occur
+	// alongside a failure
+	if applied+skipped < len(results) {
+		return newBatchError(action, results, atomic)
+	}
+
+	return nil
+}
+
+// commonRelType returns the relationship type shared by all ops, or "" when mixed
+func commonRelType(ops []BulkOperation) string {
+	if len(ops) == 0 {
+		return ""
+	}
+	for _, op := range ops[1:] {
+		if op.RelType != ops[0].RelType {
+			return ""
+		}
+	}
+	return ops[0].RelType
+}
+
+// bulkActionSymbol returns the preview marker for a bulk action
+func bulkActionSymbol(action string) string {
+	switch action {
+	case "remove":
+		return "❌"
+	case "undo":
+		return "↩️"
+	default:
+		return "➕"
+	}
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,149 @@
+package pkg
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildQueryOperations(t *testing.T) {
+	issues := []Issue{
+		{Number: 12, Title: "Auth", Repository: RepositoryInfo{FullName: "org/app"}},
+		{Number: 500, Title: "Blocker", Repository: RepositoryInfo{FullName: "org/app"}},
+		{Number: 3, Title: "Docs", Repository: RepositoryInfo{FullName: "org/docs"}},
+	}
+	targets := []IssueRef{CreateIssueRef("org", "app", 500)}
+
+	ops := BuildQueryOperations(issues, targets, "blocked-by")
+
+	// The blocker itself matched the query and must not depend on itself
+	require.Len(t, ops, 2)
+	assert.Equal(t, "org/app#12 ← org/app#500", ops[0].String())
+	assert.Equal(t, "Auth", ops[0].Title)
+	assert.Equal(t, "org/docs#3 ← org/app#500", ops[1].String())
+}
+
+func TestCommonRelType(t *testing.T) {
+	blockedBy := BulkOperation{RelType: "blocked-by"}
+	blocks := BulkOperation{RelType: "blocks"}
+
+	assert.Equal(t, "blocked-by", commonRelType([]BulkOperation{blockedBy, blockedBy}))
+	assert.Equal(t, "", commonRelType([]BulkOperation{blockedBy, blocks}))
+	assert.Equal(t, "", commonRelType(nil))
+}
+
+func TestRunBulkOperations(t *testing.T) {
+	var ops []BulkOperation
+	for i := 1; i <= 10; i++ {
+		ops = append(ops, BulkOperation{
+			Source:  CreateIssueRef("org", "app", i),
+			Target:  CreateIssueRef("org", "app", 500),
+			RelType: "blocked-by",
+		})
+	}
+
+	var running, maxRunning int32
+	results := runBulkOperations(ops, 3, func(op BulkOperation) error {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			seen := atomic.LoadInt32(&maxRunning)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxRunning, seen, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+
+		if op.Source.Number%4 == 0 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	require.Len(t, results, len(ops))
+	assert.LessOrEqual(t, maxRunning, int32(3), "concurrency must be bounded")
+	for i, result := range results {
+		assert.Equal(t, ops[i], result.Operation, "results must keep input order")
+		if ops[i].Source.Number%4 == 0 {
+			assert.Equal(t, StatusFailed, result.Status)
+			assert.Error(t, result.Err)
+		} else {
+			assert.Equal(t, StatusApplied, result.Status)
+			assert.NoError(t, result.Err)
+		}
+	}
+}
+
+func TestReportBatchResults(t *testing.T) {
+	op := BulkOperation{
+		Source:  CreateIssueRef("org", "app", 1),
+		Target:  CreateIssueRef("org", "app", 500),
+		RelType: "blocked-by",
+	}
+
+	t.Run("all succeeded", func(t *testing.T) {
+		err := reportBatchResults("add", []BulkResult{{Operation: op, Status: StatusApplied}}, false)
+		assert.NoError(t, err)
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		err := reportBatchResults("add", []BulkResult{
+			{Operation: op, Status: StatusApplied},
+			{Operation: op, Status: StatusFailed, Err: errors.New("forbidden")},
+		}, false)
+		require.Error(t, err)
+		assert.True(t, IsErrorType(err, ErrorTypeAPI))
+		assert.Contains(t, err.Error(), "1 succeeded, 1 failed")
+
+		batchErr, ok := AsBatchError(err)
+		require.True(t, ok)
+		assert.Len(t, batchErr.Results, 2)
+		assert.Equal(t, 1, batchErr.Count(StatusFailed))
+	})
+}
+
+func TestExecuteBulkOperationsValidation(t *testing.T) {
+	var ops []BulkOperation
+	for i := 1; i <= 3; i++ {
+		ops = append(ops, BulkOperation{
+			Source:  CreateIssueRef("org", "app", i),
+			Target:  CreateIssueRef("org", "app", 500),
+			RelType: "blocked-by",
+		})
+	}
+
+	t.Run("rejected pairs are skipped", func(t *testing.T) {
+		validate := func(op BulkOperation) error {
+			if op.Source.Number == 2 {
+				return NewDependencyExistsError(op.Source.String(), op.Target.String())
+			}
+			return nil
+		}
+		var applied []int
+		apply := func(op BulkOperation) error {
+			applied = append(applied, op.Source.Number)
+			return nil
+		}
+
+		err := executeBulkOperations("add", "label:auth", ops, BulkOptions{Force: true, Concurrency: 1}, validate, apply, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 3}, applied)
+	})
+
+	t.Run("other validation failures stop the batch", func(t *testing.T) {
+		validate := func(op BulkOperation) error {
+			return WrapNetworkError(errors.New("connection reset"))
+		}
+		apply := func(op BulkOperation) error {
+			t.Fatalf("%s must not be applied", op.String())
+			return nil
+		}
+
+		err := executeBulkOperations("add", "label:auth", ops, BulkOptions{Force: true}, validate, apply, nil)
+		assert.True(t, IsErrorType(err, ErrorTypeNetwork))
+	})
+}
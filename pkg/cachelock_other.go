@@ -0,0 +1,16 @@
+//go:build !unix && !windows
+
+package pkg
+
+import "os"
+
+// lockFile does nothing on platforms without file locking; writes are still
+// atomic, so concurrent processes can only lose each other's cache entries
+func lockFile(f *os.File) error {
+	return nil
+}
+
+// unlockFile does nothing on platforms without file locking
+func unlockFile(f *os.File) error {
+	return nil
+}
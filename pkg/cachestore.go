@@ -0,0 +1,368 @@
+// Package pkg provides the on-disk cache store.
+//
+// The cache lives under $XDG_CACHE_HOME/gh-issue-dependency, in a directory
+// per GitHub host and user so accounts never see each other's data. Entries
+// are written atomically, a lock file keeps concurrent processes from
+// writing or cleaning at the same time, and the least recently used entries
+// are evicted once the store grows past its size limit. Writes keep a running
+// size total in the store, so it is only scanned when that total goes over.
+package pkg
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/auth"
+	ghconfig "github.com/cli/go-gh/v2/pkg/config"
+)
+
+// CacheDirName is the directory, under the user's cache home, holding the cache
+const CacheDirName = "gh-issue-dependency"
+
+// DefaultCacheMaxSize is the size, in bytes, past which the least recently
+// used cache entries are evicted
+const DefaultCacheMaxSize = 50 << 20
+
+// cacheLockFile serializes writers across processes; it lives in the store directory
+const cacheLockFile = ".lock"
+
+// cacheSizeFile holds the running total, in bytes, of the entries in the store
+const cacheSizeFile = ".size"
+
+// cacheCleanStamp is touched after every full cleanup of the store; its
+// modification time is when the store was last cleaned
+const cacheCleanStamp = ".last-clean"
+
+// CacheCleanInterval is how often a full cleanup of the store runs
+const CacheCleanInterval = time.Hour
+
+// cacheTempPrefix prefixes files being written, which become entries on rename
+const cacheTempPrefix = ".tmp-"
+
+// staleTempAge is how old a leftover temporary file must be before cleanup
+// removes it, so files still being written are left alone
+const staleTempAge = time.Hour
+
+// CacheHome returns the directory holding the cache for every host and user.
+//
+// It follows the same lookup order as the GitHub CLI: $XDG_CACHE_HOME when set,
+// %LocalAppData% on Windows, and ~/.cache everywhere else.
+func CacheHome() string {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, CacheDirName)
+	}
+
+	if runtime.GOOS == "windows" {
+		if localAppData := os.Getenv("LocalAppData"); localAppData != "" {
+			return filepath.Join(localAppData, CacheDirName)
+		}
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".cache", CacheDirName) // fallback to relative path
+	}
+	return filepath.Join(homeDir, ".cache", CacheDirName)
+}
+
+// cacheStore is a directory of cache files with atomic writes, cross-process
+// locking, and least-recently-used eviction
+type cacheStore struct {
+	dir     string
+	maxSize int64 // Total size in bytes kept after eviction
+}
+
+// Default store for the current host and user, opened on first use
+var (
+	defaultCacheStore     *cacheStore
+	defaultCacheStoreOnce sync.Once
+)
+
+// newCacheStore returns a store in dir holding at most maxSize bytes
+func newCacheStore(dir string, maxSize int64) *cacheStore {
+	return &cacheStore{dir: dir, maxSize: maxSize}
+}
+
+// openCacheStore returns the store for the current host and user, moving the
+// legacy ~/.gh-issue-dependency-cache into it the first time
+func openCacheStore() *cacheStore {
+	defaultCacheStoreOnce.Do(func() {
+		defaultCacheStore = newCacheStore(filepath.Join(CacheHome(), cacheNamespace()), DefaultCacheMaxSize)
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			defaultCacheStore.migrate(filepath.Join(homeDir, CacheDir))
+		}
+	})
+	return defaultCacheStore
+}
+
+// cacheNamespace returns the host/user directory of the current account.
+// Tokens from the environment are identified by a digest, since they may
+// belong to a different user than the one gh is logged in as.
+func cacheNamespace() string {
+	host, _ := auth.DefaultHost()
+
+	user := ""
+	token, source := auth.TokenFromEnvOrConfig(host)
+	if token != "" && strings.HasSuffix(source, "TOKEN") {
+		digest := sha256.Sum256([]byte(token))
+		user = fmt.Sprintf("token-%x", digest[:6])
+	} else if cfg, err := ghconfig.Read(nil); err == nil {
+		user, _ = cfg.Get([]string{"hosts", host, "user"})
+	}
+	if user == "" {
+		user = "anonymous"
+	}
+
+	return filepath.Join(cacheNameComponent(host), cacheNameComponent(user))
+}
+
+// cacheNameComponent makes a host or user name safe to use as a directory name
+func cacheNameComponent(name string) string {
+	name = regexp.MustCompile(`[^A-Za-z0-9._-]`).ReplaceAllString(name, "_")
+	if name == "" || name == "." || name == ".." {
+		return "_"
+	}
+	return name
+}
+
+// path returns the file for name, or "" when name would leave the store
+func (s *cacheStore) path(name string) string {
+	if !filepath.IsLocal(name) {
+		return ""
+	}
+	return filepath.Join(s.dir, name)
+}
+
+// read returns the contents of the entry name and marks it as recently used
+func (s *cacheStore) read(name string) ([]byte, bool) {
+	path := s.path(name)
+	if path == "" {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- path validated to stay inside the store
+	if err != nil {
+		return nil, false
+	}
+	s.touch(name)
+	return data, true
+}
+
+// touch marks the entry name as recently used
+func (s *cacheStore) touch(name string) {
+	if path := s.path(name); path != "" {
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+	}
+}
+
+// write replaces the entry name with data, so readers see the old or the new
+// contents but never a partial file. It updates the running size total and
+// evicts entries only once that total is past the size limit.
+func (s *cacheStore) write(name string, data []byte) error {
+	path := s.path(name)
+	if path == "" {
+		return fmt.Errorf("invalid cache entry name: %s", name)
+	}
+
+	return s.withLock(func() error {
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			return err
+		}
+
+		tmp, err := os.CreateTemp(filepath.Dir(path), cacheTempPrefix+"*")
+		if err != nil {
+			return err
+		}
+		if _, err := tmp.Write(data); err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+			return err
+		}
+		if err := tmp.Close(); err != nil {
+			_ = os.Remove(tmp.Name())
+			return err
+		}
+		var replaced int64
+		if info, err := os.Stat(path); err == nil {
+			replaced = info.Size()
+		}
+		if err := os.Rename(tmp.Name(), path); err != nil {
+			_ = os.Remove(tmp.Name())
+			return err
+		}
+
+		if s.maxSize <= 0 {
+			return nil
+		}
+		if total, ok := s.readSize(); ok {
+			total += int64(len(data)) - replaced
+			if total <= s.maxSize {
+				s.writeSize(total)
+				return nil
+			}
+		}
+		s.evict()
+		return nil
+	})
+}
+
+// readSize returns the running size total, which is missing until the first
+// eviction scan. Entries removed outside eviction are not subtracted, so the
+// total may run high; that only means an earlier scan, which corrects it.
+// The caller holds the lock.
+func (s *cacheStore) readSize() (int64, bool) {
+	data, err := os.ReadFile(filepath.Join(s.dir, cacheSizeFile)) // #nosec G304 -- fixed name inside the store
+	if err != nil {
+		return 0, false
+	}
+	total, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil || total < 0 {
+		return 0, false
+	}
+	return total, true
+}
+
+// writeSize records the running size total. The caller holds the lock.
+func (s *cacheStore) writeSize(total int64) {
+	_ = os.WriteFile(filepath.Join(s.dir, cacheSizeFile), []byte(strconv.FormatInt(total, 10)), 0600)
+}
+
+// remove deletes the entry name; missing entries are fine
+func (s *cacheStore) remove(name string) {
+	if path := s.path(name); path != "" {
+		_ = os.Remove(path)
+	}
+}
+
+// withLock runs fn while holding the store's lock file, which other
+// processes using the same store wait for
+func (s *cacheStore) withLock(fn func() error) error {
+	if err := os.MkdirAll(s.dir, 0750); err != nil {
+		return err
+	}
+
+	lock, err := os.OpenFile(filepath.Join(s.dir, cacheLockFile), os.O_CREATE|os.O_RDWR, 0600) // #nosec G304 -- fixed name inside the store
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Close() }()
+
+	if err := lockFile(lock); err != nil {
+		return err
+	}
+	defer func() { _ = unlockFile(lock) }()
+
+	return fn()
+}
+
+// cacheFile is an entry found when scanning the store
+type cacheFile struct {
+	name    string // Slash-separated path relative to the store
+	size    int64
+	modTime time.Time
+}
+
+// files lists the entries in the store, skipping the lock, size and cleanup files and
+// removing temporary files left behind by writers that did not finish
+func (s *cacheStore) files(now time.Time) []cacheFile {
+	var files []cacheFile
+	_ = filepath.WalkDir(s.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || isCacheControlFile(entry.Name()) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		if strings.HasPrefix(entry.Name(), cacheTempPrefix) {
+			if now.Sub(info.ModTime()) > staleTempAge {
+				_ = os.Remove(path)
+			}
+			return nil
+		}
+
+		name, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return nil
+		}
+		files = append(files, cacheFile{name: filepath.ToSlash(name), size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	return files
+}
+
+// isCacheControlFile reports whether name is one of the store's own files
+// rather than an entry
+func isCacheControlFile(name string) bool {
+	return name == cacheLockFile || name == cacheSizeFile || name == cacheCleanStamp
+}
+
+// evict scans the store, removes the least recently used entries until it
+// fits in maxSize, and records the exact size total. The caller holds the lock.
+func (s *cacheStore) evict() {
+	if s.maxSize <= 0 {
+		return
+	}
+
+	files := s.files(time.Now())
+	var total int64
+	for _, file := range files {
+		total += file.size
+	}
+	defer func() { s.writeSize(total) }()
+	if total <= s.maxSize {
+		return
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	for _, file := range files {
+		if total <= s.maxSize {
+			break
+		}
+		if os.Remove(filepath.Join(s.dir, filepath.FromSlash(file.name))) == nil {
+			total -= file.size
+		}
+	}
+}
+
+// migrate moves the entries of the legacy cache directory into the store and
+// removes the legacy directory. Entries that cannot be moved, for example
+// across file systems, are dropped; they are only a cache.
+func (s *cacheStore) migrate(legacyDir string) {
+	if _, err := os.Stat(legacyDir); err != nil {
+		return
+	}
+
+	_ = s.withLock(func() error {
+		_ = filepath.WalkDir(legacyDir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+				return nil
+			}
+			name, err := filepath.Rel(legacyDir, path)
+			if err != nil {
+				return nil
+			}
+			target := filepath.Join(s.dir, name)
+			if _, err := os.Stat(target); err == nil {
+				return nil // The store already has a newer copy
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0750); err == nil {
+				_ = os.Rename(path, target)
+			}
+			return nil
+		})
+		s.evict() // Counts the moved entries in the size total
+		return os.RemoveAll(legacyDir)
+	})
+}
@@ -0,0 +1,199 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheHome(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	assert.Equal(t, filepath.Join(dir, CacheDirName), CacheHome())
+}
+
+func TestCacheNameComponent(t *testing.T) {
+	assert.Equal(t, "github.com", cacheNameComponent("github.com"))
+	assert.Equal(t, "ghe.example.com_8443", cacheNameComponent("ghe.example.com:8443"))
+	assert.Equal(t, "_", cacheNameComponent(".."))
+	assert.Equal(t, "_", cacheNameComponent(""))
+}
+
+func TestCacheStoreReadWrite(t *testing.T) {
+	store := newCacheStore(t.TempDir(), DefaultCacheMaxSize)
+
+	_, found := store.read("missing.json")
+	assert.False(t, found)
+
+	require.NoError(t, store.write("a.json", []byte("one")))
+	require.NoError(t, store.write("responses/b.json", []byte("two")))
+	require.NoError(t, store.write("a.json", []byte("three")))
+
+	data, found := store.read("a.json")
+	require.True(t, found)
+	assert.Equal(t, "three", string(data))
+	data, found = store.read("responses/b.json")
+	require.True(t, found)
+	assert.Equal(t, "two", string(data))
+
+	store.remove("a.json")
+	_, found = store.read("a.json")
+	assert.False(t, found)
+
+	assert.Error(t, store.write("../escape.json", []byte("x")))
+	_, found = store.read("../escape.json")
+	assert.False(t, found)
+}
+
+func TestCacheStoreConcurrentWrites(t *testing.T) {
+	store := newCacheStore(t.TempDir(), DefaultCacheMaxSize)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, store.write("shared.json", []byte(fmt.Sprintf(`{"writer": %d}`, i))))
+		}(i)
+	}
+	wg.Wait()
+
+	// Every read sees one complete write
+	data, found := store.read("shared.json")
+	require.True(t, found)
+	var entry struct{ Writer int }
+	assert.NoError(t, json.Unmarshal(data, &entry))
+
+	// No temporary files are left behind
+	for _, file := range store.files(time.Now()) {
+		assert.False(t, strings.HasPrefix(filepath.Base(file.name), cacheTempPrefix), file.name)
+	}
+}
+
+func TestCacheStoreEviction(t *testing.T) {
+	store := newCacheStore(t.TempDir(), 25)
+	now := time.Now()
+
+	for i, name := range []string{"old.json", "used.json", "new.json"} {
+		require.NoError(t, os.WriteFile(filepath.Join(store.dir, name), []byte("0123456789"), 0600))
+		modTime := now.Add(time.Duration(i-3) * time.Hour)
+		require.NoError(t, os.Chtimes(filepath.Join(store.dir, name), modTime, modTime))
+	}
+
+	// Reading an entry makes it the most recently used
+	_, found := store.read("old.json")
+	require.True(t, found)
+
+	require.NoError(t, store.withLock(func() error {
+		store.evict()
+		return nil
+	}))
+	assert.FileExists(t, filepath.Join(store.dir, "old.json"))
+	assert.NoFileExists(t, filepath.Join(store.dir, "used.json"))
+	assert.FileExists(t, filepath.Join(store.dir, "new.json"))
+}
+
+func TestCacheStoreWriteTracksSize(t *testing.T) {
+	store := newCacheStore(t.TempDir(), 25)
+	size := func() int64 {
+		total, ok := store.readSize()
+		require.True(t, ok)
+		return total
+	}
+
+	// An entry left by an older version is counted by the first scan
+	require.NoError(t, os.WriteFile(filepath.Join(store.dir, "old.json"), []byte("0123456789"), 0600))
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(store.dir, "old.json"), past, past))
+	require.NoError(t, store.write("a.json", []byte("01234")))
+	assert.Equal(t, int64(15), size())
+
+	// Later writes update the total without rescanning: an entry added
+	// behind the store's back is not seen until the next scan
+	require.NoError(t, os.WriteFile(filepath.Join(store.dir, "unseen.json"), []byte("0123456789"), 0600))
+	past = past.Add(time.Minute)
+	require.NoError(t, os.Chtimes(filepath.Join(store.dir, "unseen.json"), past, past))
+	require.NoError(t, store.write("b.json", []byte("01234")))
+	assert.Equal(t, int64(20), size())
+
+	// Replacing an entry counts only the difference
+	require.NoError(t, store.write("a.json", []byte("0123456789")))
+	assert.Equal(t, int64(25), size())
+
+	// Going over the limit scans the store, which now sees the unseen entry,
+	// and evicts the least recently used entries
+	require.NoError(t, store.write("c.json", []byte("01234")))
+	assert.NoFileExists(t, filepath.Join(store.dir, "old.json"))
+	assert.NoFileExists(t, filepath.Join(store.dir, "unseen.json"))
+	assert.FileExists(t, filepath.Join(store.dir, "c.json"))
+	assert.Equal(t, int64(20), size())
+}
+
+func TestCacheStoreMigrate(t *testing.T) {
+	legacy := filepath.Join(t.TempDir(), CacheDir)
+	require.NoError(t, os.MkdirAll(filepath.Join(legacy, ResponseCacheDir), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(legacy, "a.json"), []byte("legacy a"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(legacy, "b.json"), []byte("legacy b"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(legacy, ResponseCacheDir, "c.json"), []byte("legacy c"), 0600))
+
+	store := newCacheStore(t.TempDir(), DefaultCacheMaxSize)
+	require.NoError(t, store.write("b.json", []byte("current b")))
+	store.migrate(legacy)
+
+	assert.NoDirExists(t, legacy)
+	for name, want := range map[string]string{"a.json": "legacy a", "b.json": "current b", "responses/c.json": "legacy c"} {
+		data, found := store.read(name)
+		require.True(t, found, name)
+		assert.Equal(t, want, string(data), name)
+	}
+
+	// Nothing to migrate is not an error
+	store.migrate(legacy)
+}
+
+func TestCleanCacheStore(t *testing.T) {
+	store := newCacheStore(t.TempDir(), DefaultCacheMaxSize)
+	now := time.Now()
+
+	writeEntry := func(name string, expiresAt time.Time) {
+		data, err := json.Marshal(CacheEntry{ExpiresAt: expiresAt})
+		require.NoError(t, err)
+		require.NoError(t, store.write(name, data))
+	}
+	writeEntry("fresh.json", now.Add(time.Minute))
+	writeEntry("expired.json", now.Add(-time.Minute))
+	require.NoError(t, store.write("malformed.json", []byte("{")))
+	require.NoError(t, store.write("responses/used.json", []byte("{}")))
+	require.NoError(t, store.write("responses/unused.json", []byte("{}")))
+	require.NoError(t, os.WriteFile(filepath.Join(store.dir, cacheTempPrefix+"crashed"), []byte("x"), 0600))
+
+	old := now.Add(-ResponseRetention - time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(store.dir, "responses", "unused.json"), old, old))
+	require.NoError(t, os.Chtimes(filepath.Join(store.dir, cacheTempPrefix+"crashed"), old, old))
+
+	require.NoError(t, cleanCacheStore(store, now))
+
+	var names []string
+	for _, file := range store.files(now) {
+		names = append(names, file.name)
+	}
+	assert.ElementsMatch(t, []string{"fresh.json", "responses/used.json"}, names)
+	assert.NoFileExists(t, filepath.Join(store.dir, cacheTempPrefix+"crashed"))
+
+	assert.NoError(t, cleanCacheStore(newCacheStore(filepath.Join(store.dir, "missing"), 0), now))
+
+	// The full scan runs at most once per CacheCleanInterval
+	writeEntry("expired-later.json", now.Add(-time.Minute))
+	require.NoError(t, cleanCacheStore(store, now.Add(time.Minute)))
+	assert.FileExists(t, filepath.Join(store.dir, "expired-later.json"))
+	require.NoError(t, cleanCacheStore(store, now.Add(CacheCleanInterval+time.Minute)))
+	assert.NoFileExists(t, filepath.Join(store.dir, "expired-later.json"))
+}
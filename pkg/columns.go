@@ -0,0 +1,416 @@
+// Package pkg provides column selection and layout for table output.
+//
+// TTY output renders each relationship section as a table sized to the terminal,
+// truncating long values with an ellipsis. Plain output uses the same columns as
+// tab-separated values, matching gh's own table printer, so it pipes cleanly
+// into cut and awk.
+package pkg
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/cli/go-gh/v2/pkg/text"
+	"github.com/muesli/termenv"
+)
+
+// DefaultTableWidth is the table width used when the terminal size is unknown
+const DefaultTableWidth = 80
+
+// Columns selectable with --columns
+const (
+	ColumnNumber    = "number"
+	ColumnTitle     = "title"
+	ColumnState     = "state"
+	ColumnReason    = "reason"
+	ColumnAssignees = "assignees"
+	ColumnLabels    = "labels"
+	ColumnAuthor    = "author"
+	ColumnMilestone = "milestone"
+	ColumnType      = "type"
+	ColumnComments  = "comments"
+	ColumnRepo      = "repo"
+	ColumnCreated   = "created"
+	ColumnUpdated   = "updated"
+	ColumnClosed    = "closed"
+	ColumnURL       = "url"
+	ColumnAdded     = "added"
+	ColumnAddedBy   = "added_by"
+	ColumnParent    = "parent"
+	ColumnSubIssues = "sub_issues"
+	ColumnVia       = "via"
+	ColumnPRs       = "prs"
+)
+
+// tableColumn describes how one column is rendered
+type tableColumn struct {
+	header string
+
+	// value returns the column text; tty selects the human-readable form
+	value func(dep DependencyRelation, tty bool) string
+
+	// fixed columns are never truncated
+	fixed bool
+}
+
+// tableColumns maps every selectable column to its definition
+var tableColumns = map[string]tableColumn{
+	ColumnNumber: {
+		header: "ISSUE",
+		value: func(dep DependencyRelation, tty bool) string {
+			if tty {
+				return fmt.Sprintf("#%d", dep.Issue.Number)
+			}
+			return strconv.Itoa(dep.Issue.Number)
+		},
+		fixed: true,
+	},
+	ColumnTitle: {
+		header: "TITLE",
+		value:  func(dep DependencyRelation, tty bool) string { return dep.Issue.Title },
+	},
+	ColumnState: {
+		header: "STATE",
+		value: func(dep DependencyRelation, tty bool) string {
+			if tty {
+				return displayState(dep.Issue)
+			}
+			return dep.Issue.State
+		},
+		fixed: true,
+	},
+	ColumnReason: {
+		header: "REASON",
+		value:  func(dep DependencyRelation, tty bool) string { return dep.Issue.StateReason },
+		fixed:  true,
+	},
+	ColumnAuthor: {
+		header: "AUTHOR",
+		value: func(dep DependencyRelation, tty bool) string {
+			if dep.Issue.User == nil {
+				return ""
+			}
+			if tty {
+				return "@" + dep.Issue.User.Login
+			}
+			return dep.Issue.User.Login
+		},
+	},
+	ColumnMilestone: {
+		header: "MILESTONE",
+		value: func(dep DependencyRelation, tty bool) string {
+			if dep.Issue.Milestone == nil {
+				return ""
+			}
+			return dep.Issue.Milestone.Title
+		},
+	},
+	ColumnType: {
+		header: "TYPE",
+		value: func(dep DependencyRelation, tty bool) string {
+			if dep.Issue.Type == nil {
+				return ""
+			}
+			return dep.Issue.Type.Name
+		},
+	},
+	ColumnComments: {
+		header: "COMMENTS",
+		value:  func(dep DependencyRelation, tty bool) string { return strconv.Itoa(dep.Issue.Comments) },
+		fixed:  true,
+	},
+	ColumnAssignees: {
+		header: "ASSIGNEES",
+		value: func(dep DependencyRelation, tty bool) string {
+			logins := make([]string, len(dep.Issue.Assignees))
+			for i, assignee := range dep.Issue.Assignees {
+				logins[i] = assignee.Login
+				if tty {
+					logins[i] = "@" + assignee.Login
+				}
+			}
+			return joinTableValues(logins, tty)
+		},
+	},
+	ColumnLabels: {
+		header: "LABELS",
+		value: func(dep DependencyRelation, tty bool) string {
+			names := make([]string, len(dep.Issue.Labels))
+			for i, label := range dep.Issue.Labels {
+				names[i] = label.Name
+			}
+			return joinTableValues(names, tty)
+		},
+	},
+	ColumnRepo: {
+		header: "REPOSITORY",
+		value:  func(dep DependencyRelation, tty bool) string { return dep.Repository },
+	},
+	ColumnCreated: {
+		header: "CREATED",
+		value:  func(dep DependencyRelation, tty bool) string { return formatTableTime(dep.Issue.CreatedAt, tty) },
+		fixed:  true,
+	},
+	ColumnUpdated: {
+		header: "UPDATED",
+		value:  func(dep DependencyRelation, tty bool) string { return formatTableTime(dep.Issue.UpdatedAt, tty) },
+		fixed:  true,
+	},
+	ColumnClosed: {
+		header: "CLOSED",
+		value:  func(dep DependencyRelation, tty bool) string { return formatTableTime(dep.Issue.ClosedAt, tty) },
+		fixed:  true,
+	},
+	ColumnURL: {
+		header: "URL",
+		value:  func(dep DependencyRelation, tty bool) string { return dep.Issue.HTMLURL },
+		fixed:  true,
+	},
+	ColumnAdded: {
+		header: "ADDED",
+		value:  func(dep DependencyRelation, tty bool) string { return formatTableTime(dep.CreatedAt, tty) },
+		fixed:  true,
+	},
+	ColumnAddedBy: {
+		header: "ADDED BY",
+		value: func(dep DependencyRelation, tty bool) string {
+			if tty && dep.CreatedBy != "" {
+				return "@" + dep.CreatedBy
+			}
+			return dep.CreatedBy
+		},
+	},
+	ColumnParent: {
+		header: "PARENT",
+		value: func(dep DependencyRelation, tty bool) string {
+			if dep.Hierarchy == nil || dep.Hierarchy.Parent == nil {
+				return ""
+			}
+			return markdownIssueRef(*dep.Hierarchy.Parent, dep.Repository)
+		},
+	},
+	ColumnSubIssues: {
+		header: "SUB-ISSUES",
+		value: func(dep DependencyRelation, tty bool) string {
+			closed, total := dep.Hierarchy.SubIssueProgress()
+			if total == 0 {
+				return ""
+			}
+			return fmt.Sprintf("%d/%d", closed, total)
+		},
+		fixed: true,
+	},
+	ColumnVia: {
+		header: "VIA",
+		value:  func(dep DependencyRelation, tty bool) string { return joinTableValues(dep.Via, tty) },
+	},
+	ColumnPRs: {
+		header: "PULL REQUESTS",
+		value: func(dep DependencyRelation, tty bool) string {
+			prs := make([]string, len(dep.PullRequests))
+			for i, pr := range dep.PullRequests {
+				prs[i] = formatLinkedPullRequest(pr, tty)
+			}
+			return joinTableValues(prs, tty)
+		},
+	},
+}
+
+// TableColumnNames returns every column accepted by --columns, in display order
+func TableColumnNames() []string {
+	return []string{
+		ColumnNumber, ColumnTitle, ColumnState, ColumnReason, ColumnAssignees, ColumnLabels,
+		ColumnAuthor, ColumnMilestone, ColumnType, ColumnComments, ColumnRepo,
+		ColumnCreated, ColumnUpdated, ColumnClosed, ColumnURL, ColumnAdded, ColumnAddedBy,
+		ColumnParent, ColumnSubIssues, ColumnVia, ColumnPRs,
+	}
+}
+
+// ValidateColumns checks every requested column against TableColumnNames and
+// suggests the closest valid names for unknown ones
+func ValidateColumns(columns []string) error {
+	if len(columns) == 0 {
+		return NewEmptyValueError("columns")
+	}
+
+	for _, column := range columns {
+		if _, known := tableColumns[column]; known {
+			continue
+		}
+
+		appErr := NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Unknown column: %s", column),
+			nil,
+		).WithContext("column", column)
+
+		if matches := closestMatches(column, TableColumnNames(), 3); len(matches) > 0 {
+			appErr.WithSuggestion(fmt.Sprintf("Did you mean: %s?", strings.Join(matches, ", ")))
+		}
+		return appErr.WithSuggestion(fmt.Sprintf("Available columns: %s", strings.Join(TableColumnNames(), ", ")))
+	}
+
+	return nil
+}
+
+// TableWidth returns the width available for table output. GH_TERM_WIDTH takes
+// precedence over the detected terminal size, and DefaultTableWidth is used
+// when neither is available.
+func TableWidth() int {
+	if width, err := strconv.Atoi(os.Getenv("GH_TERM_WIDTH")); err == nil && width > 0 {
+		return width
+	}
+
+	if width, _, err := term.FromEnv().Size(); err == nil && width > 0 {
+		return width
+	}
+
+	return DefaultTableWidth
+}
+
+// selectedColumns resolves the columns to render. Without --columns the TTY
+// table shows the repository only when a dependency lives in another
+// repository, while plain output always includes it so scripts see stable
+// fields. Detailed plain output also includes the URL, which would leave little
+// room for titles in a terminal. Parent and sub-issue progress are shown once
+// the hierarchy has been fetched, and linked pull requests once they have been
+// looked up.
+func (f *OutputFormatter) selectedColumns(data *DependencyData, tty bool) []string {
+	if len(f.options.Columns) > 0 {
+		return f.options.Columns
+	}
+
+	columns := []string{ColumnNumber, ColumnTitle, ColumnState, ColumnAssignees}
+	if !tty || hasCrossRepoDependencies(data) {
+		columns = append(columns, ColumnRepo)
+	}
+	if data.SourceHierarchy != nil {
+		columns = append(columns, ColumnParent, ColumnSubIssues)
+	}
+	if hasLinkedPullRequests(data) {
+		columns = append(columns, ColumnPRs)
+	}
+	if f.options.Detailed {
+		columns = append(columns, ColumnLabels, ColumnMilestone, ColumnUpdated, ColumnAdded, ColumnAddedBy)
+		if !tty {
+			columns = append(columns, ColumnURL)
+		}
+	}
+	return columns
+}
+
+// rolledUpColumns returns columns with via added, so rolled-up blockers show
+// which sub-issues they block
+func rolledUpColumns(columns []string) []string {
+	if slices.Contains(columns, ColumnVia) {
+		return columns
+	}
+	return append(slices.Clone(columns), ColumnVia)
+}
+
+// tableWidth returns the configured width or the detected terminal width
+func (f *OutputFormatter) tableWidth() int {
+	if f.options.Width > 0 {
+		return f.options.Width
+	}
+	return TableWidth()
+}
+
+// writeDependencyTable renders one relationship section as a table. TTY tables
+// have a header row, colored states, and truncated values; plain tables are
+// tab-separated with the relationship type as the first field.
+func (f *OutputFormatter) writeDependencyTable(deps []DependencyRelation, relType string, columns []string, tty bool) error {
+	table := tableprinter.New(f.options.Writer, tty, f.tableWidth())
+	muted := f.colorize(termenv.ANSIBrightBlack)
+
+	if tty {
+		headers := make([]string, 0, len(columns)+1)
+		headers = append(headers, "")
+		for _, column := range columns {
+			headers = append(headers, tableColumns[column].header)
+		}
+		table.AddHeader(headers, tableprinter.WithColor(muted))
+	}
+
+	for _, dep := range deps {
+		if tty {
+			table.AddField(f.getStateEmoji(dep.Issue), tableprinter.WithTruncate(nil))
+		} else {
+			table.AddField(relType)
+		}
+
+		for _, column := range columns {
+			definition := tableColumns[column]
+
+			truncate := text.Truncate
+			if definition.fixed {
+				truncate = nil
+			}
+
+			var color func(string) string
+			switch column {
+			case ColumnState:
+				color = f.getStateColor(dep.Issue)
+			case ColumnAssignees, ColumnAuthor, ColumnAddedBy:
+				color = f.colorize(termenv.ANSIBlue)
+			}
+
+			table.AddField(definition.value(dep, tty),
+				tableprinter.WithTruncate(truncate), tableprinter.WithColor(color))
+		}
+		table.EndRow()
+	}
+
+	return table.Render()
+}
+
+// hasCrossRepoDependencies reports whether any dependency lives outside the source repository
+func hasCrossRepoDependencies(data *DependencyData) bool {
+	source := data.SourceIssue.Repository.String()
+	for _, deps := range [][]DependencyRelation{data.BlockedBy, data.Blocking} {
+		for _, dep := range deps {
+			if dep.Repository != source {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasLinkedPullRequests reports whether linked pull requests were looked up for data
+func hasLinkedPullRequests(data *DependencyData) bool {
+	for _, deps := range [][]DependencyRelation{data.BlockedBy, data.Blocking, data.RolledUpBlockedBy} {
+		for _, dep := range deps {
+			if dep.PullRequests != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// joinTableValues joins list values for display, comma-separated without
+// spaces in plain output so fields never contain tabs or need quoting
+func joinTableValues(values []string, tty bool) string {
+	if tty {
+		return strings.Join(values, ", ")
+	}
+	return strings.Join(values, ",")
+}
+
+// formatTableTime formats t as a date for TTY tables or RFC 3339 for plain output
+func formatTableTime(t time.Time, tty bool) string {
+	if t.IsZero() {
+		return ""
+	}
+	if tty {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
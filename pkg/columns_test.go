@@ -0,0 +1,46 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateColumns(t *testing.T) {
+	assert.NoError(t, ValidateColumns([]string{"number", "title", "repo", "updated"}))
+	assert.NoError(t, ValidateColumns(TableColumnNames()))
+
+	err := ValidateColumns([]string{"number", "titel"})
+	require.Error(t, err)
+	assert.True(t, IsErrorType(err, ErrorTypeValidation))
+	assert.Contains(t, err.Error(), "Unknown column: titel")
+
+	var appErr *AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Contains(t, appErr.Suggestions, "Did you mean: title?")
+
+	assert.Error(t, ValidateColumns(nil))
+}
+
+func TestTableWidth(t *testing.T) {
+	t.Setenv("GH_TERM_WIDTH", "132")
+	assert.Equal(t, 132, TableWidth())
+
+	t.Setenv("GH_TERM_WIDTH", "wide")
+	assert.Positive(t, TableWidth())
+}
+
+func TestSelectedColumns(t *testing.T) {
+	sameRepo := createTestDependencyData()
+	sameRepo.Blocking = nil
+
+	formatter := NewOutputFormatter(&OutputOptions{})
+	assert.Equal(t, []string{"number", "title", "state", "assignees"}, formatter.selectedColumns(sameRepo, true))
+	assert.Equal(t, []string{"number", "title", "state", "assignees", "repo"}, formatter.selectedColumns(sameRepo, false))
+	assert.Equal(t, []string{"number", "title", "state", "assignees", "repo"},
+		formatter.selectedColumns(createTestDependencyData(), true), "cross-repository dependencies show the repository")
+
+	formatter = NewOutputFormatter(&OutputOptions{Columns: []string{"url"}, Detailed: true})
+	assert.Equal(t, []string{"url"}, formatter.selectedColumns(sameRepo, true))
+}
@@ -0,0 +1,615 @@
+// Package pkg provides the configuration directory and the settings files.
+//
+// Settings come from four places, each overriding the one before: built-in
+// defaults, the user's config.yml, the repository's .github/issue-dependency.yml,
+// and GH_ISSUE_DEPENDENCY_* environment variables. Command-line flags override
+// them all. The same files define aliases, which expand to other commands; a
+// repository file can add aliases but not replace the user's own.
+package pkg
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/muesli/termenv"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigDirName is the directory, under the user's config home, holding
+// gh-issue-dependency state such as the operation journal
+const ConfigDirName = "gh-issue-dependency"
+
+// ConfigFileName is the user's settings file in ConfigDir
+const ConfigFileName = "config.yml"
+
+// RepoConfigPath is the repository's settings file, relative to the repository root
+const RepoConfigPath = ".github/issue-dependency.yml"
+
+// ConfigEnvPrefix prefixes the environment variable of each setting, e.g. GH_ISSUE_DEPENDENCY_FORMAT
+const ConfigEnvPrefix = "GH_ISSUE_DEPENDENCY_"
+
+// aliasKeyPrefix prefixes alias names in config get and set, e.g. aliases.deps
+const aliasKeyPrefix = "aliases."
+
+// Sources of a configuration value, from lowest to highest precedence
+const (
+	ConfigSourceDefault = "default"
+	ConfigSourceUser    = "user"
+	ConfigSourceRepo    = "repo"
+	ConfigSourceEnv     = "env"
+)
+
+// ConfigDir returns the directory used for gh-issue-dependency configuration and state.
+//
+// It follows the same lookup order as the GitHub CLI: $XDG_CONFIG_HOME when set,
+// %AppData% on Windows, and ~/.config everywhere else.
+func ConfigDir() string {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, ConfigDirName)
+	}
+
+	if runtime.GOOS == "windows" {
+		if appData := os.Getenv("AppData"); appData != "" {
+			return filepath.Join(appData, ConfigDirName)
+		}
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".config", ConfigDirName) // fallback to relative path
+	}
+	return filepath.Join(homeDir, ".config", ConfigDirName)
+}
+
+// UserConfigPath returns the location of the user's settings file
+func UserConfigPath() string {
+	return filepath.Join(ConfigDir(), ConfigFileName)
+}
+
+// FindRepoConfigPath returns the settings file of the git repository containing
+// dir, or "" when dir is not inside a repository. The file need not exist.
+func FindRepoConfigPath(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return filepath.Join(dir, filepath.FromSlash(RepoConfigPath))
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// ConfigSetting describes one setting
+type ConfigSetting struct {
+	Key         string
+	Default     string
+	Description string
+	validate    func(string) error
+}
+
+// ConfigSettings lists every setting in the order config list shows them
+var ConfigSettings = []ConfigSetting{
+	{Key: "format", Default: "table", Description: "Default --format for list: table, json, csv, markdown",
+		validate: oneOf("table", "json", "csv", "markdown")},
+	{Key: "sort", Default: "number", Description: "Default --sort for list, e.g. state,-updated",
+		validate: func(value string) error { _, err := ParseSortSpec(value); return err }},
+	{Key: "state", Default: "all", Description: "Default --state for list: all, open, closed",
+		validate: oneOf("all", "open", "closed")},
+	{Key: "cache_ttl", Default: CacheDuration.String(), Description: "How long dependency data is cached, e.g. 10m; 0 disables the cache",
+		validate: validateDuration},
+	{Key: "concurrency", Default: strconv.Itoa(DefaultBulkConcurrency), Description: "API requests made in parallel by batch and status commands (1-20)",
+		validate: validateConcurrency},
+	{Key: "color", Default: "auto", Description: "Colored terminal output: auto, always, never",
+		validate: oneOf("auto", "always", "never")},
+	{Key: "label", Default: DefaultBlockedLabel, Description: "Default --label for sync-labels and action",
+		validate: validateNonEmpty},
+	{Key: "label_color", Default: DefaultBlockedLabelColor, Description: "Default --color for sync-labels and action",
+		validate: func(value string) error { _, err := NormalizeLabelColor(value); return err }},
+}
+
+// ConfigValue is the effective value of a setting or alias and where it came from
+type ConfigValue struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"` // One of the ConfigSource constants
+}
+
+// Config holds the effective settings and aliases
+type Config struct {
+	values  map[string]ConfigValue
+	aliases map[string]ConfigValue
+
+	UserPath string // The user's settings file
+	RepoPath string // The repository's settings file, "" outside a repository
+
+	// Warnings describes entries of the repository file that were skipped
+	Warnings []string
+}
+
+// LoadConfig reads the user and repository settings files and the environment
+func LoadConfig() (*Config, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = "."
+	}
+	return loadConfig(UserConfigPath(), FindRepoConfigPath(cwd), os.Getenv)
+}
+
+// loadConfig implements LoadConfig with explicit files and environment
+func loadConfig(userPath, repoPath string, getenv func(string) string) (*Config, error) {
+	cfg := &Config{
+		values:   make(map[string]ConfigValue),
+		aliases:  make(map[string]ConfigValue),
+		UserPath: userPath,
+		RepoPath: repoPath,
+	}
+	for _, setting := range ConfigSettings {
+		cfg.values[setting.Key] = ConfigValue{Key: setting.Key, Value: setting.Default, Source: ConfigSourceDefault}
+	}
+
+	for _, file := range []struct{ path, source string }{
+		{userPath, ConfigSourceUser},
+		{repoPath, ConfigSourceRepo},
+	} {
+		if file.path == "" {
+			continue
+		}
+		settings, aliases, unknown, err := readConfigFile(file.path)
+		if err != nil {
+			return nil, err
+		}
+
+		// The repository file comes with whatever is checked out, so keys that
+		// a newer version may understand are skipped there instead of failing
+		if len(unknown) > 0 {
+			if file.source != ConfigSourceRepo {
+				return nil, unknownConfigKeyError(unknown[0]).WithContext("file", file.path)
+			}
+			for _, key := range unknown {
+				cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Ignoring unknown setting %s in %s", key, file.path))
+			}
+		}
+
+		for key, value := range settings {
+			cfg.values[key] = ConfigValue{Key: key, Value: value, Source: file.source}
+		}
+		for name, expansion := range aliases {
+			// A repository file cannot change what the user's own aliases run
+			if existing, ok := cfg.aliases[name]; ok && existing.Source == ConfigSourceUser {
+				continue
+			}
+			cfg.aliases[name] = ConfigValue{Key: aliasKeyPrefix + name, Value: expansion, Source: file.source}
+		}
+	}
+
+	for _, setting := range ConfigSettings {
+		name := ConfigEnvPrefix + strings.ToUpper(setting.Key)
+		value := getenv(name)
+		if value == "" {
+			continue
+		}
+		if err := setting.validate(value); err != nil {
+			return nil, invalidConfigValueError(setting.Key, value, name, err)
+		}
+		cfg.values[setting.Key] = ConfigValue{Key: setting.Key, Value: value, Source: ConfigSourceEnv}
+	}
+
+	return cfg, nil
+}
+
+// Get returns the effective value of a setting, or of an alias given as aliases.<name>
+func (c *Config) Get(key string) (ConfigValue, error) {
+	if name, ok := strings.CutPrefix(key, aliasKeyPrefix); ok {
+		if value, ok := c.aliases[name]; ok {
+			return value, nil
+		}
+		return ConfigValue{}, NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("No alias named %q", name),
+			nil,
+		).WithSuggestion("Run 'gh issue-dependency config list' to see the aliases")
+	}
+
+	value, ok := c.values[key]
+	if !ok {
+		return ConfigValue{}, unknownConfigKeyError(key)
+	}
+	return value, nil
+}
+
+// Value returns the effective value of a setting, or "" for unknown keys
+func (c *Config) Value(key string) string {
+	return c.values[key].Value
+}
+
+// Values returns every setting in ConfigSettings order, followed by the aliases by name
+func (c *Config) Values() []ConfigValue {
+	values := make([]ConfigValue, 0, len(c.values)+len(c.aliases))
+	for _, setting := range ConfigSettings {
+		values = append(values, c.values[setting.Key])
+	}
+
+	names := make([]string, 0, len(c.aliases))
+	for name := range c.aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values = append(values, c.aliases[name])
+	}
+	return values
+}
+
+// Alias returns the expansion of the alias called name
+func (c *Config) Alias(name string) (string, bool) {
+	alias, ok := c.aliases[name]
+	return alias.Value, ok
+}
+
+// Apply puts the settings used inside pkg into effect: the cache TTL, the
+// request concurrency, and terminal colors
+func (c *Config) Apply() {
+	if ttl, err := time.ParseDuration(c.Value("cache_ttl")); err == nil {
+		cacheTTL = ttl
+	}
+	if concurrency, err := strconv.Atoi(c.Value("concurrency")); err == nil {
+		bulkConcurrency = concurrency
+	}
+
+	switch c.Value("color") {
+	case "always":
+		termenv.SetDefaultOutput(termenv.NewOutput(os.Stdout, termenv.WithProfile(termenv.ANSI)))
+	case "never":
+		termenv.SetDefaultOutput(termenv.NewOutput(os.Stdout, termenv.WithProfile(termenv.Ascii)))
+	}
+}
+
+// ValidateConfigValue checks a value for a setting or alias before it is saved
+func ValidateConfigValue(key, value string) error {
+	if name, ok := strings.CutPrefix(key, aliasKeyPrefix); ok {
+		if name == "" || strings.ContainsAny(name, " \t") {
+			return NewAppError(
+				ErrorTypeValidation,
+				fmt.Sprintf("Invalid alias name: %q", name),
+				nil,
+			).WithSuggestion("Alias names are single words, e.g. aliases.deps")
+		}
+		if value != "" {
+			if _, err := SplitAliasArgs(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	setting, ok := findConfigSetting(key)
+	if !ok {
+		return unknownConfigKeyError(key)
+	}
+	if value == "" {
+		return nil
+	}
+	if err := setting.validate(value); err != nil {
+		return invalidConfigValueError(key, value, "", err)
+	}
+	return nil
+}
+
+// SetConfigValue saves a setting, or an alias given as aliases.<name>, in the
+// settings file at path. An empty value removes it. Comments and the order of
+// other entries are kept.
+func SetConfigValue(path, key, value string) error {
+	if err := ValidateConfigValue(key, value); err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	content, err := os.ReadFile(path) // #nosec G304 -- path is a settings file location
+	if err != nil && !os.IsNotExist(err) {
+		return WrapInternalError("reading settings file", err)
+	}
+	if len(strings.TrimSpace(string(content))) > 0 {
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return invalidConfigFileError(path, err)
+		}
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return invalidConfigFileError(path, fmt.Errorf("top level is not a mapping"))
+	}
+
+	if name, ok := strings.CutPrefix(key, aliasKeyPrefix); ok {
+		aliases := mappingValue(root, "aliases")
+		if aliases == nil {
+			if value == "" {
+				return nil
+			}
+			aliases = &yaml.Node{Kind: yaml.MappingNode}
+			setMappingValue(root, "aliases", aliases)
+		}
+		setMappingScalar(aliases, name, value)
+		if len(aliases.Content) == 0 {
+			setMappingValue(root, "aliases", nil)
+		}
+	} else {
+		setMappingScalar(root, key, value)
+	}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return WrapInternalError("writing settings file", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return WrapInternalError("writing settings file", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, out, 0600); err != nil {
+		return WrapInternalError("writing settings file", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return WrapInternalError("writing settings file", err)
+	}
+	return nil
+}
+
+// FormatConfigValues writes settings and aliases as a table of key, value, and source
+func FormatConfigValues(w io.Writer, values []ConfigValue) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tVALUE\tSOURCE")
+	for _, value := range values {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", value.Key, value.Value, value.Source)
+	}
+	return tw.Flush()
+}
+
+// readConfigFile reads the settings and aliases in a settings file, and
+// returns the keys that are not settings, sorted; a missing file has none
+func readConfigFile(path string) (map[string]string, map[string]string, []string, error) {
+	content, err := os.ReadFile(path) // #nosec G304 -- path is a settings file location
+	if os.IsNotExist(err) {
+		return nil, nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, nil, WrapInternalError("reading settings file", err)
+	}
+
+	// Scalars are read as written, so a color such as 000000 is not taken for a number
+	var raw map[string]yaml.Node
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, nil, nil, invalidConfigFileError(path, err)
+	}
+
+	settings := make(map[string]string)
+	aliases := make(map[string]string)
+	var unknown []string
+	for key, node := range raw {
+		if key == "aliases" {
+			if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+				continue
+			}
+			var entries map[string]yaml.Node
+			if node.Kind != yaml.MappingNode || node.Decode(&entries) != nil {
+				return nil, nil, nil, invalidConfigFileError(path, fmt.Errorf("aliases must map names to commands"))
+			}
+			for name, expansion := range entries {
+				if err := ValidateConfigValue(aliasKeyPrefix+name, expansion.Value); err != nil || expansion.Kind != yaml.ScalarNode {
+					return nil, nil, nil, invalidConfigFileError(path, fmt.Errorf("alias %s must be a command line", name))
+				}
+				aliases[name] = expansion.Value
+			}
+			continue
+		}
+
+		setting, ok := findConfigSetting(key)
+		if !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+		if node.Kind != yaml.ScalarNode {
+			return nil, nil, nil, invalidConfigValueError(key, "", path, fmt.Errorf("must be a single value"))
+		}
+		if err := setting.validate(node.Value); err != nil {
+			return nil, nil, nil, invalidConfigValueError(key, node.Value, path, err)
+		}
+		settings[key] = node.Value
+	}
+	sort.Strings(unknown)
+	return settings, aliases, unknown, nil
+}
+
+// mappingValue returns the value node for key in a YAML mapping, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue replaces or appends key in a YAML mapping; a nil value removes it
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != key {
+			continue
+		}
+		if value == nil {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+		} else {
+			mapping.Content[i+1] = value
+		}
+		return
+	}
+	if value != nil {
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	}
+}
+
+// setMappingScalar sets key to a string value in a YAML mapping; "" removes it.
+// An existing scalar is updated in place to keep its comments.
+func setMappingScalar(mapping *yaml.Node, key, value string) {
+	if value == "" {
+		setMappingValue(mapping, key, nil)
+		return
+	}
+	if existing := mappingValue(mapping, key); existing != nil && existing.Kind == yaml.ScalarNode {
+		existing.Value, existing.Tag, existing.Style = value, "", 0
+		return
+	}
+	setMappingValue(mapping, key, &yaml.Node{Kind: yaml.ScalarNode, Value: value})
+}
+
+// findConfigSetting looks up a setting by key
+func findConfigSetting(key string) (ConfigSetting, bool) {
+	for _, setting := range ConfigSettings {
+		if setting.Key == key {
+			return setting, true
+		}
+	}
+	return ConfigSetting{}, false
+}
+
+// SplitAliasArgs splits an alias expansion into arguments. Single and double
+// quotes group words, and a backslash escapes the next character outside
+// single quotes.
+func SplitAliasArgs(expansion string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inWord := false
+	var quote rune
+
+	runes := []rune(expansion)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != '\'' && r == '\\' && i+1 < len(runes):
+			i++
+			current.WriteRune(runes[i])
+			inWord = true
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				args = append(args, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Unterminated quote in alias: %s", expansion),
+			nil,
+		)
+	}
+	if inWord {
+		args = append(args, current.String())
+	}
+	if len(args) == 0 {
+		return nil, NewAppError(ErrorTypeValidation, "Alias expands to nothing", nil)
+	}
+	return args, nil
+}
+
+// oneOf returns a validator accepting only the given values
+func oneOf(allowed ...string) func(string) error {
+	return func(value string) error {
+		for _, candidate := range allowed {
+			if value == candidate {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %s", strings.Join(allowed, ", "))
+	}
+}
+
+// validateDuration accepts Go durations such as 90s or 10m, and 0
+func validateDuration(value string) error {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return fmt.Errorf("must be a duration such as 30s or 10m")
+	}
+	return nil
+}
+
+// validateConcurrency accepts whole numbers from 1 to 20
+func validateConcurrency(value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 || n > 20 {
+		return fmt.Errorf("must be a number from 1 to 20")
+	}
+	return nil
+}
+
+// validateNonEmpty rejects blank values
+func validateNonEmpty(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	return nil
+}
+
+// unknownConfigKeyError reports a key that is not a setting
+func unknownConfigKeyError(key string) *AppError {
+	keys := make([]string, 0, len(ConfigSettings))
+	for _, setting := range ConfigSettings {
+		keys = append(keys, setting.Key)
+	}
+	return NewAppError(
+		ErrorTypeValidation,
+		fmt.Sprintf("Unknown setting: %s", key),
+		nil,
+	).WithContext("settings", strings.Join(keys, ", ")).
+		WithSuggestion("Aliases are set as aliases.<name>, e.g. aliases.deps")
+}
+
+// invalidConfigValueError reports a value a setting does not accept; origin
+// names the file or environment variable it came from, if any
+func invalidConfigValueError(key, value, origin string, err error) *AppError {
+	appErr := NewAppError(
+		ErrorTypeValidation,
+		fmt.Sprintf("Invalid value for %s: %q %v", key, value, err),
+		err,
+	)
+	if origin != "" {
+		appErr = appErr.WithContext("from", origin)
+	}
+	return appErr
+}
+
+// invalidConfigFileError reports a settings file that cannot be parsed
+func invalidConfigFileError(path string, err error) *AppError {
+	return NewAppError(
+		ErrorTypeValidation,
+		fmt.Sprintf("Invalid settings file: %s", path),
+		err,
+	).WithSuggestion("Fix the YAML in the file, or remove it to use the defaults")
+}
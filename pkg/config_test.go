@@ -0,0 +1,202 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestConfig saves a settings file and returns its path
+func writeTestConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	userPath := writeTestConfig(t, `format: json
+sort: state
+label: waiting
+label_color: 000000
+aliases:
+  deps: list --state open --sort state
+  mine: status
+`)
+	repoPath := writeTestConfig(t, `format: csv
+state: open
+theme: dark
+aliases:
+  mine: status --format json
+  triage: list --label triage
+`)
+	env := map[string]string{"GH_ISSUE_DEPENDENCY_SORT": "-updated"}
+
+	cfg, err := loadConfig(userPath, repoPath, func(name string) string { return env[name] })
+	require.NoError(t, err)
+
+	tests := []struct {
+		key    string
+		value  string
+		source string
+	}{
+		{"format", "csv", ConfigSourceRepo},
+		{"sort", "-updated", ConfigSourceEnv},
+		{"state", "open", ConfigSourceRepo},
+		{"label", "waiting", ConfigSourceUser},
+		{"label_color", "000000", ConfigSourceUser},
+		{"cache_ttl", "5m0s", ConfigSourceDefault},
+		{"aliases.deps", "list --state open --sort state", ConfigSourceUser},
+		{"aliases.mine", "status", ConfigSourceUser},
+		{"aliases.triage", "list --label triage", ConfigSourceRepo},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			value, err := cfg.Get(tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, value.Value)
+			assert.Equal(t, tt.source, value.Source)
+		})
+	}
+
+	values := cfg.Values()
+	assert.Len(t, values, len(ConfigSettings)+3)
+	assert.Equal(t, "format", values[0].Key)
+	assert.Equal(t, "aliases.deps", values[len(values)-3].Key)
+
+	// Unknown keys in the repository file are skipped with a warning
+	assert.Equal(t, []string{"Ignoring unknown setting theme in " + repoPath}, cfg.Warnings)
+
+	_, err = cfg.Get("aliases.missing")
+	assert.Error(t, err)
+	_, err = cfg.Get("colour")
+	assert.Error(t, err)
+}
+
+func TestLoadConfigMissingFiles(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := loadConfig(filepath.Join(dir, "config.yml"), "", func(string) string { return "" })
+	require.NoError(t, err)
+	assert.Equal(t, "table", cfg.Value("format"))
+	assert.Equal(t, "4", cfg.Value("concurrency"))
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		env           map[string]string
+		errorContains string
+	}{
+		{"unknown key", "colour: never\n", nil, "Unknown setting: colour"},
+		{"invalid value", "format: xml\n", nil, "Invalid value for format"},
+		{"invalid duration", "cache_ttl: soon\n", nil, "Invalid value for cache_ttl"},
+		{"concurrency out of range", "concurrency: 50\n", nil, "Invalid value for concurrency"},
+		{"invalid YAML", "format: [\n", nil, "Invalid settings file"},
+		{"aliases not a mapping", "aliases: deps\n", nil, "Invalid settings file"},
+		{"unterminated alias quote", "aliases:\n  deps: list --label 'a\n", nil, "Invalid settings file"},
+		{"invalid env value", "", map[string]string{"GH_ISSUE_DEPENDENCY_COLOR": "sometimes"}, "Invalid value for color"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTestConfig(t, tt.content)
+			_, err := loadConfig(path, "", func(name string) string { return tt.env[name] })
+			require.Error(t, err)
+			assert.True(t, IsErrorType(err, ErrorTypeValidation))
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestSetConfigValue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.yml")
+
+	require.NoError(t, SetConfigValue(path, "format", "json"))
+	require.NoError(t, SetConfigValue(path, "aliases.deps", "list --state open"))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "format: json\naliases:\n    deps: list --state open\n", string(content))
+
+	// Comments and other keys survive edits
+	require.NoError(t, os.WriteFile(path, []byte("# team defaults\nformat: json # for scripts\nsort: state\n"), 0600))
+	require.NoError(t, SetConfigValue(path, "format", "csv"))
+	require.NoError(t, SetConfigValue(path, "sort", ""))
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# team defaults\nformat: csv # for scripts\n", string(content))
+
+	// Removing the last alias removes the aliases mapping
+	require.NoError(t, SetConfigValue(path, "aliases.deps", "list"))
+	require.NoError(t, SetConfigValue(path, "aliases.deps", ""))
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "aliases")
+
+	assert.Error(t, SetConfigValue(path, "colour", "never"))
+	assert.Error(t, SetConfigValue(path, "state", "merged"))
+	assert.Error(t, SetConfigValue(path, "aliases.two words", "list"))
+}
+
+func TestFindRepoConfigPath(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0750))
+	nested := filepath.Join(root, "a", "b")
+	require.NoError(t, os.MkdirAll(nested, 0750))
+
+	assert.Equal(t, filepath.Join(root, ".github", "issue-dependency.yml"), FindRepoConfigPath(nested))
+}
+
+func TestConfigApply(t *testing.T) {
+	originalTTL, originalConcurrency := cacheTTL, bulkConcurrency
+	defer func() { cacheTTL, bulkConcurrency = originalTTL, originalConcurrency }()
+
+	path := writeTestConfig(t, "cache_ttl: 0\nconcurrency: 8\n")
+	cfg, err := loadConfig(path, "", func(string) string { return "" })
+	require.NoError(t, err)
+	cfg.Apply()
+
+	assert.Zero(t, cacheTTL)
+	assert.Equal(t, 8, bulkConcurrency)
+}
+
+func TestSplitAliasArgs(t *testing.T) {
+	tests := []struct {
+		expansion string
+		want      []string
+	}{
+		{"list --state open", []string{"list", "--state", "open"}},
+		{`list --label "needs review" --sort state`, []string{"list", "--label", "needs review", "--sort", "state"}},
+		{`list --label 'a "b"'`, []string{"list", "--label", `a "b"`}},
+		{`list --label a\ b`, []string{"list", "--label", "a b"}},
+		{`list --label ""`, []string{"list", "--label", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expansion, func(t *testing.T) {
+			args, err := SplitAliasArgs(tt.expansion)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, args)
+		})
+	}
+
+	_, err := SplitAliasArgs(`list "open`)
+	assert.Error(t, err)
+	_, err = SplitAliasArgs("   ")
+	assert.Error(t, err)
+}
+
+func TestFormatConfigValues(t *testing.T) {
+	var buf strings.Builder
+	require.NoError(t, FormatConfigValues(&buf, []ConfigValue{
+		{Key: "format", Value: "json", Source: ConfigSourceUser},
+		{Key: "aliases.deps", Value: "list --state open", Source: ConfigSourceRepo},
+	}))
+	assert.Equal(t, `KEY           VALUE              SOURCE
+format        json               user
+aliases.deps  list --state open  repo
+`, buf.String())
+}
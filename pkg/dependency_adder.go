@@ -0,0 +1,350 @@
+// Package pkg provides GitHub API integration for creating dependency relationships.
+//
+// This file implements the write side of the dependency API: validating that a new
+// relationship is safe to create, issuing the POST request, and reporting results
+// using the same conventions as the removal workflow.
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// DependencyAdder provides GitHub API integration for creating dependency relationships.
+// It handles POST operations, duplicate and cycle detection, retry logic, and reporting.
+type DependencyAdder struct {
+	client *api.RESTClient
+}
+
+// AddOptions contains options for dependency creation operations
+type AddOptions struct {
+	DryRun bool
+	Force  bool
+	Atomic bool // Roll back every added relationship if any addition fails
+}
+
+// NewDependencyAdder creates a new dependency adder with GitHub API client
+func NewDependencyAdder() (*DependencyAdder, error) {
+	// Verify GitHub CLI authentication
+	if err := SetupGitHubClient(); err != nil {
+		return nil, err
+	}
+
+	// Create GitHub API client
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return nil, WrapInternalError("creating GitHub API client for dependency creation", err)
+	}
+
+	return &DependencyAdder{
+		client: client,
+	}, nil
+}
+
+// AddRelationship creates a single dependency relationship between two issues
+func (a *DependencyAdder) AddRelationship(source, target IssueRef, relType string, opts AddOptions) error {
+	return a.AddBatchRelationships(source, []IssueRef{target}, relType, opts)
+}
+
+// AddBatchRelationships creates multiple dependency relationships for one source issue
+func (a *DependencyAdder) AddBatchRelationships(source IssueRef, targets []IssueRef, relType string, opts AddOptions) error {
+	// 1. Validate every relationship before making any changes
+	for _, target := range targets {
+		if err := a.validateAddition(source, target, relType); err != nil {
+			return err
+		}
+	}
+
+	// 2. Handle dry run mode
+	if opts.DryRun {
+		return a.showDryRunPreview(source, targets, relType)
+	}
+
+	// 3. Create relationships
+	ops := make([]BulkOperation, len(targets))
+	for i, target := range targets {
+		ops[i] = BulkOperation{Source: source, Target: target, RelType: relType}
+	}
+
+	var results []BulkResult
+	if opts.Atomic {
+		results = runAtomicOperations(ops, a.applyOperation, a.remover().applyOperation)
+	} else {
+		results = runBulkOperations(ops, 1, a.applyOperation)
+	}
+
+	if countResults(results, StatusApplied) < len(results) {
+		if len(results) == 1 && !opts.Atomic {
+			return results[0].Err
+		}
+		return reportBatchResults("addition", results, opts.Atomic)
+	}
+
+	return a.showSuccessMessage(source, targets, relType)
+}
+
+// applyOperation creates the relationship described by op and journals it
+func (a *DependencyAdder) applyOperation(op BulkOperation) error {
+	prior := priorStateFor(a.client, op)
+	if err := a.createRelationshipWithRetry(op.Source, op.Target, op.RelType); err != nil {
+		return err
+	}
+
+	recordMutation(a.client, JournalActionAdd, op, prior, "")
+	return nil
+}
+
+// remover returns a DependencyRemover sharing this adder's client. It is used
+// to revert additions when an atomic batch rolls back.
+func (a *DependencyAdder) remover() *DependencyRemover {
+	return &DependencyRemover{
+		client:    a.client,
+		validator: &RemovalValidator{client: a.client},
+	}
+}
+
+// validateOperation checks that the relationship described by op can be created
+func (a *DependencyAdder) validateOperation(op BulkOperation) error {
+	return a.validateAddition(op.Source, op.Target, op.RelType)
+}
+
+// validateAddition checks that a relationship can be created between two issues
+func (a *DependencyAdder) validateAddition(source, target IssueRef, relType string) error {
+	if source.Owner == "" || source.Repo == "" || source.Number <= 0 {
+		return NewEmptyValueError("source issue reference")
+	}
+	if target.Owner == "" || target.Repo == "" || target.Number <= 0 {
+		return NewEmptyValueError("target issue reference")
+	}
+
+	if relType != "blocked-by" && relType != "blocks" {
+		return NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Invalid relationship type: %s", relType),
+			nil,
+		).WithContext("relationship_type", relType).
+			WithSuggestion("Use either 'blocked-by' or 'blocks'")
+	}
+
+	if source.Owner == target.Owner && source.Repo == target.Repo && source.Number == target.Number {
+		return NewAppError(
+			ErrorTypeValidation,
+			"Cannot create a dependency relationship from an issue to itself",
+			nil,
+		).WithContext("issue", source.String()).
+			WithSuggestion("Specify different source and target issues")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	dependencies, err := FetchIssueDependencies(ctx, source.Owner, source.Repo, source.Number)
+	if err != nil {
+		return fmt.Errorf("failed to fetch dependencies for %s: %w", source.String(), err)
+	}
+
+	validator := &RemovalValidator{client: a.client}
+	if validator.relationshipExistsInData(dependencies, target, relType) {
+		return NewDependencyExistsError(source.String(), target.String())
+	}
+
+	// The reverse relationship would make the two issues block each other
+	if validator.relationshipExistsInData(dependencies, target, inverseRelationType(relType)) {
+		return NewCircularDependencyError(source.String(), target.String())
+	}
+
+	// Keep the target's state for the journal entry of the addition
+	targetIssue, err := fetchIssueDetails(ctx, a.client, target.Owner, target.Repo, target.Number)
+	if err != nil {
+		return err
+	}
+	rememberPriorState(BulkOperation{Source: source, Target: target, RelType: relType}, JournalPriorState{
+		TargetState: targetIssue.State,
+		TargetTitle: targetIssue.Title,
+	})
+
+	return nil
+}
+
+// createRelationshipWithRetry performs the POST operation with retry logic
+func (a *DependencyAdder) createRelationshipWithRetry(source, target IssueRef, relType string) error {
+	maxRetries := 3
+	baseDelay := 1 * time.Second
+
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		err := a.createRelationship(source, target, relType)
+		if err == nil {
+			return nil
+		}
+
+		if !isRetryableError(err) {
+			return err
+		}
+
+		if attempt == maxRetries {
+			return fmt.Errorf("creation failed after %d attempts: %w", maxRetries, err)
+		}
+
+		time.Sleep(retryDelay(err, attempt, baseDelay))
+	}
+
+	return nil
+}
+
+// createRelationship performs the actual POST API call.
+//
+// GitHub only exposes a write endpoint for the "blocked by" side of a relationship,
+// so "A blocks B" is created as "B blocked by A".
+func (a *DependencyAdder) createRelationship(source, target IssueRef, relType string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	blocked, blocker := source, target
+	if relType == "blocks" {
+		blocked, blocker = target, source
+	}
+
+	// The write endpoint identifies the blocking issue by database ID, not number
+	blockerIssue, err := fetchIssueDetails(ctx, a.client, blocker.Owner, blocker.Repo, blocker.Number)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]int64{"issue_id": blockerIssue.ID})
+	if err != nil {
+		return WrapInternalError("encoding dependency request", err)
+	}
+
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d/dependencies/blocked_by",
+		blocked.Owner, blocked.Repo, blocked.Number)
+
+	if err := a.client.Post(endpoint, bytes.NewReader(body), nil); err != nil {
+		return a.handleCreateError(err, source, target, relType)
+	}
+
+	// Both sides of the relationship changed; drop any cached copies
+	invalidateCachedDependencies(source)
+	invalidateCachedDependencies(target)
+
+	return nil
+}
+
+// handleCreateError processes and categorizes creation errors
+func (a *DependencyAdder) handleCreateError(err error, source, target IssueRef, relType string) error {
+	errMsg := strings.ToLower(err.Error())
+
+	if strings.Contains(errMsg, "unauthorized") || strings.Contains(errMsg, "401") {
+		return WrapAuthError(err).WithSuggestion("Run 'gh auth login' to authenticate")
+	}
+
+	if strings.Contains(errMsg, "forbidden") || strings.Contains(errMsg, "403") {
+		repoName := fmt.Sprintf("%s/%s", source.Owner, source.Repo)
+		return NewPermissionDeniedError("add dependencies", repoName).WithSuggestion(
+			"You need write or maintain permissions to modify dependencies")
+	}
+
+	if strings.Contains(errMsg, "already") {
+		return NewDependencyExistsError(source.String(), target.String())
+	}
+
+	if strings.Contains(errMsg, "circular") || strings.Contains(errMsg, "cycle") {
+		return NewCircularDependencyError(source.String(), target.String())
+	}
+
+	if strings.Contains(errMsg, "not found") || strings.Contains(errMsg, "404") {
+		return NewAppError(
+			ErrorTypeIssue,
+			fmt.Sprintf("Cannot create %s relationship: %s or %s was not found",
+				relType, source.String(), target.String()),
+			err,
+		).WithSuggestion("Verify both issues exist and are accessible")
+	}
+
+	if strings.Contains(errMsg, "rate limit") || strings.Contains(errMsg, "429") {
+		return WrapAPIError(429, err)
+	}
+
+	if strings.Contains(errMsg, "timeout") || strings.Contains(errMsg, "connection") {
+		return WrapNetworkError(err)
+	}
+
+	if strings.Contains(errMsg, "500") || strings.Contains(errMsg, "502") || strings.Contains(errMsg, "503") {
+		return WrapAPIError(500, err)
+	}
+
+	return WrapInternalError("adding dependency relationship", err)
+}
+
+// showDryRunPreview displays what would be created in dry run mode
+func (a *DependencyAdder) showDryRunPreview(source IssueRef, targets []IssueRef, relType string) error {
+	fmt.Printf("Dry run: dependency creation preview\n\n")
+	fmt.Printf("Would add %d relationships:\n", len(targets))
+
+	for _, target := range targets {
+		fmt.Printf("  ➕ %s relationship: %s %s %s\n",
+			relType, source.String(), relationshipSymbol(relType), target.String())
+	}
+
+	fmt.Printf("\nNo changes made. Remove --dry-run to execute.\n")
+
+	return nil
+}
+
+// showSuccessMessage displays success confirmation after creating relationships
+func (a *DependencyAdder) showSuccessMessage(source IssueRef, targets []IssueRef, relType string) error {
+	if len(targets) == 1 {
+		fmt.Printf("✅ Added %s relationship: %s %s %s\n",
+			relType, source.String(), relationshipSymbol(relType), targets[0].String())
+		return nil
+	}
+
+	fmt.Printf("✅ Added %d %s relationships:\n", len(targets), relType)
+	for _, target := range targets {
+		fmt.Printf("  %s %s %s\n", source.String(), relationshipSymbol(relType), target.String())
+	}
+
+	return nil
+}
+
+// relationshipSymbol returns the arrow used to display a relationship type
+func relationshipSymbol(relType string) string {
+	switch relType {
+	case "blocked-by":
+		return "←"
+	case "blocks":
+		return "→"
+	default:
+		return "↔"
+	}
+}
+
+// inverseRelationType returns the relationship type seen from the other issue
+func inverseRelationType(relType string) string {
+	if relType == "blocks" {
+		return "blocked-by"
+	}
+	return "blocks"
+}
+
+// isRetryableError determines if an error should trigger a retry
+func isRetryableError(err error) bool {
+	if IsErrorType(err, ErrorTypeNetwork) {
+		return true
+	}
+	if IsErrorType(err, ErrorTypeAPI) {
+		// Rate limits and server errors are retryable
+		errMsg := strings.ToLower(err.Error())
+		return strings.Contains(errMsg, "rate limit") ||
+			strings.Contains(errMsg, "500") ||
+			strings.Contains(errMsg, "502") ||
+			strings.Contains(errMsg, "503")
+	}
+
+	return false
+}
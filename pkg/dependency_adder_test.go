@@ -0,0 +1,76 @@
+package pkg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddOptionsDefaults(t *testing.T) {
+	opts := AddOptions{}
+	assert.False(t, opts.DryRun)
+	assert.False(t, opts.Force)
+}
+
+func TestValidateAdditionInputs(t *testing.T) {
+	adder := &DependencyAdder{}
+	source := CreateIssueRef("owner", "repo", 1)
+
+	tests := []struct {
+		name          string
+		target        IssueRef
+		relType       string
+		errorContains string
+	}{
+		{"empty target", IssueRef{}, "blocked-by", "target issue reference cannot be empty"},
+		{"invalid type", CreateIssueRef("owner", "repo", 2), "depends-on", "Invalid relationship type"},
+		{"self reference", CreateIssueRef("owner", "repo", 1), "blocks", "to itself"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := adder.validateAddition(source, tt.target, tt.relType)
+			require.Error(t, err)
+			assert.True(t, IsErrorType(err, ErrorTypeValidation))
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestHandleCreateError(t *testing.T) {
+	adder := &DependencyAdder{}
+	source := CreateIssueRef("owner", "repo", 1)
+	target := CreateIssueRef("owner", "repo", 2)
+
+	tests := []struct {
+		name     string
+		err      error
+		expected ErrorType
+	}{
+		{"unauthorized", errors.New("HTTP 401: Unauthorized"), ErrorTypeAuthentication},
+		{"forbidden", errors.New("HTTP 403: Forbidden"), ErrorTypePermission},
+		{"duplicate", errors.New("HTTP 422: Dependency already exists"), ErrorTypeIssue},
+		{"not found", errors.New("HTTP 404: Not Found"), ErrorTypeIssue},
+		{"rate limit", errors.New("API rate limit exceeded"), ErrorTypeAPI},
+		{"network", errors.New("connection reset"), ErrorTypeNetwork},
+		{"unknown", errors.New("something odd"), ErrorTypeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := adder.handleCreateError(tt.err, source, target, "blocked-by")
+			assert.Equal(t, tt.expected, GetErrorType(err))
+		})
+	}
+}
+
+func TestRelationshipHelpers(t *testing.T) {
+	assert.Equal(t, "←", relationshipSymbol("blocked-by"))
+	assert.Equal(t, "→", relationshipSymbol("blocks"))
+	assert.Equal(t, "blocks", inverseRelationType("blocked-by"))
+	assert.Equal(t, "blocked-by", inverseRelationType("blocks"))
+	assert.True(t, isRetryableError(WrapNetworkError(errors.New("timeout"))))
+	assert.False(t, isRetryableError(NewEmptyValueError("x")))
+}
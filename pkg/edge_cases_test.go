@@ -523,8 +523,15 @@ func TestMemoryAndPerformanceEdgeCases(t *testing.T) {
 				State:      "open",
 				Repository: createRepositoryInfo(deepRepo),
 			},
+			BlockedBy: []DependencyRelation{
+				{
+					Issue:      Issue{Number: 45, Title: "Deep blocker", State: "open"},
+					Type:       "blocked_by",
+					Repository: deepRepo,
+				},
+			},
 			FetchedAt:  time.Now(),
-			TotalCount: 0,
+			TotalCount: 1,
 		}
 
 		// Should handle deep repository paths
@@ -538,8 +545,10 @@ func TestMemoryAndPerformanceEdgeCases(t *testing.T) {
 		err := formatter.FormatOutput(data)
 
 		assert.NoError(t, err, "Should handle deep repository paths")
-		output := buffer.String()
-		assert.Contains(t, output, deepRepo, "Should display full repository path")
+		// Plain output is one tab-separated row per relationship, with the
+		// repository in full
+		assert.Equal(t, "blocked_by\t45\tDeep blocker\topen\t\t"+deepRepo+"\n", buffer.String(),
+			"Should display full repository path")
 	})
 }
 
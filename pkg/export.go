@@ -0,0 +1,115 @@
+// Package pkg provides structured export shared by every command that emits JSON.
+//
+// Commands build a JSON-compatible value and hand it to ExportJSON, which either
+// encodes it as indented JSON, filters it with an embedded jq evaluator, or
+// renders it through a Go template using the same helper functions as `gh --template`.
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/cli/go-gh/v2/pkg/jq"
+	"github.com/cli/go-gh/v2/pkg/template"
+	"github.com/cli/go-gh/v2/pkg/term"
+)
+
+// DefaultTemplateWidth is the table width used by templates when the terminal size is unknown
+const DefaultTemplateWidth = 80
+
+// ExportOptions controls how structured data is written
+type ExportOptions struct {
+	Template string // Go template applied to the JSON data, as with `gh --template`
+	JQ       string // jq expression applied to the JSON data, as with `gh --jq`
+}
+
+// Validate checks that the export options can be used together
+func (o ExportOptions) Validate() error {
+	if o.Template != "" && o.JQ != "" {
+		return NewAppError(
+			ErrorTypeValidation,
+			"Cannot combine --jq with --template",
+			nil,
+		).WithSuggestion("Use either --jq or --template, not both")
+	}
+	return nil
+}
+
+// ExportJSON writes data as indented JSON, or through opts.Template or opts.JQ when set
+func ExportJSON(w io.Writer, data interface{}, opts ExportOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	if opts.Template != "" {
+		return renderTemplate(w, data, opts.Template)
+	}
+
+	if opts.JQ != "" {
+		return evaluateJQ(w, data, opts.JQ)
+	}
+
+	// Use Go's JSON encoder for consistent formatting
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+// renderTemplate executes tmpl against the JSON form of data. Templates see the
+// same field names as --json output and can use gh's helpers such as tablerow,
+// tablerender, timeago, color, hyperlink, and truncate.
+func renderTemplate(w io.Writer, data interface{}, tmpl string) error {
+	input, err := json.Marshal(data)
+	if err != nil {
+		return WrapInternalError("encoding template input", err)
+	}
+
+	terminal := term.FromEnv()
+	width, _, err := terminal.Size()
+	if err != nil || width <= 0 {
+		width = DefaultTemplateWidth
+	}
+
+	t := template.New(w, width, terminal.IsColorEnabled())
+	if err := t.Parse(tmpl); err != nil {
+		return NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Invalid template: %v", err),
+			err,
+		).WithSuggestion("See 'gh help formatting' for template syntax and helper functions")
+	}
+
+	if err := t.Execute(bytes.NewReader(input)); err != nil {
+		return NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Template execution failed: %v", err),
+			err,
+		).WithSuggestion("Check that the template only references fields present in the --json output")
+	}
+
+	return t.Flush()
+}
+
+// evaluateJQ filters the JSON form of data with a jq expression. Scalar results
+// are written raw, matching `gh --jq`, so no external jq binary is required.
+func evaluateJQ(w io.Writer, data interface{}, expr string) error {
+	input, err := json.Marshal(data)
+	if err != nil {
+		return WrapInternalError("encoding jq input", err)
+	}
+
+	terminal := term.FromEnv()
+	colorize := terminal.IsTerminalOutput() && terminal.IsColorEnabled()
+
+	if err := jq.EvaluateFormatted(bytes.NewReader(input), w, expr, "  ", colorize); err != nil {
+		return NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Invalid jq expression: %v", err),
+			err,
+		).WithSuggestion("Check the expression against the fields in the --json output")
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportJSON(t *testing.T) {
+	t.Run("indented JSON by default", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, ExportJSON(&buf, map[string]interface{}{"count": 2}, ExportOptions{}))
+		assert.Equal(t, "{\n  \"count\": 2\n}\n", buf.String())
+	})
+
+	t.Run("template", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		data := map[string]interface{}{
+			"items": []map[string]interface{}{
+				{"number": 12, "title": "Auth"},
+				{"number": 15, "title": "A very long title that needs truncating"},
+			},
+		}
+
+		tests := []struct {
+			name     string
+			template string
+			expected string
+		}{
+			{"range", `{{range .items}}{{.number}} {{.title}}{{"\n"}}{{end}}`, "12 Auth\n15 A very long title that needs truncating\n"},
+			{"truncate", `{{range .items}}{{truncate 10 .title}}{{"\n"}}{{end}}`, "Auth\nA very ...\n"},
+			{"tablerow", `{{range .items}}{{tablerow .number .title}}{{end}}`, "12  Auth\n15  A very long title that needs truncating\n"},
+			{"hyperlink", `{{hyperlink "https://example.com" "docs"}}`, "\x1b]8;;https://example.com\x1b\\docs\x1b]8;;\x1b\\"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var buf bytes.Buffer
+				require.NoError(t, ExportJSON(&buf, data, ExportOptions{Template: tt.template}))
+				assert.Equal(t, tt.expected, buf.String())
+			})
+		}
+	})
+
+	t.Run("jq", func(t *testing.T) {
+		data := map[string]interface{}{
+			"blocked_by": []map[string]interface{}{
+				{"number": 12, "state": "open"},
+				{"number": 15, "state": "closed"},
+				{"number": 18, "state": "open"},
+			},
+		}
+
+		tests := []struct {
+			name     string
+			expr     string
+			expected string
+		}{
+			{"scalars are raw", `.blocked_by[] | select(.state=="open") | .number`, "12\n18\n"},
+			{"strings are raw", `.blocked_by[0].state`, "open\n"},
+			{"objects are indented", `.blocked_by[1]`, "{\n  \"number\": 15,\n  \"state\": \"closed\"\n}\n"},
+			{"aggregates", `[.blocked_by[] | select(.state=="open")] | length`, "2\n"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var buf bytes.Buffer
+				require.NoError(t, ExportJSON(&buf, data, ExportOptions{JQ: tt.expr}))
+				assert.Equal(t, tt.expected, buf.String())
+			})
+		}
+	})
+
+	t.Run("invalid jq", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := ExportJSON(&buf, map[string]interface{}{}, ExportOptions{JQ: ".blocked_by[] |"})
+		require.Error(t, err)
+		assert.True(t, IsErrorType(err, ErrorTypeValidation))
+		assert.Contains(t, err.Error(), "Invalid jq expression")
+	})
+
+	t.Run("jq and template are exclusive", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := ExportJSON(&buf, map[string]interface{}{}, ExportOptions{JQ: ".", Template: "{{.}}"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Cannot combine --jq with --template")
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := ExportJSON(&buf, map[string]interface{}{}, ExportOptions{Template: "{{range .items}"})
+		require.Error(t, err)
+		assert.True(t, IsErrorType(err, ErrorTypeValidation))
+		assert.Contains(t, err.Error(), "Invalid template")
+	})
+}
+
+func TestFormatJSONOutputTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewOutputFormatter(&OutputOptions{
+		Format:     FormatJSON,
+		JSONFields: []string{"blocked_by"},
+		Writer:     &buf,
+		Export:     ExportOptions{Template: `{{range .blocked_by}}#{{.number}} {{.state}}{{"\n"}}{{end}}{{len .}}`},
+	})
+
+	require.NoError(t, formatter.FormatOutput(createTestDependencyData()))
+	assert.Equal(t, "#45 open\n#67 closed\n1", buf.String(), "templates see the same fields as --json")
+
+	// Without a template the same structure is encoded as JSON
+	buf.Reset()
+	formatter.options.Export = ExportOptions{}
+	require.NoError(t, formatter.FormatOutput(createTestDependencyData()))
+
+	var output map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	assert.Len(t, output, 1)
+}
@@ -0,0 +1,217 @@
+// Package pkg provides JSON field selection for dependency output.
+//
+// Fields are addressed with dotted paths into the structure built by
+// formatJSONOutput, e.g. "blocked_by.number" or "summary.total_count". A path
+// into a list selects that key from every element of the list.
+package pkg
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// jsonIssueFields are the keys available on every issue in JSON output
+var jsonIssueFields = []string{
+	"number", "title", "state", "repository", "assignees", "labels", "html_url",
+	"state_reason", "author", "milestone", "issue_type", "opened_at", "updated_at",
+	"closed_at", "comments", "locked", "parent", "sub_issues",
+}
+
+// jsonDetailedIssueFields are only included with --detailed unless selected explicitly
+var jsonDetailedIssueFields = map[string]bool{
+	"assignees":  true,
+	"labels":     true,
+	"html_url":   true,
+	"author":     true,
+	"milestone":  true,
+	"issue_type": true,
+	"opened_at":  true,
+	"updated_at": true,
+	"closed_at":  true,
+	"comments":   true,
+	"locked":     true,
+	"created_at": true,
+	"created_by": true,
+}
+
+// jsonRelationFields are the keys available only on blocked_by and blocks items
+var jsonRelationFields = []string{"created_at", "created_by", "pull_requests"}
+
+// jsonSummaryFields are the keys available on the summary object
+var jsonSummaryFields = []string{
+	"total_count", "blocked_by_count", "blocks_count", "fetched_at", "rolled_up_blocked_by_count",
+}
+
+// JSONFieldNames returns every field accepted by --json, sorted
+func JSONFieldNames() []string {
+	fields := []string{"source_issue", "blocked_by", "blocks", "rolled_up_blocked_by", "summary"}
+	for _, parent := range []string{"source_issue", "blocked_by", "blocks", "rolled_up_blocked_by"} {
+		for _, field := range jsonIssueFields {
+			fields = append(fields, parent+"."+field)
+		}
+	}
+	for _, parent := range []string{"blocked_by", "blocks", "rolled_up_blocked_by"} {
+		for _, field := range jsonRelationFields {
+			fields = append(fields, parent+"."+field)
+		}
+	}
+	fields = append(fields, "rolled_up_blocked_by.via")
+	for _, field := range jsonSummaryFields {
+		fields = append(fields, "summary."+field)
+	}
+
+	sort.Strings(fields)
+	return fields
+}
+
+// ValidateJSONFields checks every requested field against JSONFieldNames and
+// suggests the closest valid names for unknown ones
+func ValidateJSONFields(fields []string) error {
+	valid := JSONFieldNames()
+	known := make(map[string]bool, len(valid))
+	for _, field := range valid {
+		known[field] = true
+	}
+
+	for _, field := range fields {
+		if known[field] {
+			continue
+		}
+
+		appErr := NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Unknown JSON field: %s", field),
+			nil,
+		).WithContext("field", field)
+
+		if matches := closestMatches(field, valid, 3); len(matches) > 0 {
+			appErr.WithSuggestion(fmt.Sprintf("Did you mean: %s?", strings.Join(matches, ", ")))
+		}
+		return appErr.WithSuggestion("Use --json without a value to list available fields")
+	}
+
+	return nil
+}
+
+// selectJSONFields projects output down to the requested dotted paths
+func selectJSONFields(output map[string]interface{}, fields []string) map[string]interface{} {
+	tree := fieldTree{}
+	for _, field := range fields {
+		tree.add(strings.Split(field, "."))
+	}
+
+	return projectJSON(output, tree).(map[string]interface{})
+}
+
+// fieldTree is a set of dotted paths; a nil subtree selects the whole value
+type fieldTree map[string]fieldTree
+
+// add inserts a path into the tree. Selecting a parent wins over its children.
+func (t fieldTree) add(path []string) {
+	subtree, exists := t[path[0]]
+	if len(path) == 1 {
+		t[path[0]] = nil
+		return
+	}
+	if exists && subtree == nil {
+		return // the whole value is already selected
+	}
+	if subtree == nil {
+		subtree = fieldTree{}
+		t[path[0]] = subtree
+	}
+	subtree.add(path[1:])
+}
+
+// projectJSON keeps only the keys in tree, applying it to each element of lists
+func projectJSON(value interface{}, tree fieldTree) interface{} {
+	if tree == nil {
+		return value
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(tree))
+		for key, subtree := range tree {
+			if child, exists := v[key]; exists {
+				result[key] = projectJSON(child, subtree)
+			}
+		}
+		return result
+	case []map[string]interface{}:
+		result := make([]map[string]interface{}, len(v))
+		for i, item := range v {
+			result[i] = projectJSON(item, tree).(map[string]interface{})
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// requestedLeafFields returns the final segment of every dotted path in fields
+func requestedLeafFields(fields []string) map[string]bool {
+	leaves := make(map[string]bool)
+	for _, field := range fields {
+		if i := strings.LastIndex(field, "."); i >= 0 {
+			leaves[field[i+1:]] = true
+		}
+	}
+	return leaves
+}
+
+// closestMatches returns up to limit candidates closest to value by edit
+// distance, ignoring candidates too different to be a plausible typo
+func closestMatches(value string, candidates []string, limit int) []string {
+	type match struct {
+		candidate string
+		distance  int
+	}
+
+	value = strings.ToLower(value)
+	maxDistance := len(value)/3 + 1
+
+	var matches []match
+	for _, candidate := range candidates {
+		distance := levenshtein(value, strings.ToLower(candidate))
+		if distance <= maxDistance || strings.Contains(candidate, value) {
+			matches = append(matches, match{candidate, distance})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	var result []string
+	for i := 0; i < len(matches) && i < limit; i++ {
+		result = append(result, matches[i].candidate)
+	}
+	return result
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
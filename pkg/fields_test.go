@@ -0,0 +1,115 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONFieldNames(t *testing.T) {
+	fields := JSONFieldNames()
+
+	assert.Contains(t, fields, "blocked_by")
+	assert.Contains(t, fields, "blocked_by.number")
+	assert.Contains(t, fields, "source_issue.title")
+	assert.Contains(t, fields, "summary.total_count")
+	assert.IsNonDecreasing(t, fields)
+}
+
+func TestValidateJSONFields(t *testing.T) {
+	assert.NoError(t, ValidateJSONFields([]string{"blocked_by.number", "summary"}))
+
+	tests := []struct {
+		name       string
+		field      string
+		suggestion string
+	}{
+		{"typo in nested field", "blocked_by.nubmer", "blocked_by.number"},
+		{"typo in top-level field", "sumary", "summary"},
+		{"singular relationship", "block", "blocks"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJSONFields([]string{"summary", tt.field})
+			require.Error(t, err)
+			assert.True(t, IsErrorType(err, ErrorTypeValidation))
+			assert.Contains(t, err.Error(), "Unknown JSON field: "+tt.field)
+
+			var appErr *AppError
+			require.ErrorAs(t, err, &appErr)
+			require.NotEmpty(t, appErr.Suggestions)
+			assert.Contains(t, appErr.Suggestions[0], tt.suggestion)
+		})
+	}
+}
+
+func TestValidateJSONFieldsRejectsTrue(t *testing.T) {
+	// --json true names a field like any other value; it is not a bare --json
+	err := ValidateJSONFields([]string{"true"})
+	require.Error(t, err)
+	assert.True(t, IsErrorType(err, ErrorTypeValidation))
+	assert.Contains(t, err.Error(), "Unknown JSON field: true")
+}
+
+func TestSelectJSONFields(t *testing.T) {
+	output := map[string]interface{}{
+		"blocked_by": []map[string]interface{}{
+			{"number": 1, "state": "open", "title": "A"},
+			{"number": 2, "state": "closed", "title": "B"},
+		},
+		"summary": map[string]interface{}{"total_count": 2, "blocks_count": 0},
+		"blocks":  []map[string]interface{}{},
+	}
+
+	selected := selectJSONFields(output, []string{"blocked_by.number", "blocked_by.state", "summary.total_count"})
+
+	assert.Equal(t, map[string]interface{}{
+		"blocked_by": []map[string]interface{}{
+			{"number": 1, "state": "open"},
+			{"number": 2, "state": "closed"},
+		},
+		"summary": map[string]interface{}{"total_count": 2},
+	}, selected)
+
+	// Selecting a parent keeps the whole value regardless of order
+	selected = selectJSONFields(output, []string{"summary.total_count", "summary"})
+	assert.Equal(t, output["summary"], selected["summary"])
+}
+
+func TestFormatJSONOutputNestedFields(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewOutputFormatter(&OutputOptions{
+		Format:     FormatJSON,
+		JSONFields: []string{"blocks.number", "blocks.labels"},
+		Writer:     &buf,
+	})
+	require.NoError(t, formatter.FormatOutput(createTestDependencyData()))
+
+	var output map[string][]map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	require.Len(t, output["blocks"], 1)
+
+	// Detailed fields are included when selected explicitly, without --detailed
+	assert.Equal(t, float64(89), output["blocks"][0]["number"])
+	assert.Len(t, output["blocks"][0]["labels"], 2)
+	assert.NotContains(t, output["blocks"][0], "title")
+}
+
+func TestClosestMatches(t *testing.T) {
+	candidates := []string{"number", "title", "state", "updated", "created"}
+
+	assert.Equal(t, []string{"number"}, closestMatches("nubmer", candidates, 3))
+	assert.Equal(t, []string{"state"}, closestMatches("STATE", candidates, 3))
+	assert.Empty(t, closestMatches("zzzzzzzz", candidates, 3))
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("state", "state"))
+	assert.Equal(t, 1, levenshtein("blocks", "block"))
+	assert.Equal(t, 2, levenshtein("nubmer", "number"))
+	assert.Equal(t, 3, levenshtein("", "abc"))
+}
@@ -0,0 +1,253 @@
+// Package pkg provides attribute filters for dependency relations.
+//
+// Each filter flag is repeatable, and a value prefixed with "-" excludes
+// matching issues instead of requiring them. Different flags combine with AND.
+// Positive values of one flag combine with OR, except labels, which must all be
+// present as with `gh issue list --label`.
+package pkg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// FilterMe stands for the authenticated user in --assignee and --author values
+const FilterMe = "@me"
+
+// DependencyFilter selects dependency relations by issue attributes
+type DependencyFilter struct {
+	Labels       []string // Label names; all positive labels are required
+	Assignees    []string // Assignee logins; any positive login matches
+	Authors      []string // Issue author logins; any positive login matches
+	Milestones   []string // Milestone titles; any positive title matches
+	Repositories []string // owner/repo or bare repository names; any positive value matches
+}
+
+// filterCriterion is one filter flag with its values and how to match an issue
+type filterCriterion struct {
+	flag    string
+	values  []string
+	matches func(dep DependencyRelation, value string) bool
+	all     bool // Every positive value must match instead of any
+}
+
+// criteria returns the filter flags in the order they are reported
+func (f DependencyFilter) criteria() []filterCriterion {
+	return []filterCriterion{
+		{flag: "label", values: f.Labels, matches: hasLabel, all: true},
+		{flag: "assignee", values: f.Assignees, matches: hasAssignee},
+		{flag: "author", values: f.Authors, matches: hasAuthor},
+		{flag: "milestone", values: f.Milestones, matches: hasMilestone},
+		{flag: "repo-filter", values: f.Repositories, matches: inRepository},
+	}
+}
+
+// IsEmpty reports whether no filter values are set
+func (f DependencyFilter) IsEmpty() bool {
+	for _, criterion := range f.criteria() {
+		if len(criterion.values) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// UsesMe reports whether an assignee or author value refers to the authenticated user
+func (f DependencyFilter) UsesMe() bool {
+	for _, value := range append(append([]string{}, f.Assignees...), f.Authors...) {
+		if strings.EqualFold(strings.TrimPrefix(value, "-"), FilterMe) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithMe returns a copy of the filter with @me replaced by login
+func (f DependencyFilter) WithMe(login string) DependencyFilter {
+	replace := func(values []string) []string {
+		resolved := make([]string, len(values))
+		for i, value := range values {
+			negated := strings.HasPrefix(value, "-")
+			if strings.EqualFold(strings.TrimPrefix(value, "-"), FilterMe) {
+				value = login
+				if negated {
+					value = "-" + login
+				}
+			}
+			resolved[i] = value
+		}
+		return resolved
+	}
+
+	f.Assignees = replace(f.Assignees)
+	f.Authors = replace(f.Authors)
+	return f
+}
+
+// Validate rejects empty values, including a bare "-"
+func (f DependencyFilter) Validate() error {
+	for _, criterion := range f.criteria() {
+		for _, value := range criterion.values {
+			if strings.TrimSpace(strings.TrimPrefix(value, "-")) == "" {
+				return NewAppError(
+					ErrorTypeValidation,
+					fmt.Sprintf("Invalid --%s value: %q", criterion.flag, value),
+					nil,
+				).WithContext("flag", criterion.flag).
+					WithSuggestion(fmt.Sprintf("Use --%s <value> to require a value or --%s -<value> to exclude it",
+						criterion.flag, criterion.flag))
+			}
+		}
+	}
+	return nil
+}
+
+// Matches reports whether dep passes every filter flag
+func (f DependencyFilter) Matches(dep DependencyRelation) bool {
+	return len(f.Rejections(dep)) == 0
+}
+
+// Rejections returns the flags, such as "--label bug", that exclude dep
+func (f DependencyFilter) Rejections(dep DependencyRelation) []string {
+	var rejected []string
+	for _, criterion := range f.criteria() {
+		if !criterion.accepts(dep) {
+			rejected = append(rejected, criterion.describe())
+		}
+	}
+	return rejected
+}
+
+// Flags returns the set filter flags as reported by Rejections, in flag order
+func (f DependencyFilter) Flags() []string {
+	var flags []string
+	for _, criterion := range f.criteria() {
+		if len(criterion.values) > 0 {
+			flags = append(flags, criterion.describe())
+		}
+	}
+	return flags
+}
+
+// Apply returns a copy of data containing only the relations that match
+func (f DependencyFilter) Apply(data *DependencyData) *DependencyData {
+	if f.IsEmpty() {
+		return data
+	}
+
+	filtered := &DependencyData{
+		SourceIssue:       data.SourceIssue,
+		BlockedBy:         []DependencyRelation{},
+		Blocking:          []DependencyRelation{},
+		FetchedAt:         data.FetchedAt,
+		SourceHierarchy:   data.SourceHierarchy,
+		RolledUpBlockedBy: data.RolledUpBlockedBy,
+	}
+
+	for _, dep := range data.BlockedBy {
+		if f.Matches(dep) {
+			filtered.BlockedBy = append(filtered.BlockedBy, dep)
+		}
+	}
+	for _, dep := range data.Blocking {
+		if f.Matches(dep) {
+			filtered.Blocking = append(filtered.Blocking, dep)
+		}
+	}
+
+	filtered.TotalCount = len(filtered.BlockedBy) + len(filtered.Blocking)
+	return filtered
+}
+
+// accepts applies one criterion: no negated value may match, and any (or, for
+// labels, every) positive value must match
+func (c filterCriterion) accepts(dep DependencyRelation) bool {
+	positives, matched := 0, 0
+	for _, value := range c.values {
+		if negated, ok := strings.CutPrefix(value, "-"); ok {
+			if c.matches(dep, negated) {
+				return false
+			}
+			continue
+		}
+
+		positives++
+		if c.matches(dep, value) {
+			matched++
+		}
+	}
+
+	if positives == 0 {
+		return true
+	}
+	if c.all {
+		return matched == positives
+	}
+	return matched > 0
+}
+
+// describe returns the criterion as it was written on the command line
+func (c filterCriterion) describe() string {
+	return fmt.Sprintf("--%s %s", c.flag, strings.Join(c.values, ","))
+}
+
+// hasLabel reports whether the issue has a label named value
+func hasLabel(dep DependencyRelation, value string) bool {
+	for _, label := range dep.Issue.Labels {
+		if strings.EqualFold(label.Name, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAssignee reports whether value is one of the issue's assignees
+func hasAssignee(dep DependencyRelation, value string) bool {
+	value = strings.TrimPrefix(value, "@")
+	for _, assignee := range dep.Issue.Assignees {
+		if strings.EqualFold(assignee.Login, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAuthor reports whether value opened the issue
+func hasAuthor(dep DependencyRelation, value string) bool {
+	return dep.Issue.User != nil && strings.EqualFold(dep.Issue.User.Login, strings.TrimPrefix(value, "@"))
+}
+
+// hasMilestone reports whether the issue's milestone is titled value
+func hasMilestone(dep DependencyRelation, value string) bool {
+	return dep.Issue.Milestone != nil && strings.EqualFold(dep.Issue.Milestone.Title, value)
+}
+
+// inRepository reports whether the issue lives in value, given as owner/repo or
+// as a bare repository name
+func inRepository(dep DependencyRelation, value string) bool {
+	if strings.Contains(value, "/") {
+		return strings.EqualFold(dep.Repository, value)
+	}
+	_, name, _ := strings.Cut(dep.Repository, "/")
+	return strings.EqualFold(name, value)
+}
+
+// CurrentUserLogin returns the login of the authenticated user for @me filters
+func CurrentUserLogin() (string, error) {
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return "", WrapAuthError(err)
+	}
+
+	login := currentUserLogin(client)
+	if login == "" {
+		return "", NewAppError(
+			ErrorTypeAuthentication,
+			"Could not determine the authenticated user for @me",
+			nil,
+		).WithSuggestion("Run 'gh auth status' to check your login, or use your username instead of @me")
+	}
+	return login, nil
+}
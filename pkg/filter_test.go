@@ -0,0 +1,133 @@
+package pkg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createFilterTestData returns test data with authors and a milestone set
+func createFilterTestData() *DependencyData {
+	data := createTestDependencyData()
+	data.BlockedBy[0].Issue.User = &User{Login: "alice"}
+	data.BlockedBy[0].Issue.Labels = []Label{{Name: "backend"}, {Name: "urgent"}}
+	data.BlockedBy[0].Issue.Milestone = &Milestone{Title: "v2.0"}
+	data.BlockedBy[1].Issue.User = &User{Login: "bob"}
+	data.Blocking[0].Issue.User = &User{Login: "alice"}
+	return data
+}
+
+// filteredNumbers applies filter to createFilterTestData and returns the remaining issue numbers
+func filteredNumbers(filter DependencyFilter) []int {
+	data := filter.Apply(createFilterTestData())
+
+	numbers := []int{}
+	for _, deps := range [][]DependencyRelation{data.BlockedBy, data.Blocking} {
+		for _, dep := range deps {
+			numbers = append(numbers, dep.Issue.Number)
+		}
+	}
+	return numbers
+}
+
+func TestDependencyFilterApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   DependencyFilter
+		expected []int
+	}{
+		{"empty filter keeps everything", DependencyFilter{}, []int{45, 67, 89}},
+		{"assignee", DependencyFilter{Assignees: []string{"bob"}}, []int{45}},
+		{"any assignee matches", DependencyFilter{Assignees: []string{"bob", "@Diana"}}, []int{45, 89}},
+		{"negated assignee", DependencyFilter{Assignees: []string{"-bob"}}, []int{67, 89}},
+		{"all labels required", DependencyFilter{Labels: []string{"urgent", "frontend"}}, []int{89}},
+		{"label is case-insensitive", DependencyFilter{Labels: []string{"BACKEND"}}, []int{45}},
+		{"negated label", DependencyFilter{Labels: []string{"-urgent"}}, []int{67}},
+		{"author", DependencyFilter{Authors: []string{"alice"}}, []int{45, 89}},
+		{"author without a user never matches", DependencyFilter{Authors: []string{"-alice"}}, []int{67}},
+		{"milestone", DependencyFilter{Milestones: []string{"V2.0"}}, []int{45}},
+		{"full repository", DependencyFilter{Repositories: []string{"testowner/frontend"}}, []int{89}},
+		{"bare repository name", DependencyFilter{Repositories: []string{"-frontend"}}, []int{45, 67}},
+		{"flags combine with and", DependencyFilter{Authors: []string{"alice"}, Labels: []string{"urgent"}}, []int{45, 89}},
+		{"nothing matches", DependencyFilter{Assignees: []string{"zed"}}, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, filteredNumbers(tt.filter))
+		})
+	}
+}
+
+func TestDependencyFilterApplyCounts(t *testing.T) {
+	data := DependencyFilter{Assignees: []string{"bob"}}.Apply(createFilterTestData())
+	assert.Equal(t, 1, data.TotalCount)
+	assert.Len(t, data.BlockedBy, 1)
+	assert.Empty(t, data.Blocking)
+}
+
+func TestDependencyFilterValidate(t *testing.T) {
+	assert.NoError(t, DependencyFilter{Labels: []string{"bug", "-wontfix"}}.Validate())
+
+	for _, value := range []string{"-", "", " "} {
+		err := DependencyFilter{Milestones: []string{value}}.Validate()
+		require.Error(t, err, "value %q", value)
+
+		var appErr *AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, ErrorTypeValidation, appErr.Type)
+		assert.Contains(t, appErr.Message, "--milestone")
+	}
+}
+
+func TestDependencyFilterMe(t *testing.T) {
+	filter := DependencyFilter{Assignees: []string{"@me", "bob"}, Authors: []string{"-@ME"}}
+	assert.True(t, filter.UsesMe())
+	assert.False(t, DependencyFilter{Assignees: []string{"bob"}}.UsesMe())
+
+	resolved := filter.WithMe("octocat")
+	assert.Equal(t, []string{"octocat", "bob"}, resolved.Assignees)
+	assert.Equal(t, []string{"-octocat"}, resolved.Authors)
+	assert.Equal(t, []string{"@me", "bob"}, filter.Assignees, "the original filter is unchanged")
+}
+
+func TestFilteredEmptyStateMessage(t *testing.T) {
+	original := createFilterTestData()
+	filter := DependencyFilter{Assignees: []string{"zed"}, Labels: []string{"-urgent"}}
+
+	filtered := filter.Apply(applyTestStateFilter(original, "open"))
+	require.Equal(t, 0, filtered.TotalCount)
+
+	var buf bytes.Buffer
+	formatter := NewOutputFormatter(&OutputOptions{
+		Format:       FormatPlain,
+		Writer:       &buf,
+		StateFilter:  "open",
+		Filter:       filter,
+		OriginalData: original,
+	})
+	require.NoError(t, formatter.FormatOutput(filtered))
+
+	output := buf.String()
+	assert.Contains(t, output, "No dependencies match the filters for issue #123.")
+	assert.Contains(t, output, "3 dependencies found; excluded 1 by --state open, 2 by --label -urgent, 3 by --assignee zed.")
+}
+
+// applyTestStateFilter keeps relations in the given state, like the list command's --state
+func applyTestStateFilter(data *DependencyData, state string) *DependencyData {
+	filtered := &DependencyData{SourceIssue: data.SourceIssue, FetchedAt: data.FetchedAt}
+	for _, dep := range data.BlockedBy {
+		if dep.Issue.State == state {
+			filtered.BlockedBy = append(filtered.BlockedBy, dep)
+		}
+	}
+	for _, dep := range data.Blocking {
+		if dep.Issue.State == state {
+			filtered.Blocking = append(filtered.Blocking, dep)
+		}
+	}
+	filtered.TotalCount = len(filtered.BlockedBy) + len(filtered.Blocking)
+	return filtered
+}
@@ -11,7 +11,6 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -49,13 +48,52 @@ type Label struct {
 
 // Issue represents a GitHub issue with dependency-relevant fields
 type Issue struct {
-	Number     int            `json:"number"`
-	Title      string         `json:"title"`
-	State      string         `json:"state"`
-	Assignees  []User         `json:"assignees"`
-	Labels     []Label        `json:"labels"`
-	HTMLURL    string         `json:"html_url"`
-	Repository RepositoryInfo `json:"repository,omitempty"` // Repository object from GitHub API
+	ID          int64          `json:"id"` // Database ID used by the dependency write endpoints
+	Number      int            `json:"number"`
+	Title       string         `json:"title"`
+	State       string         `json:"state"`
+	User        *User          `json:"user,omitempty"` // Author of the issue
+	Assignees   []User         `json:"assignees"`
+	Labels      []Label        `json:"labels"`
+	HTMLURL     string         `json:"html_url"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	ClosedAt    time.Time      `json:"closed_at"`    // Zero while the issue is open
+	StateReason string         `json:"state_reason"` // completed, not_planned, reopened, or "" while never closed
+	Comments    int            `json:"comments"`
+	Locked      bool           `json:"locked"`
+	Type        *IssueType     `json:"type,omitempty"` // Organization issue type, when configured
+	Milestone   *Milestone     `json:"milestone,omitempty"`
+	Repository  RepositoryInfo `json:"repository,omitempty"` // Repository object from GitHub API
+
+	SubIssuesSummary *SubIssuesSummary `json:"sub_issues_summary,omitempty"` // Sub-issue counts, when GitHub reports them
+}
+
+// State reasons reported by GitHub for closed and reopened issues
+const (
+	StateReasonCompleted  = "completed"
+	StateReasonNotPlanned = "not_planned"
+	StateReasonReopened   = "reopened"
+)
+
+// IsNotPlanned reports whether the issue was closed as not planned rather than completed
+func (i Issue) IsNotPlanned() bool {
+	return strings.EqualFold(i.State, "closed") && i.StateReason == StateReasonNotPlanned
+}
+
+// IssueType represents an organization-defined issue type such as Bug or Task
+type IssueType struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Color       string `json:"color"`
+}
+
+// Milestone represents a GitHub milestone
+type Milestone struct {
+	Number int       `json:"number"`
+	Title  string    `json:"title"`
+	DueOn  time.Time `json:"due_on"`
 }
 
 // RepositoryInfo represents repository information from GitHub API
@@ -83,6 +121,21 @@ type DependencyRelation struct {
 	Issue      Issue  `json:"issue"`
 	Type       string `json:"type"`       // "blocked_by" or "blocks"
 	Repository string `json:"repository"` // Repository of the related issue
+
+	// CreatedAt and CreatedBy record when and by whom the relationship was
+	// added. They are only populated by EnrichWithRelationshipEvents.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+
+	// Hierarchy is the related issue's parent and sub-issues, and Via lists the
+	// sub-issues a rolled-up blocker blocks. Both are only populated by
+	// EnrichWithHierarchy.
+	Hierarchy *Hierarchy `json:"hierarchy,omitempty"`
+	Via       []string   `json:"via,omitempty"`
+
+	// PullRequests are the pull requests that will close the related issue,
+	// closest to done first. Only populated by EnrichWithPullRequests.
+	PullRequests []LinkedPullRequest `json:"pull_requests,omitempty"`
 }
 
 // DependencyData contains all dependency information for an issue
@@ -94,6 +147,10 @@ type DependencyData struct {
 	TotalCount             int                  `json:"total_count"`
 	OriginalBlockedByCount int                  `json:"original_blocked_by_count,omitempty"`
 	OriginalBlockingCount  int                  `json:"original_blocking_count,omitempty"`
+
+	// SourceHierarchy and RolledUpBlockedBy are only populated by EnrichWithHierarchy
+	SourceHierarchy   *Hierarchy           `json:"source_hierarchy,omitempty"`
+	RolledUpBlockedBy []DependencyRelation `json:"rolled_up_blocked_by,omitempty"`
 }
 
 // CacheEntry represents a cached dependency data entry
@@ -104,10 +161,14 @@ type CacheEntry struct {
 
 // Cache configuration
 const (
-	CacheDir      = ".gh-issue-dependency-cache"
-	CacheDuration = 5 * time.Minute // Cache for 5 minutes
+	CacheDir      = ".gh-issue-dependency-cache" // Legacy cache directory under the home directory, migrated on first use
+	CacheDuration = 5 * time.Minute              // Cache for 5 minutes
 )
 
+// cacheTTL is how long new cache entries stay valid; the cache_ttl setting
+// changes it, and zero turns the cache off
+var cacheTTL = CacheDuration
+
 // Repository Context Detection
 //
 // These functions handle repository context detection following gh-sub-issue patterns.
@@ -489,12 +550,17 @@ func extractRepoFromURL(url string) string {
 
 // fetchDependencies retrieves all dependency data for an issue using parallel API calls
 func fetchDependencies(ctx context.Context, owner, repo string, issueNumber int) (*DependencyData, error) {
-	// Create GitHub API client
-	client, err := api.DefaultRESTClient()
+	// Revalidate stored responses so unchanged data costs no rate limit
+	client, err := newConditionalRESTClient()
 	if err != nil {
-		return nil, WrapInternalError("creating GitHub API client", err)
+		return nil, err
 	}
 
+	return fetchDependencyData(ctx, client, owner, repo, issueNumber)
+}
+
+// fetchDependencyData implements fetchDependencies with an explicit client
+func fetchDependencyData(ctx context.Context, client *api.RESTClient, owner, repo string, issueNumber int) (*DependencyData, error) {
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
@@ -576,8 +642,9 @@ func FetchIssueDependencies(ctx context.Context, owner, repo string, issueNumber
 
 	// Try to get from cache first
 	cacheKey := getCacheKey(owner, repo, issueNumber)
-	if data, found := getFromCache(cacheKey); found {
-		return data, nil
+	entry, found := readCacheEntry(cacheKey)
+	if found && time.Now().Before(entry.ExpiresAt) {
+		return &entry.Data, nil
 	}
 
 	// Verify GitHub CLI authentication
@@ -585,9 +652,12 @@ func FetchIssueDependencies(ctx context.Context, owner, repo string, issueNumber
 		return nil, err
 	}
 
-	// Validate repository access
-	if err := ValidateRepoAccess(owner, repo); err != nil {
-		return nil, err
+	// Validate repository access. An expired entry means access was checked
+	// before, and revalidating its responses reports lost access anyway.
+	if !found {
+		if err := ValidateRepoAccess(owner, repo); err != nil {
+			return nil, err
+		}
 	}
 
 	// Fetch dependency data
@@ -609,33 +679,29 @@ func getCacheKey(owner, repo string, issueNumber int) string {
 	return fmt.Sprintf("%x", hash)
 }
 
-// getCacheDir returns the cache directory path
+// getCacheDir returns the cache directory of the current host and user
 func getCacheDir() string {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return CacheDir // fallback to relative path
-	}
-	return filepath.Join(homeDir, CacheDir)
+	return openCacheStore().dir
 }
 
-// getFromCache attempts to retrieve data from cache
+// getFromCache attempts to retrieve unexpired data from cache
 func getFromCache(key string) (*DependencyData, bool) {
-	cacheDir := getCacheDir()
-	cachePath := filepath.Join(cacheDir, key+".json")
-
-	// Validate path to prevent directory traversal
-	if !strings.HasPrefix(cachePath, cacheDir) {
+	entry, found := readCacheEntry(key)
+	if !found || time.Now().After(entry.ExpiresAt) {
 		return nil, false
 	}
+	return &entry.Data, true
+}
 
-	// Check if cache file exists
-	if _, err := os.Stat(cachePath); os.IsNotExist(err) {
+// readCacheEntry reads a cache entry whether or not it has expired. Expired
+// entries are left for CleanExpiredCache.
+func readCacheEntry(key string) (*CacheEntry, bool) {
+	if cacheTTL <= 0 {
 		return nil, false
 	}
 
-	// Read cache file
-	data, err := os.ReadFile(cachePath) // #nosec G304 -- cachePath validated against directory traversal
-	if err != nil {
+	data, found := openCacheStore().read(key + ".json")
+	if !found {
 		return nil, false
 	}
 
@@ -645,29 +711,19 @@ func getFromCache(key string) (*DependencyData, bool) {
 		return nil, false
 	}
 
-	// Check if cache entry has expired
-	if time.Now().After(entry.ExpiresAt) {
-		// Remove expired cache file
-		_ = os.Remove(cachePath) // Ignore cleanup errors
-		return nil, false
-	}
-
-	return &entry.Data, true
+	return &entry, true
 }
 
 // saveToCache stores data in cache
 func saveToCache(key string, data *DependencyData) {
-	cacheDir := getCacheDir()
-
-	// Create cache directory if it doesn't exist
-	if err := os.MkdirAll(cacheDir, 0750); err != nil {
-		return // fail silently
+	if cacheTTL <= 0 {
+		return
 	}
 
 	// Create cache entry
 	entry := CacheEntry{
 		Data:      *data,
-		ExpiresAt: time.Now().Add(CacheDuration),
+		ExpiresAt: time.Now().Add(cacheTTL),
 	}
 
 	// Marshal to JSON
@@ -676,63 +732,73 @@ func saveToCache(key string, data *DependencyData) {
 		return // fail silently
 	}
 
-	// Write to cache file
-	cachePath := filepath.Join(cacheDir, key+".json")
-	if err := os.WriteFile(cachePath, jsonData, 0600); err != nil {
+	if err := openCacheStore().write(key+".json", jsonData); err != nil {
 		// Log error but don't fail the main operation
 		fmt.Fprintf(os.Stderr, "Warning: failed to write cache file: %v\n", err)
 	}
 }
 
-// CleanExpiredCache removes expired cache entries
+// invalidateCachedDependencies removes the cached dependency data for an issue
+// after a mutation so the next read reflects the change.
+func invalidateCachedDependencies(ref IssueRef) {
+	openCacheStore().remove(getCacheKey(ref.Owner, ref.Repo, ref.Number) + ".json")
+}
+
+// CleanExpiredCache removes expired dependency data, stored responses not
+// used within ResponseRetention, and least recently used entries past the
+// cache size limit. Every entry is read, so the scan runs at most once per
+// CacheCleanInterval; other calls return right away.
 func CleanExpiredCache() error {
-	cacheDir := getCacheDir()
+	return cleanCacheStore(openCacheStore(), time.Now())
+}
 
-	// Check if cache directory exists
-	if _, err := os.Stat(cacheDir); os.IsNotExist(err) {
+// cleanCacheStore implements CleanExpiredCache for an explicit store
+func cleanCacheStore(store *cacheStore, now time.Time) error {
+	if _, err := os.Stat(store.dir); os.IsNotExist(err) {
 		return nil // no cache to clean
 	}
 
-	// Read cache directory
-	files, err := os.ReadDir(cacheDir)
-	if err != nil {
-		return err
-	}
-
-	now := time.Now()
-	for _, file := range files {
-		if !strings.HasSuffix(file.Name(), ".json") {
-			continue
+	stamp := store.path(cacheCleanStamp)
+	if info, err := os.Stat(stamp); err == nil {
+		if since := now.Sub(info.ModTime()); since >= 0 && since < CacheCleanInterval {
+			return nil // cleaned recently
 		}
+	}
 
-		cachePath := filepath.Join(cacheDir, file.Name())
+	return store.withLock(func() error {
+		for _, file := range store.files(now) {
+			if !strings.HasSuffix(file.name, ".json") {
+				continue
+			}
 
-		// Validate path to prevent directory traversal
-		if !strings.HasPrefix(cachePath, cacheDir) {
-			continue
-		}
+			// Stored responses expire when unused; dependency data at its ExpiresAt
+			if strings.HasPrefix(file.name, ResponseCacheDir+"/") {
+				if now.Sub(file.modTime) > ResponseRetention {
+					store.remove(file.name)
+				}
+				continue
+			}
 
-		// Read cache file
-		data, err := os.ReadFile(cachePath) // #nosec G304 -- cachePath validated against directory traversal
-		if err != nil {
-			continue
+			// Read without marking the entry as used, so eviction order holds
+			data, err := os.ReadFile(store.path(file.name)) // #nosec G304 -- name listed from the store
+			if err != nil {
+				continue
+			}
+			var entry CacheEntry
+			if err := json.Unmarshal(data, &entry); err != nil || now.After(entry.ExpiresAt) {
+				// Remove malformed and expired entries
+				store.remove(file.name)
+			}
 		}
 
-		// Parse cache entry
-		var entry CacheEntry
-		if err := json.Unmarshal(data, &entry); err != nil {
-			// Remove malformed cache files
-			_ = os.Remove(cachePath) // Ignore cleanup errors
-			continue
-		}
+		store.evict()
 
-		// Remove expired entries
-		if now.After(entry.ExpiresAt) {
-			_ = os.Remove(cachePath) // Ignore cleanup errors
+		// Writes evict on their own, so a missed stamp only means an early rescan
+		if err := os.WriteFile(stamp, nil, 0600); err == nil {
+			_ = os.Chtimes(stamp, now, now)
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
 
 // GitHub API Integration for Dependency Removal
@@ -801,7 +867,8 @@ func (r *DependencyRemover) RemoveRelationship(source, target IssueRef, relType
 	}
 
 	// 4. Execute deletion with retry logic
-	if err := r.deleteRelationshipWithRetry(source, target, relType); err != nil {
+	op := BulkOperation{Source: source, Target: target, RelType: relType}
+	if err := r.applyOperation(op); err != nil {
 		return fmt.Errorf("deletion failed: %w", err)
 	}
 
@@ -837,7 +904,7 @@ func (r *DependencyRemover) RemoveBatchRelationships(source IssueRef, targets []
 	}
 
 	// 4. Execute batch deletion
-	return r.executeBatchDeletion(source, targets, relType)
+	return r.executeBatchDeletion(source, targets, relType, opts.Atomic)
 }
 
 // deleteRelationshipWithRetry performs the actual DELETE operation with retry logic
@@ -861,9 +928,8 @@ func (r *DependencyRemover) deleteRelationshipWithRetry(source, target IssueRef,
 			return fmt.Errorf("deletion failed after %d attempts: %w", maxRetries, err)
 		}
 
-		// Exponential backoff delay
-		delay := time.Duration(attempt) * baseDelay
-		time.Sleep(delay)
+		// Wait as long as GitHub asks, or back off linearly
+		time.Sleep(retryDelay(err, attempt, baseDelay))
 	}
 
 	return nil
@@ -890,6 +956,10 @@ func (r *DependencyRemover) deleteRelationship(source, target IssueRef, relType
 		return r.handleDeleteError(err, source, target, relType)
 	}
 
+	// Both sides of the relationship changed; drop any cached copies
+	invalidateCachedDependencies(source)
+	invalidateCachedDependencies(target)
+
 	return nil
 }
 
@@ -993,50 +1063,49 @@ func (r *DependencyRemover) handleDeleteError(err error, source, target IssueRef
 
 // isRetryableError determines if an error should trigger a retry
 func (r *DependencyRemover) isRetryableError(err error) bool {
-	// Check for retryable error types
-	if IsErrorType(err, ErrorTypeNetwork) {
-		return true
-	}
-	if IsErrorType(err, ErrorTypeAPI) {
-		// Rate limits and server errors are retryable
-		errMsg := strings.ToLower(err.Error())
-		return strings.Contains(errMsg, "rate limit") ||
-			strings.Contains(errMsg, "500") ||
-			strings.Contains(errMsg, "502") ||
-			strings.Contains(errMsg, "503")
-	}
-
-	return false
+	return isRetryableError(err)
 }
 
-// executeBatchDeletion performs batch deletion of multiple relationships
-func (r *DependencyRemover) executeBatchDeletion(source IssueRef, targets []IssueRef, relType string) error {
-	var errors []string
-	successCount := 0
+// executeBatchDeletion performs batch deletion of multiple relationships.
+// In atomic mode the first failure restores every relationship already removed.
+func (r *DependencyRemover) executeBatchDeletion(source IssueRef, targets []IssueRef, relType string, atomic bool) error {
+	ops := make([]BulkOperation, len(targets))
+	for i, target := range targets {
+		ops[i] = BulkOperation{Source: source, Target: target, RelType: relType}
+	}
 
-	for _, target := range targets {
-		err := r.deleteRelationshipWithRetry(source, target, relType)
-		if err != nil {
-			errors = append(errors, fmt.Sprintf("%s: %v", target.String(), err))
-		} else {
-			successCount++
-		}
+	var results []BulkResult
+	if atomic {
+		results = runAtomicOperations(ops, r.applyOperation, r.adder().applyOperation)
+	} else {
+		results = runBulkOperations(ops, 1, r.applyOperation)
 	}
 
-	// Report results
-	if len(errors) > 0 {
-		return NewAppError(
-			ErrorTypeAPI,
-			fmt.Sprintf("Batch removal partially failed: %d succeeded, %d failed",
-				successCount, len(errors)),
-			nil,
-		).WithContext("errors", strings.Join(errors, "; ")).WithSuggestion(
-			"Review the errors and retry failed operations individually")
+	// Report per-target results
+	if countResults(results, StatusApplied) < len(results) {
+		return reportBatchResults("removal", results, atomic)
 	}
 
 	return r.showBatchSuccessMessage(source, targets, relType)
 }
 
+// applyOperation removes the relationship described by op and journals it
+func (r *DependencyRemover) applyOperation(op BulkOperation) error {
+	prior := priorStateFor(r.client, op)
+	if err := r.deleteRelationshipWithRetry(op.Source, op.Target, op.RelType); err != nil {
+		return err
+	}
+
+	recordMutation(r.client, JournalActionRemove, op, prior, "")
+	return nil
+}
+
+// adder returns a DependencyAdder sharing this remover's client. It is used
+// to restore removed relationships when an atomic batch rolls back.
+func (r *DependencyRemover) adder() *DependencyAdder {
+	return &DependencyAdder{client: r.client}
+}
+
 // User Interface and Confirmation Functions
 //
 // These functions handle user interaction, confirmation prompts, dry run previews,
@@ -1267,6 +1336,11 @@ func (r *DependencyRemover) RemoveAllRelationships(issue IssueRef, opts RemoveOp
 		blockingTargets = append(blockingTargets, target)
 	}
 
+	// Atomic mode removes both directions as a single transaction
+	if opts.Atomic {
+		return r.removeAllAtomically(issue, blockedByTargets, blockingTargets, opts)
+	}
+
 	// Remove blocked-by relationships
 	if len(blockedByTargets) > 0 {
 		if err := r.RemoveBatchRelationships(issue, blockedByTargets, "blocked-by", opts); err != nil {
@@ -1289,6 +1363,28 @@ func (r *DependencyRemover) RemoveAllRelationships(issue IssueRef, opts RemoveOp
 	return nil
 }
 
+// removeAllAtomically removes every relationship of an issue as one transaction
+func (r *DependencyRemover) removeAllAtomically(issue IssueRef, blockedByTargets, blockingTargets []IssueRef, opts RemoveOptions) error {
+	var ops []BulkOperation
+	for _, target := range blockedByTargets {
+		ops = append(ops, BulkOperation{Source: issue, Target: target, RelType: "blocked-by"})
+	}
+	for _, target := range blockingTargets {
+		ops = append(ops, BulkOperation{Source: issue, Target: target, RelType: "blocks"})
+	}
+
+	bulkOpts := BulkOptions{DryRun: opts.DryRun, Force: opts.Force, Atomic: true}
+	if err := executeBulkOperations("remove", "", ops, bulkOpts, nil, r.applyOperation, r.adder().applyOperation); err != nil {
+		return err
+	}
+
+	if !opts.DryRun {
+		fmt.Printf("\n✅ Removed all dependency relationships for %s\n", issue.String())
+	}
+
+	return nil
+}
+
 // dependencyRelationToIssueRef converts a DependencyRelation to an IssueRef
 func (r *DependencyRemover) dependencyRelationToIssueRef(relation DependencyRelation) IssueRef {
 	// Parse the repository from the relation
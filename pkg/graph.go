@@ -0,0 +1,315 @@
+// Package pkg provides repository-wide dependency graph crawling and analysis.
+//
+// A DependencyGraph holds every issue in a repository that takes part in a
+// dependency relationship, along with the blockers it points to (which may live
+// in other repositories). Reports and cycle detection are built on top of it.
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// Graph crawl configuration
+const (
+	CrawlPageSize     = 100  // Maximum page size allowed by the issues API
+	DefaultCrawlLimit = 1000 // Default maximum number of issues read from a repository
+)
+
+// GraphNode is an issue in the dependency graph
+type GraphNode struct {
+	ID         string    `json:"id"` // owner/repo#number
+	Repository string    `json:"repository"`
+	Number     int       `json:"number"`
+	Title      string    `json:"title"`
+	State      string    `json:"state"`
+	HTMLURL    string    `json:"html_url"`
+	Assignees  []string  `json:"assignees,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// GraphEdge records that From blocks To
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// DependencyGraph is the set of issues and blocking relationships in a repository
+type DependencyGraph struct {
+	Repository string      `json:"repository"`
+	Nodes      []GraphNode `json:"nodes"`
+	Edges      []GraphEdge `json:"edges"`
+	CrawledAt  time.Time   `json:"crawled_at"`
+
+	index map[string]int
+	edges map[GraphEdge]bool
+}
+
+// CrawlOptions controls how a repository is crawled
+type CrawlOptions struct {
+	Limit int // Maximum number of issues to read; DefaultCrawlLimit when zero
+}
+
+// NewDependencyGraph creates an empty graph for repository
+func NewDependencyGraph(repository string) *DependencyGraph {
+	return &DependencyGraph{
+		Repository: repository,
+		Nodes:      []GraphNode{},
+		Edges:      []GraphEdge{},
+		index:      make(map[string]int),
+		edges:      make(map[GraphEdge]bool),
+	}
+}
+
+// GraphNodeID returns the node ID used for an issue
+func GraphNodeID(repository string, number int) string {
+	return fmt.Sprintf("%s#%d", repository, number)
+}
+
+// AddIssue adds or updates the node for issue in repository and returns its ID
+func (g *DependencyGraph) AddIssue(repository string, issue Issue) string {
+	id := GraphNodeID(repository, issue.Number)
+
+	assignees := make([]string, 0, len(issue.Assignees))
+	for _, assignee := range issue.Assignees {
+		assignees = append(assignees, assignee.Login)
+	}
+
+	node := GraphNode{
+		ID:         id,
+		Repository: repository,
+		Number:     issue.Number,
+		Title:      issue.Title,
+		State:      issue.State,
+		HTMLURL:    issue.HTMLURL,
+		Assignees:  assignees,
+		UpdatedAt:  issue.UpdatedAt,
+	}
+
+	if i, exists := g.index[id]; exists {
+		g.Nodes[i] = node
+	} else {
+		g.index[id] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, node)
+	}
+	return id
+}
+
+// AddEdge records that from blocks to. Duplicate edges are ignored.
+func (g *DependencyGraph) AddEdge(from, to string) {
+	edge := GraphEdge{From: from, To: to}
+	if g.edges[edge] {
+		return
+	}
+	g.edges[edge] = true
+	g.Edges = append(g.Edges, edge)
+}
+
+// Node returns the node with the given ID
+func (g *DependencyGraph) Node(id string) (GraphNode, bool) {
+	i, exists := g.index[id]
+	if !exists {
+		return GraphNode{}, false
+	}
+	return g.Nodes[i], true
+}
+
+// Blockers returns the IDs of the issues blocking id, in edge order
+func (g *DependencyGraph) Blockers(id string) []string {
+	var blockers []string
+	for _, edge := range g.Edges {
+		if edge.To == id {
+			blockers = append(blockers, edge.From)
+		}
+	}
+	return blockers
+}
+
+// FindCycles returns every elementary blocking cycle in the graph. Each cycle is
+// a list of node IDs starting at its smallest ID, and cycles are sorted so the
+// result is stable across crawls.
+func (g *DependencyGraph) FindCycles() [][]string {
+	adjacency := make(map[string][]string)
+	for _, edge := range g.Edges {
+		adjacency[edge.From] = append(adjacency[edge.From], edge.To)
+	}
+
+	var cycles [][]string
+	seen := make(map[string]bool)
+
+	// Only report a cycle from its smallest node so each is found once
+	var visit func(start, current string, path []string, onPath map[string]bool)
+	visit = func(start, current string, path []string, onPath map[string]bool) {
+		for _, next := range adjacency[current] {
+			if next == start {
+				cycle := append([]string(nil), path...)
+				key := fmt.Sprint(cycle)
+				if !seen[key] {
+					seen[key] = true
+					cycles = append(cycles, cycle)
+				}
+				continue
+			}
+			if onPath[next] || next < start {
+				continue
+			}
+			onPath[next] = true
+			visit(start, next, append(path, next), onPath)
+			delete(onPath, next)
+		}
+	}
+
+	starts := make([]string, 0, len(adjacency))
+	for id := range adjacency {
+		starts = append(starts, id)
+	}
+	sort.Strings(starts)
+
+	for _, start := range starts {
+		visit(start, start, []string{start}, map[string]bool{start: true})
+	}
+
+	sort.SliceStable(cycles, func(i, j int) bool {
+		return fmt.Sprint(cycles[i]) < fmt.Sprint(cycles[j])
+	})
+	return cycles
+}
+
+// crawlIssueItem is an issue from the repository issues list. Newer API versions
+// include a dependency summary, which lets the crawl skip issues with no blockers.
+type crawlIssueItem struct {
+	Issue
+	PullRequest *struct {
+		URL string `json:"url"`
+	} `json:"pull_request,omitempty"`
+	DependencySummary *struct {
+		BlockedBy int `json:"blocked_by"`
+	} `json:"issue_dependencies_summary,omitempty"`
+}
+
+// CrawlRepository reads the issues in owner/repo and builds the graph of their
+// blocked-by relationships
+func CrawlRepository(ctx context.Context, owner, repo string, opts CrawlOptions) (*DependencyGraph, error) {
+	if owner == "" || repo == "" {
+		return nil, NewEmptyValueError("repository owner or name")
+	}
+
+	// Verify GitHub CLI authentication
+	if err := SetupGitHubClient(); err != nil {
+		return nil, err
+	}
+
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return nil, WrapInternalError("creating GitHub API client", err)
+	}
+
+	return crawlRepository(ctx, client, owner, repo, opts)
+}
+
+// crawlRepository implements CrawlRepository with an explicit client
+func crawlRepository(ctx context.Context, client *api.RESTClient, owner, repo string, opts CrawlOptions) (*DependencyGraph, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultCrawlLimit
+	}
+
+	repository := fmt.Sprintf("%s/%s", owner, repo)
+	graph := NewDependencyGraph(repository)
+
+	var issues []crawlIssueItem
+	for page := 1; len(issues) < limit; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, NewTimeoutError("crawling repository issues")
+		}
+
+		endpoint := fmt.Sprintf("repos/%s/issues?state=all&per_page=%d&page=%d", repository, CrawlPageSize, page)
+
+		var batch []crawlIssueItem
+		if err := client.Get(endpoint, &batch); err != nil {
+			return nil, wrapGraphAPIError(repository, "listing repository issues", err)
+		}
+
+		for _, item := range batch {
+			if item.PullRequest != nil {
+				continue // the issues API also returns pull requests
+			}
+			issues = append(issues, item)
+			if len(issues) == limit {
+				break
+			}
+		}
+
+		if len(batch) < CrawlPageSize {
+			break
+		}
+	}
+
+	for _, item := range issues {
+		graph.AddIssue(repository, item.Issue)
+	}
+
+	for _, item := range issues {
+		if item.DependencySummary != nil && item.DependencySummary.BlockedBy == 0 {
+			continue
+		}
+
+		blockers, err := fetchDependencyRelationships(ctx, client, owner, repo, item.Number, "blocked_by")
+		if err != nil {
+			return nil, err
+		}
+
+		blocked := GraphNodeID(repository, item.Number)
+		for _, blocker := range blockers {
+			if _, exists := graph.Node(GraphNodeID(blocker.Repository, blocker.Issue.Number)); !exists {
+				graph.AddIssue(blocker.Repository, blocker.Issue)
+			}
+			graph.AddEdge(GraphNodeID(blocker.Repository, blocker.Issue.Number), blocked)
+		}
+	}
+
+	graph.pruneUnconnected()
+	graph.CrawledAt = time.Now()
+	return graph, nil
+}
+
+// pruneUnconnected drops nodes that take part in no relationship
+func (g *DependencyGraph) pruneUnconnected() {
+	connected := make(map[string]bool)
+	for _, edge := range g.Edges {
+		connected[edge.From] = true
+		connected[edge.To] = true
+	}
+
+	nodes := g.Nodes[:0]
+	g.index = make(map[string]int)
+	for _, node := range g.Nodes {
+		if connected[node.ID] {
+			g.index[node.ID] = len(nodes)
+			nodes = append(nodes, node)
+		}
+	}
+	g.Nodes = nodes
+}
+
+// wrapGraphAPIError converts an API error into the matching AppError
+func wrapGraphAPIError(repository, operation string, err error) error {
+	lower := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(lower, "not found"):
+		return NewRepositoryNotFoundError(repository)
+	case strings.Contains(lower, "forbidden"):
+		return WrapPermissionError(repository, err)
+	case strings.Contains(lower, "unauthorized"):
+		return WrapAuthError(err)
+	case strings.Contains(lower, "rate limit"):
+		return WrapAPIError(429, err)
+	default:
+		return WrapInternalError(operation, err)
+	}
+}
@@ -0,0 +1,131 @@
+package pkg
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestGraph builds a graph from "from>to" edges between issues in owner/repo
+func createTestGraph(edges ...[2]int) *DependencyGraph {
+	graph := NewDependencyGraph("owner/repo")
+	for _, edge := range edges {
+		from := graph.AddIssue("owner/repo", Issue{Number: edge[0], State: "open"})
+		to := graph.AddIssue("owner/repo", Issue{Number: edge[1], State: "open"})
+		graph.AddEdge(from, to)
+	}
+	return graph
+}
+
+func TestDependencyGraph(t *testing.T) {
+	graph := NewDependencyGraph("owner/repo")
+	a := graph.AddIssue("owner/repo", Issue{Number: 1, Title: "First"})
+	b := graph.AddIssue("other/repo", Issue{Number: 2, Title: "Second"})
+
+	graph.AddEdge(a, b)
+	graph.AddEdge(a, b)
+	assert.Len(t, graph.Edges, 1, "duplicate edges are ignored")
+
+	// Re-adding a node updates it in place
+	graph.AddIssue("owner/repo", Issue{Number: 1, Title: "Renamed"})
+	assert.Len(t, graph.Nodes, 2)
+	node, ok := graph.Node("owner/repo#1")
+	require.True(t, ok)
+	assert.Equal(t, "Renamed", node.Title)
+
+	assert.Equal(t, []string{"owner/repo#1"}, graph.Blockers("other/repo#2"))
+	assert.Empty(t, graph.Blockers("owner/repo#1"))
+}
+
+func TestFindCycles(t *testing.T) {
+	tests := []struct {
+		name     string
+		graph    *DependencyGraph
+		expected [][]string
+	}{
+		{
+			name:     "acyclic",
+			graph:    createTestGraph([2]int{1, 2}, [2]int{2, 3}, [2]int{1, 3}),
+			expected: nil,
+		},
+		{
+			name:     "two issue cycle",
+			graph:    createTestGraph([2]int{1, 2}, [2]int{2, 1}),
+			expected: [][]string{{"owner/repo#1", "owner/repo#2"}},
+		},
+		{
+			name:     "cycle reported once from its smallest issue",
+			graph:    createTestGraph([2]int{3, 1}, [2]int{1, 2}, [2]int{2, 3}),
+			expected: [][]string{{"owner/repo#1", "owner/repo#2", "owner/repo#3"}},
+		},
+		{
+			name:  "overlapping cycles",
+			graph: createTestGraph([2]int{1, 2}, [2]int{2, 1}, [2]int{2, 3}, [2]int{3, 2}),
+			expected: [][]string{
+				{"owner/repo#1", "owner/repo#2"},
+				{"owner/repo#2", "owner/repo#3"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.graph.FindCycles())
+		})
+	}
+}
+
+func TestCrawlRepository(t *testing.T) {
+	var requested []string
+	client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req.URL.Path)
+		switch req.URL.Path {
+		case "/repos/owner/repo/issues":
+			return jsonResponse(req, 200, `[
+				{"number": 1, "title": "Blocked", "state": "open", "issue_dependencies_summary": {"blocked_by": 2}},
+				{"number": 2, "title": "Blocker", "state": "open", "issue_dependencies_summary": {"blocked_by": 0}},
+				{"number": 3, "title": "Unrelated", "state": "open", "issue_dependencies_summary": {"blocked_by": 0}},
+				{"number": 4, "title": "A pull request", "state": "open", "pull_request": {"url": "x"}}
+			]`), nil
+		case "/repos/owner/repo/issues/1/dependencies/blocked_by":
+			return jsonResponse(req, 200, `[
+				{"number": 2, "title": "Blocker", "state": "open", "html_url": "https://github.com/owner/repo/issues/2"},
+				{"number": 9, "title": "Library fix", "state": "closed", "html_url": "https://github.com/other/lib/issues/9"}
+			]`), nil
+		}
+		return jsonResponse(req, 404, `{"message": "Not Found"}`), nil
+	})
+
+	graph, err := crawlRepository(context.Background(), client, "owner", "repo", CrawlOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/repos/owner/repo/issues",
+		"/repos/owner/repo/issues/1/dependencies/blocked_by",
+	}, requested, "issues without blockers are skipped")
+
+	ids := make([]string, len(graph.Nodes))
+	for i, node := range graph.Nodes {
+		ids[i] = node.ID
+	}
+	assert.Equal(t, []string{"owner/repo#1", "owner/repo#2", "other/lib#9"}, ids, "unconnected issues are pruned")
+
+	assert.Equal(t, []GraphEdge{
+		{From: "owner/repo#2", To: "owner/repo#1"},
+		{From: "other/lib#9", To: "owner/repo#1"},
+	}, graph.Edges)
+	assert.False(t, graph.CrawledAt.IsZero())
+}
+
+func TestCrawlRepositoryErrors(t *testing.T) {
+	client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, 404, `{"message": "Not Found"}`), nil
+	})
+
+	_, err := crawlRepository(context.Background(), client, "owner", "missing", CrawlOptions{})
+	require.Error(t, err)
+	assert.True(t, IsErrorType(err, ErrorTypeRepository))
+}
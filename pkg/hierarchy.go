@@ -0,0 +1,283 @@
+// Package pkg provides sub-issue hierarchy for dependency output.
+//
+// GitHub sub-issues form a tree that is independent of blocked-by
+// relationships. Showing both lets an epic's structure and its ordering be read
+// in one place, and rolling up dependencies treats an epic as blocked when any
+// of its children is blocked by an open issue outside the epic.
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// Relation types used for hierarchy entries in DependencyRelation.Type
+const (
+	RelationParent           = "parent"
+	RelationSubIssue         = "sub_issue"
+	RelationRolledUpBlocking = "rolled_up_blocked_by"
+)
+
+// maxSubIssuePages bounds how many pages of sub-issues are read per issue
+const maxSubIssuePages = 10
+
+// maxRollUpDepth bounds how many levels of the sub-issue tree are walked when
+// rolling up blockers; GitHub allows eight
+const maxRollUpDepth = 8
+
+// Hierarchy is an issue's position in the sub-issue tree
+type Hierarchy struct {
+	Parent    *DependencyRelation  `json:"parent,omitempty"` // nil for top-level issues
+	SubIssues []DependencyRelation `json:"sub_issues,omitempty"`
+}
+
+// SubIssueProgress returns how many sub-issues are closed and how many there are
+func (h *Hierarchy) SubIssueProgress() (closed, total int) {
+	if h == nil {
+		return 0, 0
+	}
+	for _, sub := range h.SubIssues {
+		if strings.EqualFold(sub.Issue.State, "closed") {
+			closed++
+		}
+	}
+	return closed, len(h.SubIssues)
+}
+
+// SubIssuesSummary is the sub-issue count GitHub includes on every issue
+type SubIssuesSummary struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+}
+
+// HierarchyOptions configures EnrichWithHierarchy
+type HierarchyOptions struct {
+	// RollUp adds the open blockers of the source issue's sub-issues that are
+	// outside the source issue's tree to DependencyData.RolledUpBlockedBy
+	RollUp bool
+}
+
+// EnrichWithHierarchy sets the parent and sub-issues of the source issue and of
+// every related issue in data, and optionally rolls up the blockers of the
+// source issue's sub-issues
+func EnrichWithHierarchy(ctx context.Context, data *DependencyData, opts HierarchyOptions) error {
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return WrapInternalError("creating GitHub API client", err)
+	}
+
+	return enrichWithHierarchy(ctx, client, data, opts)
+}
+
+// enrichWithHierarchy implements EnrichWithHierarchy with an explicit client
+func enrichWithHierarchy(ctx context.Context, client *api.RESTClient, data *DependencyData, opts HierarchyOptions) error {
+	sourceRepo := data.SourceIssue.Repository.String()
+
+	hierarchy, err := fetchHierarchy(ctx, client, sourceRepo, data.SourceIssue)
+	if err != nil {
+		return err
+	}
+	data.SourceHierarchy = hierarchy
+
+	for _, deps := range [][]DependencyRelation{data.BlockedBy, data.Blocking} {
+		for i := range deps {
+			repo := deps[i].Repository
+			if repo == "" {
+				repo = sourceRepo
+			}
+			if deps[i].Hierarchy, err = fetchHierarchy(ctx, client, repo, deps[i].Issue); err != nil {
+				return err
+			}
+		}
+	}
+
+	if opts.RollUp {
+		data.RolledUpBlockedBy, err = rollUpBlockers(ctx, client, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchHierarchy reads the parent and sub-issues of issue in repo. The
+// sub-issue request is skipped when GitHub's summary says there are none.
+func fetchHierarchy(ctx context.Context, client *api.RESTClient, repo string, issue Issue) (*Hierarchy, error) {
+	hierarchy := &Hierarchy{}
+
+	var parent Issue
+	endpoint := fmt.Sprintf("repos/%s/issues/%d/parent", repo, issue.Number)
+	if err := client.DoWithContext(ctx, "GET", endpoint, nil, &parent); err != nil {
+		// Top-level issues have no parent
+		if !strings.Contains(strings.ToLower(err.Error()), "not found") {
+			return nil, wrapHierarchyAPIError(repo, "fetching parent issue", err)
+		}
+	} else if parent.Number != 0 {
+		relation := hierarchyRelation(parent, RelationParent, repo)
+		hierarchy.Parent = &relation
+	}
+
+	subIssues, err := fetchSubIssues(ctx, client, repo, issue)
+	if err != nil {
+		return nil, err
+	}
+	hierarchy.SubIssues = subIssues
+	return hierarchy, nil
+}
+
+// fetchSubIssues reads the sub-issues of issue in repo, skipping the request
+// when GitHub's summary says there are none
+func fetchSubIssues(ctx context.Context, client *api.RESTClient, repo string, issue Issue) ([]DependencyRelation, error) {
+	if issue.SubIssuesSummary != nil && issue.SubIssuesSummary.Total == 0 {
+		return nil, nil
+	}
+
+	var subIssues []DependencyRelation
+	for page := 1; page <= maxSubIssuePages; page++ {
+		endpoint := fmt.Sprintf("repos/%s/issues/%d/sub_issues?per_page=100&page=%d", repo, issue.Number, page)
+
+		var batch []Issue
+		if err := client.DoWithContext(ctx, "GET", endpoint, nil, &batch); err != nil {
+			if strings.Contains(strings.ToLower(err.Error()), "not found") {
+				break
+			}
+			return nil, wrapHierarchyAPIError(repo, "fetching sub-issues", err)
+		}
+
+		for _, sub := range batch {
+			if sub.Number != 0 {
+				subIssues = append(subIssues, hierarchyRelation(sub, RelationSubIssue, repo))
+			}
+		}
+
+		if len(batch) < 100 {
+			break
+		}
+	}
+
+	return subIssues, nil
+}
+
+// rollUpBlockers returns the open issues outside the source issue's tree that
+// block one of its open sub-issues, at any depth down to maxRollUpDepth.
+// Blockers already blocking the source issue directly are left out, and a
+// blocker of several sub-issues appears once.
+func rollUpBlockers(ctx context.Context, client *api.RESTClient, data *DependencyData) ([]DependencyRelation, error) {
+	// Non-nil even when empty, so output can tell "none" from "not computed"
+	rolledUp := []DependencyRelation{}
+	if data.SourceHierarchy == nil || len(data.SourceHierarchy.SubIssues) == 0 {
+		return rolledUp, nil
+	}
+
+	sourceRepo := data.SourceIssue.Repository.String()
+
+	// Issues in the epic, plus those already shown as direct blockers
+	skip := map[string]bool{hierarchyKey(sourceRepo, data.SourceIssue.Number): true}
+	tree, err := collectSubIssueTree(ctx, client, data.SourceHierarchy.SubIssues, skip)
+	if err != nil {
+		return nil, err
+	}
+	for _, dep := range data.BlockedBy {
+		skip[hierarchyKey(dep.Repository, dep.Issue.Number)] = true
+	}
+
+	index := make(map[string]int)
+
+	for _, sub := range tree {
+		if !strings.EqualFold(sub.Issue.State, "open") {
+			continue
+		}
+
+		owner, repo, _ := strings.Cut(sub.Repository, "/")
+		blockers, err := fetchDependencyRelationships(ctx, client, owner, repo, sub.Issue.Number, "blocked_by")
+		if err != nil {
+			return nil, err
+		}
+
+		via := markdownIssueRef(sub, sourceRepo)
+		for _, blocker := range blockers {
+			key := hierarchyKey(blocker.Repository, blocker.Issue.Number)
+			if skip[key] || !strings.EqualFold(blocker.Issue.State, "open") {
+				continue
+			}
+
+			if i, seen := index[key]; seen {
+				rolledUp[i].Via = append(rolledUp[i].Via, via)
+				continue
+			}
+
+			blocker.Type = RelationRolledUpBlocking
+			blocker.Via = []string{via}
+			index[key] = len(rolledUp)
+			rolledUp = append(rolledUp, blocker)
+		}
+	}
+
+	return rolledUp, nil
+}
+
+// collectSubIssueTree walks the sub-issue tree breadth first, starting with the
+// direct sub-issues, down to maxRollUpDepth levels. Every issue found is added
+// to seen, and issues already in seen are not visited again.
+func collectSubIssueTree(ctx context.Context, client *api.RESTClient, subIssues []DependencyRelation, seen map[string]bool) ([]DependencyRelation, error) {
+	var tree []DependencyRelation
+	level := subIssues
+	for depth := 1; len(level) > 0; depth++ {
+		var next []DependencyRelation
+		for _, sub := range level {
+			key := hierarchyKey(sub.Repository, sub.Issue.Number)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			tree = append(tree, sub)
+
+			if depth == maxRollUpDepth {
+				continue
+			}
+			children, err := fetchSubIssues(ctx, client, sub.Repository, sub.Issue)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, children...)
+		}
+		level = next
+	}
+	return tree, nil
+}
+
+// hierarchyRelation wraps a parent or sub-issue as a relation, taking its
+// repository from the API response and falling back to repo
+func hierarchyRelation(issue Issue, relType, repo string) DependencyRelation {
+	relation := DependencyRelation{Issue: issue, Type: relType, Repository: repo}
+	if issue.Repository.FullName != "" {
+		relation.Repository = issue.Repository.FullName
+	} else if fromURL := extractRepoFromURL(issue.HTMLURL); fromURL != "" {
+		relation.Repository = fromURL
+	}
+	return relation
+}
+
+// hierarchyKey identifies an issue across repositories
+func hierarchyKey(repo string, number int) string {
+	return fmt.Sprintf("%s#%d", strings.ToLower(repo), number)
+}
+
+// wrapHierarchyAPIError converts a sub-issue or linked pull request API error into an AppError
+func wrapHierarchyAPIError(repo, operation string, err error) error {
+	lower := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(lower, "forbidden"):
+		return WrapPermissionError(repo, err)
+	case strings.Contains(lower, "unauthorized"):
+		return WrapAuthError(err)
+	case strings.Contains(lower, "rate limit"):
+		return WrapAPIError(429, err)
+	default:
+		return WrapInternalError(operation, err)
+	}
+}
@@ -0,0 +1,230 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hierarchyResponses serves a small sub-issue tree: #123 has parent #10 and
+// sub-issues #200 (open), #201 (closed), and testowner/api#202 (open), which
+// are blocked by issues inside and outside the tree
+var hierarchyResponses = map[string]string{
+	"/repos/testowner/testrepo/issues/123/parent": `{"number": 10, "title": "Epic", "state": "open",
+		"html_url": "https://github.com/testowner/testrepo/issues/10"}`,
+	"/repos/testowner/testrepo/issues/123/sub_issues": `[
+		{"number": 200, "title": "Child A", "state": "open", "html_url": "https://github.com/testowner/testrepo/issues/200"},
+		{"number": 201, "title": "Child B", "state": "closed", "html_url": "https://github.com/testowner/testrepo/issues/201"},
+		{"number": 202, "title": "Child C", "state": "open", "html_url": "https://github.com/testowner/api/issues/202"}
+	]`,
+	"/repos/testowner/testrepo/issues/200/dependencies/blocked_by": `[
+		{"number": 300, "title": "Outside blocker", "state": "open", "html_url": "https://github.com/testowner/other/issues/300"},
+		{"number": 45, "title": "Direct blocker", "state": "open", "html_url": "https://github.com/testowner/testrepo/issues/45"},
+		{"number": 201, "title": "Sibling", "state": "open", "html_url": "https://github.com/testowner/testrepo/issues/201"},
+		{"number": 301, "title": "Done", "state": "closed", "html_url": "https://github.com/testowner/other/issues/301"}
+	]`,
+	"/repos/testowner/api/issues/202/dependencies/blocked_by": `[
+		{"number": 300, "title": "Outside blocker", "state": "open", "html_url": "https://github.com/testowner/other/issues/300"}
+	]`,
+}
+
+// createHierarchyDependencyData fetches the hierarchy for the standard test
+// data from hierarchyResponses and returns it with the requested paths
+func createHierarchyDependencyData(t *testing.T, rollUp bool) (*DependencyData, []string) {
+	t.Helper()
+
+	var requested []string
+	client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req.URL.Path)
+		if body, ok := hierarchyResponses[req.URL.Path]; ok {
+			return jsonResponse(req, 200, body), nil
+		}
+		if strings.HasSuffix(req.URL.Path, "/sub_issues") {
+			return jsonResponse(req, 200, `[]`), nil
+		}
+		return jsonResponse(req, 404, `{"message": "Not Found"}`), nil
+	})
+
+	data := createTestDependencyData()
+	data.BlockedBy[0].Issue.SubIssuesSummary = &SubIssuesSummary{Total: 0}
+	require.NoError(t, enrichWithHierarchy(context.Background(), client, data, HierarchyOptions{RollUp: rollUp}))
+	return data, requested
+}
+
+func TestEnrichWithHierarchy(t *testing.T) {
+	data, requested := createHierarchyDependencyData(t, false)
+
+	require.NotNil(t, data.SourceHierarchy.Parent)
+	assert.Equal(t, 10, data.SourceHierarchy.Parent.Issue.Number)
+	assert.Equal(t, RelationParent, data.SourceHierarchy.Parent.Type)
+
+	require.Len(t, data.SourceHierarchy.SubIssues, 3)
+	assert.Equal(t, "testowner/api", data.SourceHierarchy.SubIssues[2].Repository)
+	closed, total := data.SourceHierarchy.SubIssueProgress()
+	assert.Equal(t, 1, closed)
+	assert.Equal(t, 3, total)
+
+	// Related issues are annotated too; top-level issues have no parent
+	require.NotNil(t, data.Blocking[0].Hierarchy)
+	assert.Nil(t, data.Blocking[0].Hierarchy.Parent)
+	assert.Contains(t, requested, "/repos/testowner/frontend/issues/89/sub_issues")
+
+	// The summary says #45 has no sub-issues, so they are not requested
+	assert.NotContains(t, requested, "/repos/testowner/testrepo/issues/45/sub_issues")
+
+	// Without roll-up, sub-issue blockers are neither fetched nor reported
+	assert.Nil(t, data.RolledUpBlockedBy)
+	assert.NotContains(t, requested, "/repos/testowner/testrepo/issues/200/dependencies/blocked_by")
+}
+
+func TestRollUpBlockers(t *testing.T) {
+	data, requested := createHierarchyDependencyData(t, true)
+
+	// #300 blocks two sub-issues; #45 already blocks the epic directly, #201 is
+	// inside the tree, and #301 is closed
+	require.Len(t, data.RolledUpBlockedBy, 1)
+	blocker := data.RolledUpBlockedBy[0]
+	assert.Equal(t, 300, blocker.Issue.Number)
+	assert.Equal(t, "testowner/other", blocker.Repository)
+	assert.Equal(t, RelationRolledUpBlocking, blocker.Type)
+	assert.Equal(t, []string{"#200", "testowner/api#202"}, blocker.Via)
+
+	// Closed sub-issues are not checked
+	assert.NotContains(t, requested, "/repos/testowner/testrepo/issues/201/dependencies/blocked_by")
+}
+
+func TestRollUpBlockersNested(t *testing.T) {
+	// #123 has sub-issue #200, which has sub-issue #210, which has sub-issue
+	// #220. #200 is blocked by #220 inside the tree; #210 and #220 are blocked
+	// by #310 outside it.
+	responses := map[string]string{
+		"/repos/testowner/testrepo/issues/123/sub_issues": `[
+			{"number": 200, "title": "Feature", "state": "open", "html_url": "https://github.com/testowner/testrepo/issues/200"}
+		]`,
+		"/repos/testowner/testrepo/issues/200/sub_issues": `[
+			{"number": 210, "title": "Task", "state": "open", "html_url": "https://github.com/testowner/testrepo/issues/210"}
+		]`,
+		"/repos/testowner/testrepo/issues/210/sub_issues": `[
+			{"number": 220, "title": "Subtask", "state": "open", "html_url": "https://github.com/testowner/testrepo/issues/220",
+				"sub_issues_summary": {"total": 0, "completed": 0}}
+		]`,
+		"/repos/testowner/testrepo/issues/200/dependencies/blocked_by": `[
+			{"number": 220, "title": "Subtask", "state": "open", "html_url": "https://github.com/testowner/testrepo/issues/220"}
+		]`,
+		"/repos/testowner/testrepo/issues/210/dependencies/blocked_by": `[
+			{"number": 310, "title": "Outside blocker", "state": "open", "html_url": "https://github.com/testowner/other/issues/310"}
+		]`,
+		"/repos/testowner/testrepo/issues/220/dependencies/blocked_by": `[
+			{"number": 310, "title": "Outside blocker", "state": "open", "html_url": "https://github.com/testowner/other/issues/310"}
+		]`,
+	}
+
+	var requested []string
+	client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req.URL.Path)
+		if body, ok := responses[req.URL.Path]; ok {
+			return jsonResponse(req, 200, body), nil
+		}
+		if strings.HasSuffix(req.URL.Path, "/sub_issues") || strings.HasSuffix(req.URL.Path, "/blocked_by") {
+			return jsonResponse(req, 200, `[]`), nil
+		}
+		return jsonResponse(req, 404, `{"message": "Not Found"}`), nil
+	})
+
+	data := createTestDependencyData()
+	data.BlockedBy = nil
+	data.Blocking = nil
+	require.NoError(t, enrichWithHierarchy(context.Background(), client, data, HierarchyOptions{RollUp: true}))
+
+	require.Len(t, data.RolledUpBlockedBy, 1)
+	assert.Equal(t, 310, data.RolledUpBlockedBy[0].Issue.Number)
+	assert.Equal(t, []string{"#210", "#220"}, data.RolledUpBlockedBy[0].Via)
+
+	// The summary says #220 has no sub-issues, so they are not requested
+	assert.NotContains(t, requested, "/repos/testowner/testrepo/issues/220/sub_issues")
+}
+
+func TestEnrichWithHierarchyErrors(t *testing.T) {
+	client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, 403, `{"message": "Forbidden"}`), nil
+	})
+
+	err := enrichWithHierarchy(context.Background(), client, createTestDependencyData(), HierarchyOptions{})
+	require.Error(t, err)
+
+	var appErr *AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, ErrorTypePermission, appErr.Type)
+}
+
+func TestHierarchyOutput(t *testing.T) {
+	data, _ := createHierarchyDependencyData(t, true)
+
+	format := func(format OutputFormat) string {
+		var buf bytes.Buffer
+		formatter := NewOutputFormatter(&OutputOptions{
+			Format:      format,
+			Writer:      &buf,
+			Width:       200,
+			StateFilter: "all",
+		})
+		require.NoError(t, formatter.FormatOutput(data))
+		return buf.String()
+	}
+
+	t.Run("tty", func(t *testing.T) {
+		output := format(FormatTTY)
+		assert.Contains(t, output, "Parent: #10 - Epic")
+		assert.Contains(t, output, "Sub-issues: 1 of 3 closed")
+		assert.Contains(t, output, "SUB-ISSUES (3 issues)")
+		assert.Contains(t, output, "BLOCKED THROUGH SUB-ISSUES (1 issues)")
+		assert.Contains(t, output, "#200, testowner/api#202")
+		assert.Contains(t, output, "PARENT")
+	})
+
+	t.Run("plain", func(t *testing.T) {
+		lines := strings.Split(strings.TrimSpace(format(FormatPlain)), "\n")
+		assert.True(t, strings.HasPrefix(lines[0], "blocked_by\t45\t"), "relationship rows come first")
+		assert.Contains(t, lines, "parent\t10\tEpic\topen\t\ttestowner/testrepo\t\t")
+		assert.Contains(t, lines, "sub_issue\t201\tChild B\tclosed\t\ttestowner/testrepo\t\t")
+		assert.Equal(t, "rolled_up_blocked_by\t300\tOutside blocker\topen\t\ttestowner/other\t\t\t#200,testowner/api#202", lines[len(lines)-1])
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var output map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(format(FormatJSON)), &output))
+
+		source := output["source_issue"].(map[string]interface{})
+		assert.Equal(t, float64(10), source["parent"].(map[string]interface{})["number"])
+		assert.Len(t, source["sub_issues"], 3)
+
+		blocks := output["blocks"].([]interface{})[0].(map[string]interface{})
+		assert.Nil(t, blocks["parent"])
+		assert.Equal(t, []interface{}{}, blocks["sub_issues"])
+
+		rolledUp := output["rolled_up_blocked_by"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, []interface{}{"#200", "testowner/api#202"}, rolledUp["via"])
+		assert.Equal(t, float64(1), output["summary"].(map[string]interface{})["rolled_up_blocked_by_count"])
+	})
+
+	t.Run("json without hierarchy", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewOutputFormatter(&OutputOptions{Format: FormatJSON, Writer: &buf, StateFilter: "all"})
+		require.NoError(t, formatter.FormatOutput(createTestDependencyData()))
+		assert.NotContains(t, buf.String(), "sub_issues")
+		assert.NotContains(t, buf.String(), "rolled_up_blocked_by")
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		output := format(FormatMarkdown)
+		assert.Contains(t, output, "\nParent: #10 Epic\n")
+		assert.Contains(t, output, "### Sub-issues (3)\n\n- [ ] #200 Child A\n- [x] #201 Child B\n- [ ] testowner/api#202 Child C\n")
+		assert.Contains(t, output, "- [ ] testowner/other#300 Outside blocker (via #200, testowner/api#202)\n")
+	})
+}
@@ -0,0 +1,191 @@
+// Package pkg provides conditional requests backed by the disk cache.
+//
+// Each successful GET response is stored with its ETag and Last-Modified
+// validators. Later requests for the same URL send If-None-Match and
+// If-Modified-Since, and GitHub answers 304 Not Modified when nothing
+// changed. A 304 does not count against the REST rate limit, so stored
+// responses can be served long after the dependency cache TTL has passed.
+package pkg
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// ResponseCacheDir is the directory, under the cache directory, holding
+// responses kept for revalidation
+const ResponseCacheDir = "responses"
+
+// ResponseRetention is how long a stored response is kept after it was last
+// fetched or revalidated
+const ResponseRetention = 7 * 24 * time.Hour
+
+// conditionalTransport makes GET requests conditional on the validators of the
+// last response for the same URL and answers 304 Not Modified with that
+// response, so callers see a normal 200. Responses are kept in memory and,
+// when disk is set, in the cache store so later runs can revalidate them too.
+type conditionalTransport struct {
+	base      http.RoundTripper
+	disk      *cacheStore // Where responses are stored; nil keeps them in memory only
+	mu        sync.Mutex
+	responses map[string]conditionalResponse
+}
+
+// conditionalResponse is a response remembered for revalidation
+type conditionalResponse struct {
+	URL          string      `json:"url"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+}
+
+// newConditionalTransport wraps base with revalidation of the responses stored
+// in disk, or in memory only when disk is nil
+func newConditionalTransport(base http.RoundTripper, disk *cacheStore) *conditionalTransport {
+	return &conditionalTransport{base: base, disk: disk, responses: make(map[string]conditionalResponse)}
+}
+
+// newConditionalRESTClient returns a REST client that revalidates responses
+// stored in the disk cache. Responses are kept in memory only while the
+// cache is turned off.
+func newConditionalRESTClient() (*api.RESTClient, error) {
+	var disk *cacheStore
+	if cacheTTL > 0 {
+		disk = openCacheStore()
+	}
+
+	client, err := api.NewRESTClient(api.ClientOptions{Transport: newConditionalTransport(http.DefaultTransport, disk)})
+	if err != nil {
+		return nil, WrapInternalError("creating GitHub API client", err)
+	}
+	return client, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *conditionalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	key := conditionalKey(req)
+	cached, ok := t.lookup(key)
+	if ok {
+		req = req.Clone(req.Context())
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		_ = resp.Body.Close()
+		t.touch(key)
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        cached.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(cached.Body)),
+			ContentLength: int64(len(cached.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.store(key, conditionalResponse{
+		URL:          req.URL.String(),
+		ETag:         etag,
+		LastModified: lastModified,
+		Header:       resp.Header.Clone(),
+		Body:         body,
+	})
+	return resp, nil
+}
+
+// lookup returns the stored response for key, from memory or else from disk
+func (t *conditionalTransport) lookup(key string) (conditionalResponse, bool) {
+	t.mu.Lock()
+	cached, ok := t.responses[key]
+	t.mu.Unlock()
+	if ok || t.disk == nil {
+		return cached, ok
+	}
+
+	content, found := t.disk.read(responseEntryName(key))
+	if !found {
+		return conditionalResponse{}, false
+	}
+	if err := json.Unmarshal(content, &cached); err != nil {
+		return conditionalResponse{}, false
+	}
+
+	t.mu.Lock()
+	t.responses[key] = cached
+	t.mu.Unlock()
+	return cached, true
+}
+
+// store remembers a response for key. Disk errors are ignored because the
+// response itself was fetched successfully.
+func (t *conditionalTransport) store(key string, response conditionalResponse) {
+	t.mu.Lock()
+	t.responses[key] = response
+	t.mu.Unlock()
+	if t.disk == nil {
+		return
+	}
+
+	content, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+	_ = t.disk.write(responseEntryName(key), content)
+}
+
+// touch marks a stored response as just revalidated, so cleanup keeps it
+func (t *conditionalTransport) touch(key string) {
+	if t.disk != nil {
+		t.disk.touch(responseEntryName(key))
+	}
+}
+
+// responseEntryName returns the cache store entry holding the response for key
+func responseEntryName(key string) string {
+	return ResponseCacheDir + "/" + key + ".json"
+}
+
+// conditionalKey identifies a request by URL and credentials, so a response
+// fetched with one token is never revalidated with another
+func conditionalKey(req *http.Request) string {
+	hash := sha256.Sum256([]byte(req.Header.Get("Authorization") + "\n" + req.URL.String()))
+	return fmt.Sprintf("%x", hash)
+}
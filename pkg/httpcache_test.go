@@ -0,0 +1,96 @@
+package pkg
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newValidatorServer returns a server answering 304 to requests carrying the
+// validators it hands out, and the number of requests it received
+func newValidatorServer(t *testing.T) (*httptest.Server, *int) {
+	t.Helper()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case r.URL.Path == "/etag" && r.Header.Get("If-None-Match") == `"v1"`,
+			r.URL.Path == "/modified" && r.Header.Get("If-Modified-Since") == "Mon, 02 Jan 2006 15:04:05 GMT":
+			w.WriteHeader(http.StatusNotModified)
+			return
+		case r.URL.Path == "/etag":
+			w.Header().Set("ETag", `"v1"`)
+		case r.URL.Path == "/modified":
+			w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		}
+		_, _ = w.Write([]byte(`{"number": 1}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// getBody fetches url and returns the status and body
+func getBody(t *testing.T, client *http.Client, url, token string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "token "+token)
+	}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestConditionalTransport(t *testing.T) {
+	for _, path := range []string{"/etag", "/modified", "/none"} {
+		t.Run(path, func(t *testing.T) {
+			server, requests := newValidatorServer(t)
+			client := &http.Client{Transport: newConditionalTransport(http.DefaultTransport, nil)}
+
+			for i := 0; i < 2; i++ {
+				status, body := getBody(t, client, server.URL+path, "")
+				assert.Equal(t, http.StatusOK, status, "request %d", i)
+				assert.Equal(t, `{"number": 1}`, body, "request %d", i)
+			}
+			assert.Equal(t, 2, *requests)
+		})
+	}
+}
+
+func TestConditionalTransportDisk(t *testing.T) {
+	server, _ := newValidatorServer(t)
+	store := newCacheStore(t.TempDir(), DefaultCacheMaxSize)
+
+	first := &http.Client{Transport: newConditionalTransport(http.DefaultTransport, store)}
+	_, _ = getBody(t, first, server.URL+"/etag", "alice")
+
+	files, err := os.ReadDir(filepath.Join(store.dir, ResponseCacheDir))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	// A later run revalidates the stored response
+	var revalidated bool
+	second := &http.Client{Transport: newConditionalTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		revalidated = req.Header.Get("If-None-Match") == `"v1"`
+		return http.DefaultTransport.RoundTrip(req)
+	}), store)}
+	status, body := getBody(t, second, server.URL+"/etag", "alice")
+	assert.True(t, revalidated)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"number": 1}`, body)
+
+	// Responses fetched with another token are not reused
+	revalidated = false
+	_, _ = getBody(t, second, server.URL+"/etag", "bob")
+	assert.False(t, revalidated)
+}
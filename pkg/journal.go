@@ -0,0 +1,426 @@
+// Package pkg provides the local operation journal.
+//
+// Every dependency mutation made by this extension is appended to a JSON Lines
+// file under the config directory. The journal powers the `history` command and
+// lets `undo` replay the inverse of earlier operations.
+package pkg
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// JournalFileName is the name of the append-only journal file in ConfigDir
+const JournalFileName = "journal.jsonl"
+
+// Journal actions
+const (
+	JournalActionAdd    = "add"
+	JournalActionRemove = "remove"
+)
+
+// JournalEntry records a single dependency mutation
+type JournalEntry struct {
+	ID         string             `json:"id"`
+	Timestamp  time.Time          `json:"timestamp"`
+	User       string             `json:"user,omitempty"`
+	Action     string             `json:"action"`                // "add" or "remove"
+	Source     string             `json:"source"`                // owner/repo#number
+	Target     string             `json:"target"`                // owner/repo#number
+	RelType    string             `json:"relationship_type"`     // "blocked-by" or "blocks"
+	PriorState *JournalPriorState `json:"prior_state,omitempty"` // State read before the mutation, if it could be read
+	UndoOf     string             `json:"undo_of,omitempty"`     // ID of the entry this mutation reverted
+}
+
+// JournalPriorState is the state of a relationship and its target issue read
+// just before the relationship was changed
+type JournalPriorState struct {
+	RelationshipExisted bool   `json:"relationship_existed"`
+	TargetState         string `json:"target_state,omitempty"` // "open" or "closed"
+	TargetTitle         string `json:"target_title,omitempty"`
+}
+
+// String describes the prior state for the history table
+func (s *JournalPriorState) String() string {
+	if s == nil {
+		return "-"
+	}
+
+	if s.TargetState == "" {
+		return s.relationshipState()
+	}
+	return fmt.Sprintf("%s (target %s: %s)", s.relationshipState(), s.TargetState, s.TargetTitle)
+}
+
+// relationshipState returns "present" or "absent"
+func (s *JournalPriorState) relationshipState() string {
+	if s.RelationshipExisted {
+		return "present"
+	}
+	return "absent"
+}
+
+// Operation converts the entry back into the relationship it changed
+func (e JournalEntry) Operation() (BulkOperation, error) {
+	source, err := parseJournalRef(e.Source)
+	if err != nil {
+		return BulkOperation{}, err
+	}
+	target, err := parseJournalRef(e.Target)
+	if err != nil {
+		return BulkOperation{}, err
+	}
+	return BulkOperation{Source: source, Target: target, RelType: e.RelType}, nil
+}
+
+// InverseAction returns the action that reverts this entry
+func (e JournalEntry) InverseAction() string {
+	if e.Action == JournalActionAdd {
+		return JournalActionRemove
+	}
+	return JournalActionAdd
+}
+
+// journalMutex serializes appends from parallel bulk operations
+var journalMutex sync.Mutex
+
+// JournalPath returns the location of the operation journal
+func JournalPath() string {
+	return filepath.Join(ConfigDir(), JournalFileName)
+}
+
+// AppendJournalEntry appends entry to the journal, creating the file if needed
+func AppendJournalEntry(entry JournalEntry) error {
+	journalMutex.Lock()
+	defer journalMutex.Unlock()
+
+	path := JournalPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) // #nosec G304 -- path is derived from ConfigDir
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// ReadJournal returns every journal entry in the order it was recorded.
+// A missing journal is treated as empty.
+func ReadJournal() ([]JournalEntry, error) {
+	file, err := os.Open(JournalPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, WrapInternalError("reading operation journal", err)
+	}
+	defer file.Close()
+
+	var entries []JournalEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var entry JournalEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			continue // skip lines truncated by an interrupted write
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, WrapInternalError("reading operation journal", err)
+	}
+
+	return entries, nil
+}
+
+// FilterJournalSince returns the entries recorded at or after since
+func FilterJournalSince(entries []JournalEntry, since time.Time) []JournalEntry {
+	var filtered []JournalEntry
+	for _, entry := range entries {
+		if !entry.Timestamp.Before(since) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// ParseJournalSince parses a --since value relative to now. It accepts Go
+// durations ("36h"), day counts ("7d"), dates ("2006-01-02"), and RFC 3339 timestamps.
+func ParseJournalSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+
+	if duration, err := time.ParseDuration(value); err == nil && duration >= 0 {
+		return now.Add(-duration), nil
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return date, nil
+	}
+
+	if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		return timestamp, nil
+	}
+
+	return time.Time{}, NewAppError(
+		ErrorTypeValidation,
+		fmt.Sprintf("Invalid --since value: %s", value),
+		nil,
+	).WithSuggestion("Use a duration (e.g., 24h, 7d), a date (2006-01-02), or an RFC 3339 timestamp")
+}
+
+// SelectUndoEntries picks the entries to undo, newest first. Either opID names
+// a single entry, or last selects the most recent operations that have not
+// already been undone. Undo entries themselves are skipped by last so repeated
+// undos keep walking back through history.
+func SelectUndoEntries(entries []JournalEntry, opID string, last int) ([]JournalEntry, error) {
+	undone := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.UndoOf != "" {
+			undone[entry.UndoOf] = true
+		}
+	}
+
+	if opID != "" {
+		for _, entry := range entries {
+			if entry.ID != opID {
+				continue
+			}
+			if undone[entry.ID] {
+				return nil, NewAppError(
+					ErrorTypeValidation,
+					fmt.Sprintf("Operation %s has already been undone", opID),
+					nil,
+				).WithSuggestion("Use 'gh issue-dependency history' to find the undo operation")
+			}
+			return []JournalEntry{entry}, nil
+		}
+
+		return nil, NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Operation not found in journal: %s", opID),
+			nil,
+		).WithSuggestion("Use 'gh issue-dependency history' to list recorded operations")
+	}
+
+	if last <= 0 {
+		return nil, NewAppError(
+			ErrorTypeValidation,
+			"--last must be a positive number",
+			nil,
+		).WithSuggestion("Use --last 1 to undo the most recent operation")
+	}
+
+	var selected []JournalEntry
+	for i := len(entries) - 1; i >= 0 && len(selected) < last; i-- {
+		entry := entries[i]
+		if entry.UndoOf != "" || undone[entry.ID] {
+			continue
+		}
+		selected = append(selected, entry)
+	}
+
+	if len(selected) == 0 {
+		return nil, NewAppError(
+			ErrorTypeValidation,
+			"No operations to undo",
+			nil,
+		).WithSuggestion("Use 'gh issue-dependency history' to review recorded operations")
+	}
+
+	return selected, nil
+}
+
+// FormatJournalEntries writes entries as an aligned table
+func FormatJournalEntries(w io.Writer, entries []JournalEntry) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTIME\tUSER\tACTION\tRELATIONSHIP\tBEFORE")
+
+	for _, entry := range entries {
+		action := entry.Action
+		if entry.UndoOf != "" {
+			action = fmt.Sprintf("%s (undo %s)", entry.Action, entry.UndoOf)
+		}
+		user := entry.User
+		if user == "" {
+			user = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s %s %s (%s)\t%s\n",
+			entry.ID,
+			entry.Timestamp.Local().Format("2006-01-02 15:04:05"),
+			user,
+			action,
+			entry.Source, relationshipSymbol(entry.RelType), entry.Target, entry.RelType,
+			entry.PriorState)
+	}
+
+	return tw.Flush()
+}
+
+// recordMutation appends a journal entry for a relationship change that was just
+// applied. prior is the state read before the change, from priorStateFor.
+// Journal failures are reported as warnings because the change itself already
+// succeeded.
+func recordMutation(client *api.RESTClient, action string, op BulkOperation, prior *JournalPriorState, undoOf string) {
+	entry := JournalEntry{
+		ID:         newJournalID(),
+		Timestamp:  time.Now().UTC(),
+		User:       currentUserLogin(client),
+		Action:     action,
+		Source:     op.Source.String(),
+		Target:     op.Target.String(),
+		RelType:    op.RelType,
+		PriorState: prior,
+		UndoOf:     undoOf,
+	}
+
+	if err := AppendJournalEntry(entry); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record operation in journal: %v\n", err)
+	}
+}
+
+// validatedPriorStates holds the state validation read for each relationship
+// until the change is applied, so journaling does not fetch it again
+var (
+	validatedPriorStates = make(map[string]JournalPriorState)
+	priorStatesMutex     sync.Mutex
+)
+
+// priorStateKey identifies the relationship changed by op
+func priorStateKey(op BulkOperation) string {
+	return fmt.Sprintf("%s %s %s", op.Source.String(), op.RelType, op.Target.String())
+}
+
+// rememberPriorState records the state validation read for the relationship
+// described by op
+func rememberPriorState(op BulkOperation, state JournalPriorState) {
+	priorStatesMutex.Lock()
+	defer priorStatesMutex.Unlock()
+	validatedPriorStates[priorStateKey(op)] = state
+}
+
+// priorStateFor returns the state of the relationship described by op before
+// it is changed. It must be called before the change is applied. The state
+// read by validation is used when there is one; otherwise, as for rollbacks
+// and unvalidated bulk removals, it is fetched. It returns nil when the state
+// cannot be read.
+func priorStateFor(client *api.RESTClient, op BulkOperation) *JournalPriorState {
+	priorStatesMutex.Lock()
+	state, found := validatedPriorStates[priorStateKey(op)]
+	delete(validatedPriorStates, priorStateKey(op))
+	priorStatesMutex.Unlock()
+	if found {
+		return &state
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	dependencies, err := FetchIssueDependencies(ctx, op.Source.Owner, op.Source.Repo, op.Source.Number)
+	if err != nil {
+		return nil
+	}
+	validator := &RemovalValidator{client: client}
+	if relation := validator.findRelationInData(dependencies, op.Target, op.RelType); relation != nil {
+		return &JournalPriorState{
+			RelationshipExisted: true,
+			TargetState:         relation.Issue.State,
+			TargetTitle:         relation.Issue.Title,
+		}
+	}
+
+	state = JournalPriorState{}
+	if client != nil {
+		if target, err := fetchIssueDetails(ctx, client, op.Target.Owner, op.Target.Repo, op.Target.Number); err == nil {
+			state.TargetState, state.TargetTitle = target.State, target.Title
+		}
+	}
+	return &state
+}
+
+// newJournalID returns a short random identifier for a journal entry
+func newJournalID() string {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(buf)
+}
+
+// cachedUserLogin holds the authenticated user's login once it has been looked up
+var (
+	cachedUserLogin string
+	userLoginMutex  sync.Mutex
+)
+
+// currentUserLogin returns the login of the authenticated user, or "" when it
+// cannot be determined
+func currentUserLogin(client *api.RESTClient) string {
+	userLoginMutex.Lock()
+	defer userLoginMutex.Unlock()
+
+	if cachedUserLogin != "" || client == nil {
+		return cachedUserLogin
+	}
+
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := client.Get("user", &user); err != nil {
+		return ""
+	}
+
+	cachedUserLogin = user.Login
+	return cachedUserLogin
+}
+
+// parseJournalRef parses an owner/repo#number reference recorded in the journal
+func parseJournalRef(ref string) (IssueRef, error) {
+	repo, number, err := ParseIssueReference(ref)
+	if err != nil {
+		return IssueRef{}, err
+	}
+	if repo == "" {
+		return IssueRef{}, NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Journal reference is missing a repository: %s", ref),
+			nil,
+		)
+	}
+
+	parts := strings.SplitN(repo, "/", 2)
+	return CreateIssueRef(parts[0], parts[1], number), nil
+}
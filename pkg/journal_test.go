@@ -0,0 +1,247 @@
+package pkg
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createJournalEntry builds a journal entry for org/app#1 and the given target
+func createJournalEntry(id, action string, target int, undoOf string) JournalEntry {
+	return JournalEntry{
+		ID:        id,
+		Timestamp: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Action:    action,
+		Source:    "org/app#1",
+		Target:    CreateIssueRef("org", "app", target).String(),
+		RelType:   "blocked-by",
+		UndoOf:    undoOf,
+	}
+}
+
+func TestConfigDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	assert.Equal(t, filepath.Join(dir, ConfigDirName), ConfigDir())
+	assert.Equal(t, filepath.Join(dir, ConfigDirName, JournalFileName), JournalPath())
+}
+
+func TestJournalRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	entries, err := ReadJournal()
+	require.NoError(t, err)
+	assert.Empty(t, entries, "missing journal is empty")
+
+	require.NoError(t, AppendJournalEntry(createJournalEntry("aaaa", JournalActionAdd, 10, "")))
+	require.NoError(t, AppendJournalEntry(createJournalEntry("bbbb", JournalActionRemove, 11, "")))
+
+	// A truncated line from an interrupted write must not hide other entries
+	file, err := os.OpenFile(JournalPath(), os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = file.WriteString("{\"id\":\"cc\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.NoError(t, AppendJournalEntry(createJournalEntry("dddd", JournalActionAdd, 12, "")))
+
+	entries, err = ReadJournal()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "aaaa", entries[0].ID)
+	assert.Equal(t, "bbbb", entries[1].ID)
+	assert.Equal(t, "dddd", entries[2].ID)
+}
+
+func TestParseJournalSince(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Time
+		wantErr  bool
+	}{
+		{"hours", "36h", now.Add(-36 * time.Hour), false},
+		{"days", "7d", now.AddDate(0, 0, -7), false},
+		{"date", "2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"timestamp", "2024-03-01T08:30:00Z", time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC), false},
+		{"negative duration", "-1h", time.Time{}, true},
+		{"garbage", "yesterday", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			since, err := ParseJournalSince(tt.value, now)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, IsErrorType(err, ErrorTypeValidation))
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(since), "got %v", since)
+		})
+	}
+}
+
+func TestFilterJournalSince(t *testing.T) {
+	old := createJournalEntry("old", JournalActionAdd, 10, "")
+	recent := createJournalEntry("new", JournalActionAdd, 11, "")
+	recent.Timestamp = old.Timestamp.Add(48 * time.Hour)
+
+	filtered := FilterJournalSince([]JournalEntry{old, recent}, old.Timestamp.Add(time.Hour))
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "new", filtered[0].ID)
+}
+
+func TestSelectUndoEntries(t *testing.T) {
+	entries := []JournalEntry{
+		createJournalEntry("e1", JournalActionAdd, 10, ""),
+		createJournalEntry("e2", JournalActionRemove, 11, ""),
+		createJournalEntry("e3", JournalActionAdd, 12, ""),
+		createJournalEntry("u3", JournalActionRemove, 12, "e3"),
+	}
+
+	tests := []struct {
+		name          string
+		opID          string
+		last          int
+		expectedIDs   []string
+		errorContains string
+	}{
+		{"by id", "e2", 0, []string{"e2"}, ""},
+		{"undo of an undo", "u3", 0, []string{"u3"}, ""},
+		{"already undone", "e3", 0, nil, "already been undone"},
+		{"unknown id", "zz", 0, nil, "not found"},
+		{"last skips undone and undo entries", "", 1, []string{"e2"}, ""},
+		{"last is newest first", "", 2, []string{"e2", "e1"}, ""},
+		{"last beyond journal", "", 10, []string{"e2", "e1"}, ""},
+		{"last zero", "", 0, nil, "positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := SelectUndoEntries(entries, tt.opID, tt.last)
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+			require.NoError(t, err)
+
+			var ids []string
+			for _, entry := range selected {
+				ids = append(ids, entry.ID)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+
+	_, err := SelectUndoEntries(nil, "", 1)
+	assert.ErrorContains(t, err, "No operations to undo")
+}
+
+func TestJournalEntryOperation(t *testing.T) {
+	entry := createJournalEntry("e1", JournalActionAdd, 10, "")
+	entry.Target = "other/lib#10"
+
+	op, err := entry.Operation()
+	require.NoError(t, err)
+	assert.Equal(t, "org/app#1 ← other/lib#10", op.String())
+	assert.Equal(t, JournalActionRemove, entry.InverseAction())
+
+	entry.Source = "42"
+	_, err = entry.Operation()
+	assert.Error(t, err, "references without a repository cannot be replayed")
+}
+
+func TestFormatJournalEntries(t *testing.T) {
+	entries := []JournalEntry{
+		createJournalEntry("e1", JournalActionRemove, 10, ""),
+		createJournalEntry("u1", JournalActionAdd, 10, "e1"),
+	}
+	entries[0].User = "octocat"
+	entries[0].PriorState = &JournalPriorState{RelationshipExisted: true, TargetState: "open", TargetTitle: "Fix login"}
+
+	var buf bytes.Buffer
+	require.NoError(t, FormatJournalEntries(&buf, entries))
+
+	output := buf.String()
+	assert.Contains(t, output, "ID")
+	assert.Contains(t, output, "BEFORE")
+	assert.Contains(t, output, "octocat")
+	assert.Contains(t, output, "add (undo e1)")
+	assert.Contains(t, output, "org/app#1 ← org/app#10 (blocked-by)")
+	assert.Contains(t, output, "present (target open: Fix login)")
+
+	// Entries without a recorded prior state show a placeholder
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasSuffix(lines[2], " -"), lines[2])
+}
+
+func TestRecordMutation(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cachedUserLogin = ""
+	defer func() { cachedUserLogin = "" }()
+
+	userRequests := 0
+	client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+		userRequests++
+		assert.Equal(t, "/user", req.URL.Path)
+		return jsonResponse(req, 200, `{"login":"octocat"}`), nil
+	})
+
+	op := BulkOperation{
+		Source:  CreateIssueRef("org", "app", 1),
+		Target:  CreateIssueRef("org", "app", 10),
+		RelType: "blocks",
+	}
+	prior := &JournalPriorState{RelationshipExisted: true, TargetState: "closed", TargetTitle: "Old blocker"}
+	recordMutation(client, JournalActionRemove, op, prior, "")
+	recordMutation(client, JournalActionAdd, op, nil, "abcd")
+
+	entries, err := ReadJournal()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, 1, userRequests, "login is looked up once")
+
+	assert.Len(t, entries[0].ID, 8)
+	assert.Equal(t, "octocat", entries[0].User)
+	assert.Equal(t, "org/app#1", entries[0].Source)
+	assert.Equal(t, "org/app#10", entries[0].Target)
+	assert.Equal(t, "blocks", entries[0].RelType)
+	assert.Equal(t, JournalActionRemove, entries[0].Action)
+	assert.Equal(t, prior, entries[0].PriorState)
+
+	assert.Equal(t, JournalActionAdd, entries[1].Action)
+	assert.Nil(t, entries[1].PriorState)
+	assert.Equal(t, "abcd", entries[1].UndoOf)
+	assert.NotEqual(t, entries[0].ID, entries[1].ID)
+}
+
+func TestPriorStateFor(t *testing.T) {
+	op := BulkOperation{
+		Source:  CreateIssueRef("org", "app", 1),
+		Target:  CreateIssueRef("org", "app", 10),
+		RelType: "blocked-by",
+	}
+	validated := JournalPriorState{TargetState: "open", TargetTitle: "Blocker"}
+
+	// The state read by validation is used once, without any API calls
+	rememberPriorState(op, validated)
+	assert.Equal(t, &validated, priorStateFor(nil, op))
+
+	priorStatesMutex.Lock()
+	_, found := validatedPriorStates[priorStateKey(op)]
+	priorStatesMutex.Unlock()
+	assert.False(t, found, "the validated state is consumed")
+}
@@ -0,0 +1,447 @@
+// Package pkg provides syncing of blocked state into issue labels.
+//
+// Dashboards and saved searches often key on a label such as "blocked".
+// Label sync adds that label to every open issue with an open blocker and
+// removes it from open issues whose blockers are all closed. Only the
+// difference is applied, so running it repeatedly is safe.
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// Label sync actions
+const (
+	LabelSyncActionAdd    = "add"    // Add the label to a blocked issue
+	LabelSyncActionRemove = "remove" // Remove the label from an unblocked issue
+)
+
+// DefaultBlockedLabel is the label name used when none is given
+const DefaultBlockedLabel = "blocked"
+
+// DefaultBlockedLabelColor is the color of a label created by label sync
+const DefaultBlockedLabelColor = "b60205"
+
+// blockedLabelDescription is the description of a label created by label sync
+const blockedLabelDescription = "Blocked by an open issue"
+
+// labelSyncPageSize is how many issues are read per GraphQL query
+const labelSyncPageSize = 100
+
+// maxLabelSyncPages bounds how many pages of issues are read
+const maxLabelSyncPages = 50
+
+// maxLabelSyncBlockers bounds how many blockers are read per issue
+const maxLabelSyncBlockers = 50
+
+// labelColorPattern matches a six-digit hex color without the leading #
+var labelColorPattern = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+
+// LabelSyncOptions configures SyncLabels
+type LabelSyncOptions struct {
+	Owner  string
+	Repo   string
+	Label  string // Label marking blocked issues
+	Color  string // Hex color used if the label has to be created, with or without #
+	DryRun bool   // Report the changes without making them
+}
+
+// LabelSyncChange is a label added to, or removed from, one issue
+type LabelSyncChange struct {
+	Issue     string   `json:"issue"` // owner/repo#number
+	Number    int      `json:"number"`
+	Title     string   `json:"title"`
+	Action    string   `json:"action"` // One of the LabelSyncAction constants
+	BlockedBy []string `json:"blocked_by,omitempty"`
+	Status    string   `json:"status"` // StatusApplied, StatusFailed, or StatusPlanned
+	Error     string   `json:"error,omitempty"`
+}
+
+// LabelSyncReport describes the outcome of a label sync
+type LabelSyncReport struct {
+	Repository    string            `json:"repository"` // owner/repo
+	Label         string            `json:"label"`
+	LabelCreated  bool              `json:"label_created"` // The label was missing and was (or, in a dry run, would be) created
+	DryRun        bool              `json:"dry_run"`
+	IssuesChecked int               `json:"issues_checked"` // Open issues in the repository
+	Blocked       int               `json:"blocked"`        // Open issues with at least one open blocker
+	Changes       []LabelSyncChange `json:"changes"`
+}
+
+// Failed returns how many changes could not be made
+func (r *LabelSyncReport) Failed() int {
+	failed := 0
+	for _, change := range r.Changes {
+		if change.Status == StatusFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// labelSyncIssue is an open issue with whether it has the label and its open blockers
+type labelSyncIssue struct {
+	Number    int
+	Title     string
+	HasLabel  bool
+	BlockedBy []string
+}
+
+// NormalizeLabelColor validates a hex label color and strips a leading #
+func NormalizeLabelColor(color string) (string, error) {
+	color = strings.TrimPrefix(strings.TrimSpace(color), "#")
+	if !labelColorPattern.MatchString(color) {
+		return "", NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Invalid label color: %s", color),
+			nil,
+		).WithSuggestion("Use a six-digit hex color, e.g. --color b60205")
+	}
+	return strings.ToLower(color), nil
+}
+
+// SyncLabels adds the label in opts to every open issue in the repository
+// with an open blocker, and removes it from open issues without one. The label
+// is created first if the repository does not have it.
+func SyncLabels(ctx context.Context, opts LabelSyncOptions) (*LabelSyncReport, error) {
+	if err := SetupGitHubClient(); err != nil {
+		return nil, err
+	}
+
+	restClient, err := api.DefaultRESTClient()
+	if err != nil {
+		return nil, WrapInternalError("creating GitHub API client", err)
+	}
+	gqlClient, err := api.DefaultGraphQLClient()
+	if err != nil {
+		return nil, WrapInternalError("creating GitHub GraphQL client", err)
+	}
+
+	return syncLabels(ctx, restClient, gqlClient, opts)
+}
+
+// syncLabels implements SyncLabels with explicit clients
+func syncLabels(ctx context.Context, restClient *api.RESTClient, gqlClient *api.GraphQLClient, opts LabelSyncOptions) (*LabelSyncReport, error) {
+	if strings.TrimSpace(opts.Label) == "" {
+		return nil, NewEmptyValueError("--label")
+	}
+	color, err := NormalizeLabelColor(opts.Color)
+	if err != nil {
+		return nil, err
+	}
+	repository := opts.Owner + "/" + opts.Repo
+
+	exists, err := labelExists(ctx, restClient, opts.Owner, opts.Repo, opts.Label)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := fetchLabelSyncIssues(ctx, gqlClient, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &LabelSyncReport{
+		Repository:    repository,
+		Label:         opts.Label,
+		DryRun:        opts.DryRun,
+		IssuesChecked: len(issues),
+		Changes:       []LabelSyncChange{},
+	}
+
+	var changes []LabelSyncChange
+	for _, issue := range issues {
+		if len(issue.BlockedBy) > 0 {
+			report.Blocked++
+		}
+		if change, ok := planLabelSyncChange(repository, issue); ok {
+			changes = append(changes, change)
+		}
+	}
+
+	// The label is only created when it is about to be added
+	report.LabelCreated = !exists && hasLabelSyncAction(changes, LabelSyncActionAdd)
+
+	if opts.DryRun {
+		for _, change := range changes {
+			change.Status = StatusPlanned
+			report.Changes = append(report.Changes, change)
+		}
+		return report, nil
+	}
+
+	if report.LabelCreated {
+		if err := createLabel(ctx, restClient, opts.Owner, opts.Repo, opts.Label, color); err != nil {
+			report.LabelCreated = false
+			return report, err
+		}
+	}
+
+	for _, change := range changes {
+		if err := applyLabelSyncChange(ctx, restClient, opts, change); err != nil {
+			change.Status = StatusFailed
+			change.Error = err.Error()
+		} else {
+			change.Status = StatusApplied
+		}
+		report.Changes = append(report.Changes, change)
+	}
+
+	return report, nil
+}
+
+// planLabelSyncChange decides what to do with issue: blocked issues without
+// the label get it, unblocked issues with the label lose it
+func planLabelSyncChange(repository string, issue labelSyncIssue) (LabelSyncChange, bool) {
+	change := LabelSyncChange{
+		Issue:     fmt.Sprintf("%s#%d", repository, issue.Number),
+		Number:    issue.Number,
+		Title:     issue.Title,
+		BlockedBy: issue.BlockedBy,
+	}
+
+	blocked := len(issue.BlockedBy) > 0
+	switch {
+	case blocked && !issue.HasLabel:
+		change.Action = LabelSyncActionAdd
+	case !blocked && issue.HasLabel:
+		change.Action = LabelSyncActionRemove
+	default:
+		return change, false
+	}
+	return change, true
+}
+
+// hasLabelSyncAction reports whether any change has the given action
+func hasLabelSyncAction(changes []LabelSyncChange, action string) bool {
+	for _, change := range changes {
+		if change.Action == action {
+			return true
+		}
+	}
+	return false
+}
+
+// labelExists reports whether the repository has a label with the given name
+func labelExists(ctx context.Context, client *api.RESTClient, owner, repo, label string) (bool, error) {
+	endpoint := fmt.Sprintf("repos/%s/%s/labels/%s", owner, repo, url.PathEscape(label))
+
+	var response struct {
+		Name string `json:"name"`
+	}
+	if err := client.DoWithContext(ctx, "GET", endpoint, nil, &response); err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			return false, nil
+		}
+		return false, wrapLabelAPIError(owner+"/"+repo, "reading label", err)
+	}
+	return true, nil
+}
+
+// createLabel creates the label with the given color
+func createLabel(ctx context.Context, client *api.RESTClient, owner, repo, label, color string) error {
+	body, err := json.Marshal(map[string]string{
+		"name":        label,
+		"color":       color,
+		"description": blockedLabelDescription,
+	})
+	if err != nil {
+		return WrapInternalError("encoding label request", err)
+	}
+
+	endpoint := fmt.Sprintf("repos/%s/%s/labels", owner, repo)
+	if err := client.DoWithContext(ctx, "POST", endpoint, bytes.NewReader(body), nil); err != nil {
+		// Another run created it in the meantime
+		if strings.Contains(strings.ToLower(err.Error()), "already_exists") {
+			return nil
+		}
+		return wrapLabelAPIError(owner+"/"+repo, "creating label", err)
+	}
+	return nil
+}
+
+// applyLabelSyncChange adds or removes the label on one issue. Removing a label
+// the issue no longer has is not an error.
+func applyLabelSyncChange(ctx context.Context, client *api.RESTClient, opts LabelSyncOptions, change LabelSyncChange) error {
+	repository := opts.Owner + "/" + opts.Repo
+
+	if change.Action == LabelSyncActionAdd {
+		body, err := json.Marshal(map[string][]string{"labels": {opts.Label}})
+		if err != nil {
+			return WrapInternalError("encoding label request", err)
+		}
+		endpoint := fmt.Sprintf("repos/%s/issues/%d/labels", repository, change.Number)
+		if err := client.DoWithContext(ctx, "POST", endpoint, bytes.NewReader(body), nil); err != nil {
+			return wrapLabelAPIError(repository, "adding label", err)
+		}
+		return nil
+	}
+
+	endpoint := fmt.Sprintf("repos/%s/issues/%d/labels/%s", repository, change.Number, url.PathEscape(opts.Label))
+	if err := client.DoWithContext(ctx, "DELETE", endpoint, nil, nil); err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			return nil
+		}
+		return wrapLabelAPIError(repository, "removing label", err)
+	}
+	return nil
+}
+
+// fetchLabelSyncIssues reads every open issue in the repository with whether it
+// has the label and its open blockers
+func fetchLabelSyncIssues(ctx context.Context, client *api.GraphQLClient, opts LabelSyncOptions) ([]labelSyncIssue, error) {
+	query := fmt.Sprintf(`query LabelSyncIssues($owner: String!, $repo: String!, $cursor: String) {
+  repository(owner: $owner, name: $repo) {
+    issues(first: %d, after: $cursor, states: OPEN) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        number
+        title
+        labels(first: 100) { nodes { name } }
+        blockedBy(first: %d) { nodes { number state repository { nameWithOwner } } }
+      }
+    }
+  }
+}`, labelSyncPageSize, maxLabelSyncBlockers)
+
+	repository := opts.Owner + "/" + opts.Repo
+	var issues []labelSyncIssue
+	var cursor *string
+
+	for page := 0; page < maxLabelSyncPages; page++ {
+		var response struct {
+			Repository *struct {
+				Issues struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						Number int    `json:"number"`
+						Title  string `json:"title"`
+						Labels struct {
+							Nodes []struct {
+								Name string `json:"name"`
+							} `json:"nodes"`
+						} `json:"labels"`
+						BlockedBy struct {
+							Nodes []struct {
+								Number     int    `json:"number"`
+								State      string `json:"state"`
+								Repository struct {
+									NameWithOwner string `json:"nameWithOwner"`
+								} `json:"repository"`
+							} `json:"nodes"`
+						} `json:"blockedBy"`
+					} `json:"nodes"`
+				} `json:"issues"`
+			} `json:"repository"`
+		}
+
+		variables := map[string]interface{}{"owner": opts.Owner, "repo": opts.Repo, "cursor": cursor}
+		if err := client.DoWithContext(ctx, query, variables, &response); err != nil && !isPartialGraphQLError(err) {
+			return nil, wrapLabelAPIError(repository, "reading issues", err)
+		}
+		if response.Repository == nil {
+			if page == 0 {
+				return nil, NewRepositoryNotFoundError(repository)
+			}
+			break
+		}
+
+		for _, node := range response.Repository.Issues.Nodes {
+			issue := labelSyncIssue{Number: node.Number, Title: node.Title}
+			for _, label := range node.Labels.Nodes {
+				if strings.EqualFold(label.Name, opts.Label) {
+					issue.HasLabel = true
+				}
+			}
+			for _, blocker := range node.BlockedBy.Nodes {
+				if strings.EqualFold(blocker.State, "open") {
+					issue.BlockedBy = append(issue.BlockedBy, fmt.Sprintf("%s#%d", blocker.Repository.NameWithOwner, blocker.Number))
+				}
+			}
+			issues = append(issues, issue)
+		}
+
+		if !response.Repository.Issues.PageInfo.HasNextPage {
+			break
+		}
+		endCursor := response.Repository.Issues.PageInfo.EndCursor
+		cursor = &endCursor
+	}
+
+	return issues, nil
+}
+
+// wrapLabelAPIError converts a label or issue API error into an AppError
+func wrapLabelAPIError(repository, operation string, err error) error {
+	lower := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(lower, "forbidden") || strings.Contains(lower, "must have push access"):
+		return WrapPermissionError(repository, err).
+			WithSuggestion("Managing labels needs triage or write access to the repository")
+	case strings.Contains(lower, "unauthorized"):
+		return WrapAuthError(err)
+	case strings.Contains(lower, "rate limit"):
+		return WrapAPIError(429, err)
+	default:
+		return WrapInternalError(operation, err)
+	}
+}
+
+// FormatLabelSyncReport writes report as a summary line and one line per change
+func FormatLabelSyncReport(w io.Writer, report *LabelSyncReport) error {
+	fmt.Fprintf(w, "%s: %d open issues checked, %d blocked\n",
+		report.Repository, report.IssuesChecked, report.Blocked)
+
+	if report.LabelCreated {
+		verb := "Created"
+		if report.DryRun {
+			verb = "Would create"
+		}
+		fmt.Fprintf(w, "%s label %q\n", verb, report.Label)
+	}
+
+	if len(report.Changes) == 0 {
+		fmt.Fprintf(w, "Label %q is up to date\n", report.Label)
+		return nil
+	}
+
+	verb := "Updated"
+	if report.DryRun {
+		verb = "Would update"
+	}
+	fmt.Fprintf(w, "%s label %q on %d issues:\n", verb, report.Label, len(report.Changes))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, change := range report.Changes {
+		symbol, detail := "+", "blocked by "+strings.Join(change.BlockedBy, ", ")
+		if change.Action == LabelSyncActionRemove {
+			symbol, detail = "-", "unblocked"
+		}
+		if change.Status == StatusFailed {
+			symbol, detail = "❌", "failed: "+change.Error
+		}
+
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", symbol, change.Issue, change.Title, detail)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if report.DryRun {
+		fmt.Fprintln(w, "\nNo changes made. Remove --dry-run to apply them.")
+	}
+	return nil
+}
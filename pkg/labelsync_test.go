@@ -0,0 +1,197 @@
+package pkg
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// labelSyncIssuesResponse has four open issues: #1 is blocked and unlabeled,
+// #2 is blocked and already labeled, #3 is labeled but its only blocker is
+// closed, and #4 has no blockers and no label
+const labelSyncIssuesResponse = `{"data": {"repository": {"issues": {
+	"pageInfo": {"hasNextPage": false, "endCursor": "c1"},
+	"nodes": [
+		{"number": 1, "title": "One", "labels": {"nodes": [{"name": "bug"}]},
+			"blockedBy": {"nodes": [{"number": 9, "state": "OPEN", "repository": {"nameWithOwner": "org/api"}}]}},
+		{"number": 2, "title": "Two", "labels": {"nodes": [{"name": "Blocked"}]},
+			"blockedBy": {"nodes": [{"number": 1, "state": "OPEN", "repository": {"nameWithOwner": "org/app"}}]}},
+		{"number": 3, "title": "Three", "labels": {"nodes": [{"name": "blocked"}]},
+			"blockedBy": {"nodes": [{"number": 8, "state": "CLOSED", "repository": {"nameWithOwner": "org/app"}}]}},
+		{"number": 4, "title": "Four", "labels": {"nodes": []}, "blockedBy": {"nodes": []}}
+	]
+}}}}`
+
+// labelSyncServer fakes the label and issue APIs and records label writes
+type labelSyncServer struct {
+	labelExists bool
+	failAdd     bool
+	requests    []string
+}
+
+func (s *labelSyncServer) clients(t *testing.T) (*api.RESTClient, *api.GraphQLClient) {
+	rest := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+		path := strings.TrimPrefix(req.URL.Path, "/")
+		switch {
+		case req.Method == "GET" && path == "repos/org/app/labels/blocked":
+			if !s.labelExists {
+				return jsonResponse(req, 404, `{"message": "Not Found"}`), nil
+			}
+			return jsonResponse(req, 200, `{"name": "blocked"}`), nil
+		case req.Method == "POST" && path == "repos/org/app/issues/1/labels" && s.failAdd:
+			return jsonResponse(req, 403, `{"message": "Forbidden"}`), nil
+		}
+
+		body := ""
+		if req.Body != nil {
+			content, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			body = " " + string(content)
+		}
+		s.requests = append(s.requests, req.Method+" "+path+body)
+		return jsonResponse(req, 200, `{}`), nil
+	})
+
+	gql := newFakeGraphQLClient(t, func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, 200, labelSyncIssuesResponse), nil
+	})
+	return rest, gql
+}
+
+func testLabelSyncOptions(dryRun bool) LabelSyncOptions {
+	return LabelSyncOptions{Owner: "org", Repo: "app", Label: "blocked", Color: "#B60205", DryRun: dryRun}
+}
+
+func TestSyncLabels(t *testing.T) {
+	t.Run("adds and removes the label", func(t *testing.T) {
+		server := &labelSyncServer{labelExists: true}
+		rest, gql := server.clients(t)
+
+		report, err := syncLabels(context.Background(), rest, gql, testLabelSyncOptions(false))
+		require.NoError(t, err)
+
+		assert.Equal(t, 4, report.IssuesChecked)
+		assert.Equal(t, 2, report.Blocked)
+		assert.False(t, report.LabelCreated)
+		require.Len(t, report.Changes, 2)
+		assert.Equal(t, LabelSyncChange{Issue: "org/app#1", Number: 1, Title: "One", Action: LabelSyncActionAdd,
+			BlockedBy: []string{"org/api#9"}, Status: StatusApplied}, report.Changes[0])
+		assert.Equal(t, LabelSyncActionRemove, report.Changes[1].Action)
+		assert.Equal(t, "org/app#3", report.Changes[1].Issue)
+
+		assert.Equal(t, []string{
+			`POST repos/org/app/issues/1/labels {"labels":["blocked"]}`,
+			"DELETE repos/org/app/issues/3/labels/blocked",
+		}, server.requests)
+	})
+
+	t.Run("creates a missing label", func(t *testing.T) {
+		server := &labelSyncServer{}
+		rest, gql := server.clients(t)
+
+		report, err := syncLabels(context.Background(), rest, gql, testLabelSyncOptions(false))
+		require.NoError(t, err)
+		assert.True(t, report.LabelCreated)
+		require.NotEmpty(t, server.requests)
+		assert.Equal(t,
+			`POST repos/org/app/labels {"color":"b60205","description":"Blocked by an open issue","name":"blocked"}`,
+			server.requests[0])
+	})
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		server := &labelSyncServer{}
+		rest, gql := server.clients(t)
+
+		report, err := syncLabels(context.Background(), rest, gql, testLabelSyncOptions(true))
+		require.NoError(t, err)
+		assert.True(t, report.LabelCreated)
+		require.Len(t, report.Changes, 2)
+		for _, change := range report.Changes {
+			assert.Equal(t, StatusPlanned, change.Status)
+		}
+		assert.Empty(t, server.requests)
+	})
+
+	t.Run("failed changes are reported", func(t *testing.T) {
+		server := &labelSyncServer{labelExists: true, failAdd: true}
+		rest, gql := server.clients(t)
+
+		report, err := syncLabels(context.Background(), rest, gql, testLabelSyncOptions(false))
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Failed())
+		assert.Equal(t, StatusFailed, report.Changes[0].Status)
+		assert.Equal(t, StatusApplied, report.Changes[1].Status)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		server := &labelSyncServer{}
+		rest, gql := server.clients(t)
+
+		opts := testLabelSyncOptions(false)
+		opts.Label = " "
+		_, err := syncLabels(context.Background(), rest, gql, opts)
+		assert.True(t, IsErrorType(err, ErrorTypeValidation))
+
+		opts = testLabelSyncOptions(false)
+		opts.Color = "red"
+		_, err = syncLabels(context.Background(), rest, gql, opts)
+		assert.True(t, IsErrorType(err, ErrorTypeValidation))
+	})
+}
+
+func TestNormalizeLabelColor(t *testing.T) {
+	tests := []struct {
+		color    string
+		expected string
+		valid    bool
+	}{
+		{"b60205", "b60205", true},
+		{"#B60205", "b60205", true},
+		{"fff", "", false},
+		{"zzzzzz", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.color, func(t *testing.T) {
+			color, err := NormalizeLabelColor(tt.color)
+			if !tt.valid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, color)
+		})
+	}
+}
+
+func TestFormatLabelSyncReport(t *testing.T) {
+	report := &LabelSyncReport{
+		Repository:    "org/app",
+		Label:         "blocked",
+		LabelCreated:  true,
+		DryRun:        true,
+		IssuesChecked: 4,
+		Blocked:       2,
+		Changes: []LabelSyncChange{
+			{Issue: "org/app#1", Title: "One", Action: LabelSyncActionAdd, BlockedBy: []string{"org/api#9"}, Status: StatusPlanned},
+			{Issue: "org/app#3", Title: "Three", Action: LabelSyncActionRemove, Status: StatusPlanned},
+		},
+	}
+
+	var buf strings.Builder
+	require.NoError(t, FormatLabelSyncReport(&buf, report))
+	assert.Equal(t, `org/app: 4 open issues checked, 2 blocked
+Would create label "blocked"
+Would update label "blocked" on 2 issues:
+  +  org/app#1  One    blocked by org/api#9
+  -  org/app#3  Three  unblocked
+
+No changes made. Remove --dry-run to apply them.
+`, buf.String())
+}
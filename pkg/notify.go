@@ -0,0 +1,366 @@
+// Package pkg provides comments on dependents when a blocker closes.
+//
+// Notifying walks the issues a closed blocker was blocking and posts a comment
+// on each open one, telling its assignees whether they are now unblocked or
+// which blockers remain. Every comment carries a hidden marker naming the
+// blocker, so notifying twice never posts the same comment twice.
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// StatusAlreadyNotified marks a dependent that already has a comment for the blocker
+const StatusAlreadyNotified = "already_notified"
+
+// DefaultNotifyTemplate is the comment posted on each dependent. It is a Go
+// template over the fields of NotifyCommentData, with the same helpers as --template.
+const DefaultNotifyTemplate = `{{if .mentions}}{{join " " .mentions}} {{end}}{{.blocker}} closed; ` +
+	`{{if .unblocked}}you are now unblocked.{{else}}still blocked by {{join ", " .still_blocked_by}}.{{end}}`
+
+// maxNotifyCommentPages bounds how many pages of comments are searched for the marker
+const maxNotifyCommentPages = 10
+
+// NotifyOptions configures NotifyDependents
+type NotifyOptions struct {
+	Template string // Comment template; DefaultNotifyTemplate when empty
+	DryRun   bool   // Report the comments without posting them
+}
+
+// NotifyCommentData is the input of the comment template for one dependent.
+// Issue references are relative to the dependent's repository, so blockers in
+// the same repository read as #42.
+type NotifyCommentData struct {
+	Blocker        string   `json:"blocker"`
+	BlockerTitle   string   `json:"blocker_title"`
+	BlockerURL     string   `json:"blocker_url"`
+	Issue          string   `json:"issue"`
+	Title          string   `json:"title"`
+	Mentions       []string `json:"mentions"` // @login of each assignee
+	Unblocked      bool     `json:"unblocked"`
+	StillBlockedBy []string `json:"still_blocked_by"` // Open blockers other than Blocker
+}
+
+// NotifyResult is the comment posted, or planned, on one dependent
+type NotifyResult struct {
+	Issue          string   `json:"issue"` // owner/repo#number
+	Title          string   `json:"title"`
+	Unblocked      bool     `json:"unblocked"`
+	StillBlockedBy []string `json:"still_blocked_by"`
+	Comment        string   `json:"comment"`
+	Status         string   `json:"status"` // StatusApplied, StatusPlanned, StatusAlreadyNotified, or StatusFailed
+	Error          string   `json:"error,omitempty"`
+}
+
+// NotifyReport describes the outcome of notifying the dependents of a blocker
+type NotifyReport struct {
+	Blocker    string         `json:"blocker"` // owner/repo#number
+	Title      string         `json:"title"`
+	DryRun     bool           `json:"dry_run"`
+	Dependents []NotifyResult `json:"dependents"` // Open issues the blocker was blocking
+}
+
+// Failed returns how many comments could not be posted
+func (r *NotifyReport) Failed() int {
+	failed := 0
+	for _, result := range r.Dependents {
+		if result.Status == StatusFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// notifyMarker is the hidden comment identifying notifications about blocker
+func notifyMarker(blocker string) string {
+	return fmt.Sprintf("<!-- gh-issue-dependency:notify blocker=%s -->", strings.ToLower(blocker))
+}
+
+// NotifyDependents comments on every open issue blocked by the given closed
+// issue. Dependents that already have a comment for it are skipped.
+func NotifyDependents(ctx context.Context, owner, repo string, issueNumber int, opts NotifyOptions) (*NotifyReport, error) {
+	if err := SetupGitHubClient(); err != nil {
+		return nil, err
+	}
+
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return nil, WrapInternalError("creating GitHub API client", err)
+	}
+
+	return notifyDependents(ctx, client, owner, repo, issueNumber, opts)
+}
+
+// notifyDependents implements NotifyDependents with an explicit client
+func notifyDependents(ctx context.Context, client *api.RESTClient, owner, repo string, issueNumber int, opts NotifyOptions) (*NotifyReport, error) {
+	tmpl := opts.Template
+	if tmpl == "" {
+		tmpl = DefaultNotifyTemplate
+	}
+	// Catch template mistakes before anything is posted
+	if _, err := renderNotifyComment(tmpl, NotifyCommentData{
+		Blocker: "#1", Issue: "#2", Mentions: []string{"@octocat"}, StillBlockedBy: []string{"#3"},
+	}); err != nil {
+		return nil, err
+	}
+
+	data, err := fetchDependencyData(ctx, client, owner, repo, issueNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	blockerRepo := owner + "/" + repo
+	blocker := fmt.Sprintf("%s#%d", blockerRepo, issueNumber)
+	if !strings.EqualFold(data.SourceIssue.State, "closed") {
+		return nil, NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Issue %s is still open", blocker),
+			nil,
+		).WithSuggestion("Dependents are notified once their blocker is closed; close the issue first")
+	}
+
+	report := &NotifyReport{
+		Blocker:    blocker,
+		Title:      data.SourceIssue.Title,
+		DryRun:     opts.DryRun,
+		Dependents: []NotifyResult{},
+	}
+	notifier := &dependentNotifier{
+		client:        client,
+		template:      tmpl,
+		marker:        notifyMarker(blocker),
+		blockerRepo:   blockerRepo,
+		blockerNumber: issueNumber,
+		blocker:       data.SourceIssue,
+		dryRun:        opts.DryRun,
+	}
+
+	for _, dep := range data.Blocking {
+		if !strings.EqualFold(dep.Issue.State, "open") {
+			continue
+		}
+
+		result := NotifyResult{
+			Issue:          fmt.Sprintf("%s#%d", dep.Repository, dep.Issue.Number),
+			Title:          dep.Issue.Title,
+			StillBlockedBy: []string{},
+		}
+		if err := notifier.notify(ctx, dep, &result); err != nil {
+			if IsErrorType(err, ErrorTypeValidation) {
+				return report, err
+			}
+			result.Status = StatusFailed
+			result.Error = err.Error()
+		}
+		report.Dependents = append(report.Dependents, result)
+	}
+
+	return report, nil
+}
+
+// dependentNotifier posts the comments about one closed blocker
+type dependentNotifier struct {
+	client        *api.RESTClient
+	template      string
+	marker        string
+	blockerRepo   string
+	blockerNumber int
+	blocker       Issue
+	dryRun        bool
+}
+
+// notify fills in result for one dependent and posts its comment
+func (n *dependentNotifier) notify(ctx context.Context, dep DependencyRelation, result *NotifyResult) error {
+	depOwner, depRepo, ok := strings.Cut(dep.Repository, "/")
+	if !ok {
+		return NewAppError(ErrorTypeInternal, fmt.Sprintf("Unexpected repository name: %s", dep.Repository), nil)
+	}
+
+	blockers, err := fetchDependencyRelationships(ctx, n.client, depOwner, depRepo, dep.Issue.Number, "blocked_by")
+	if err != nil {
+		return err
+	}
+	stillBlockedBy := []string{}
+	for _, other := range blockers {
+		isBlocker := strings.EqualFold(other.Repository, n.blockerRepo) && other.Issue.Number == n.blockerNumber
+		if !isBlocker && strings.EqualFold(other.Issue.State, "open") {
+			result.StillBlockedBy = append(result.StillBlockedBy, fmt.Sprintf("%s#%d", other.Repository, other.Issue.Number))
+			stillBlockedBy = append(stillBlockedBy, relativeIssueRef(dep.Repository, other.Repository, other.Issue.Number))
+		}
+	}
+	result.Unblocked = len(result.StillBlockedBy) == 0
+
+	mentions := []string{}
+	for _, assignee := range dep.Issue.Assignees {
+		mentions = append(mentions, "@"+assignee.Login)
+	}
+
+	comment, err := renderNotifyComment(n.template, NotifyCommentData{
+		Blocker:        relativeIssueRef(dep.Repository, n.blockerRepo, n.blockerNumber),
+		BlockerTitle:   n.blocker.Title,
+		BlockerURL:     n.blocker.HTMLURL,
+		Issue:          result.Issue,
+		Title:          dep.Issue.Title,
+		Mentions:       mentions,
+		Unblocked:      result.Unblocked,
+		StillBlockedBy: stillBlockedBy,
+	})
+	if err != nil {
+		return err
+	}
+	result.Comment = comment
+
+	notified, err := hasNotifyComment(ctx, n.client, dep.Repository, dep.Issue.Number, n.marker)
+	if err != nil {
+		return err
+	}
+	switch {
+	case notified:
+		result.Status = StatusAlreadyNotified
+		return nil
+	case n.dryRun:
+		result.Status = StatusPlanned
+		return nil
+	}
+
+	body, err := json.Marshal(map[string]string{"body": comment + "\n\n" + n.marker})
+	if err != nil {
+		return WrapInternalError("encoding comment", err)
+	}
+	endpoint := fmt.Sprintf("repos/%s/issues/%d/comments", dep.Repository, dep.Issue.Number)
+	if err := n.client.DoWithContext(ctx, "POST", endpoint, bytes.NewReader(body), nil); err != nil {
+		return wrapNotifyAPIError(dep.Repository, "posting comment", err)
+	}
+
+	result.Status = StatusApplied
+	return nil
+}
+
+// hasNotifyComment reports whether the issue has a comment containing marker
+func hasNotifyComment(ctx context.Context, client *api.RESTClient, repository string, number int, marker string) (bool, error) {
+	for page := 1; page <= maxNotifyCommentPages; page++ {
+		endpoint := fmt.Sprintf("repos/%s/issues/%d/comments?per_page=100&page=%d", repository, number, page)
+
+		var comments []struct {
+			Body string `json:"body"`
+		}
+		if err := client.DoWithContext(ctx, "GET", endpoint, nil, &comments); err != nil {
+			return false, wrapNotifyAPIError(repository, "reading comments", err)
+		}
+
+		for _, comment := range comments {
+			if strings.Contains(strings.ToLower(comment.Body), marker) {
+				return true, nil
+			}
+		}
+		if len(comments) < 100 {
+			break
+		}
+	}
+	return false, nil
+}
+
+// renderNotifyComment executes the comment template for one dependent
+func renderNotifyComment(tmpl string, data NotifyCommentData) (string, error) {
+	var buf bytes.Buffer
+	if err := renderTemplate(&buf, data, tmpl); err != nil {
+		return "", err
+	}
+
+	comment := strings.TrimSpace(buf.String())
+	if comment == "" {
+		return "", NewAppError(
+			ErrorTypeValidation,
+			"Comment template produced an empty comment",
+			nil,
+		).WithSuggestion("Check the conditions in the comment template")
+	}
+	return comment, nil
+}
+
+// relativeIssueRef returns #number for issues in base, and owner/repo#number otherwise
+func relativeIssueRef(base, repository string, number int) string {
+	if strings.EqualFold(base, repository) {
+		return fmt.Sprintf("#%d", number)
+	}
+	return fmt.Sprintf("%s#%d", repository, number)
+}
+
+// wrapNotifyAPIError converts a comment API error into an AppError
+func wrapNotifyAPIError(repository, operation string, err error) error {
+	lower := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(lower, "forbidden"):
+		return WrapPermissionError(repository, err)
+	case strings.Contains(lower, "unauthorized"):
+		return WrapAuthError(err)
+	case strings.Contains(lower, "rate limit"):
+		return WrapAPIError(429, err)
+	case strings.Contains(lower, "locked"):
+		return NewAppError(
+			ErrorTypePermission,
+			fmt.Sprintf("Conversation is locked in %s", repository),
+			err,
+		).WithSuggestion("Only collaborators can comment on locked issues")
+	default:
+		return WrapInternalError(operation, err)
+	}
+}
+
+// FormatNotifyReport writes report as a summary line and one line per dependent.
+// In a dry run the comments are shown too.
+func FormatNotifyReport(w io.Writer, report *NotifyReport) error {
+	fmt.Fprintf(w, "%s (%s): %d open dependents\n", report.Blocker, report.Title, len(report.Dependents))
+	if len(report.Dependents) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, result := range report.Dependents {
+		detail := "unblocked"
+		if !result.Unblocked {
+			detail = "still blocked by " + strings.Join(result.StillBlockedBy, ", ")
+		}
+
+		var status string
+		switch result.Status {
+		case StatusApplied:
+			status = "✅ commented"
+		case StatusPlanned:
+			status = "would comment"
+		case StatusAlreadyNotified:
+			status = "already notified"
+		default:
+			status = "❌ failed"
+			detail = result.Error
+		}
+
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", status, result.Issue, detail)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if !report.DryRun {
+		return nil
+	}
+	for _, result := range report.Dependents {
+		if result.Status != StatusPlanned {
+			continue
+		}
+		fmt.Fprintf(w, "\nComment for %s:\n", result.Issue)
+		for _, line := range strings.Split(result.Comment, "\n") {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+	fmt.Fprintln(w, "\nNo comments posted. Remove --dry-run to post them.")
+	return nil
+}
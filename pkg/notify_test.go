@@ -0,0 +1,182 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// notifyServer fakes the issue, dependency, and comment APIs. org/app#42 is a
+// closed blocker of org/app#10, which has no other blockers, org/web#11, which
+// is still blocked by org/web#7 and already has a comment, and the closed
+// org/app#12.
+type notifyServer struct {
+	blockerState string
+
+	mu       sync.Mutex
+	comments map[string]string // Posted comment bodies by issue path
+}
+
+func (s *notifyServer) handler(t *testing.T) roundTripFunc {
+	responses := map[string]string{
+		"repos/org/app/issues/42/dependencies/blocked_by": `[]`,
+		"repos/org/app/issues/42/dependencies/blocking": `[
+			{"number": 10, "title": "Ten", "state": "open", "assignees": [{"login": "alice"}, {"login": "bob"}],
+				"html_url": "https://github.com/org/app/issues/10"},
+			{"number": 11, "title": "Eleven", "state": "open", "assignees": [],
+				"html_url": "https://github.com/org/web/issues/11"},
+			{"number": 12, "title": "Twelve", "state": "closed", "html_url": "https://github.com/org/app/issues/12"}
+		]`,
+		"repos/org/app/issues/10/dependencies/blocked_by": `[
+			{"number": 42, "state": "closed", "html_url": "https://github.com/org/app/issues/42"}
+		]`,
+		"repos/org/web/issues/11/dependencies/blocked_by": `[
+			{"number": 42, "state": "closed", "html_url": "https://github.com/org/app/issues/42"},
+			{"number": 7, "state": "open", "html_url": "https://github.com/org/web/issues/7"},
+			{"number": 8, "state": "open", "html_url": "https://github.com/org/app/issues/8"}
+		]`,
+		"repos/org/app/issues/10/comments": `[{"body": "Looks good"}]`,
+		"repos/org/web/issues/11/comments": `[{"body": "done\n\n<!-- gh-issue-dependency:notify blocker=org/app#42 -->"}]`,
+	}
+
+	return func(req *http.Request) (*http.Response, error) {
+		path := strings.TrimPrefix(req.URL.Path, "/")
+		if req.Method == "POST" {
+			var body struct {
+				Body string `json:"body"`
+			}
+			content, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(content, &body))
+
+			s.mu.Lock()
+			s.comments[path] = body.Body
+			s.mu.Unlock()
+			return jsonResponse(req, 201, `{}`), nil
+		}
+
+		if path == "repos/org/app/issues/42" {
+			return jsonResponse(req, 200, `{"number": 42, "title": "Schema", "state": "`+s.blockerState+`",
+				"html_url": "https://github.com/org/app/issues/42"}`), nil
+		}
+		if response, ok := responses[path]; ok {
+			return jsonResponse(req, 200, response), nil
+		}
+		return jsonResponse(req, 404, `{"message": "Not Found"}`), nil
+	}
+}
+
+func newNotifyServer() *notifyServer {
+	return &notifyServer{blockerState: "closed", comments: make(map[string]string)}
+}
+
+func TestNotifyDependents(t *testing.T) {
+	t.Run("comments on open dependents", func(t *testing.T) {
+		server := newNotifyServer()
+		client := newFakeRESTClient(t, server.handler(t))
+
+		report, err := notifyDependents(context.Background(), client, "org", "app", 42, NotifyOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, "org/app#42", report.Blocker)
+		require.Len(t, report.Dependents, 2)
+
+		assert.Equal(t, NotifyResult{
+			Issue:          "org/app#10",
+			Title:          "Ten",
+			Unblocked:      true,
+			StillBlockedBy: []string{},
+			Comment:        "@alice @bob #42 closed; you are now unblocked.",
+			Status:         StatusApplied,
+		}, report.Dependents[0])
+
+		assert.Equal(t, "org/web#11", report.Dependents[1].Issue)
+		assert.Equal(t, StatusAlreadyNotified, report.Dependents[1].Status)
+		assert.Equal(t, []string{"org/web#7", "org/app#8"}, report.Dependents[1].StillBlockedBy)
+		assert.Equal(t, "org/app#42 closed; still blocked by #7, org/app#8.", report.Dependents[1].Comment)
+
+		assert.Equal(t, map[string]string{
+			"repos/org/app/issues/10/comments": "@alice @bob #42 closed; you are now unblocked.\n\n" +
+				"<!-- gh-issue-dependency:notify blocker=org/app#42 -->",
+		}, server.comments)
+	})
+
+	t.Run("dry run posts nothing", func(t *testing.T) {
+		server := newNotifyServer()
+		client := newFakeRESTClient(t, server.handler(t))
+
+		report, err := notifyDependents(context.Background(), client, "org", "app", 42, NotifyOptions{DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, StatusPlanned, report.Dependents[0].Status)
+		assert.Empty(t, server.comments)
+	})
+
+	t.Run("custom template", func(t *testing.T) {
+		server := newNotifyServer()
+		client := newFakeRESTClient(t, server.handler(t))
+
+		opts := NotifyOptions{Template: `Heads up: {{.blocker_title}} ({{.blocker}}) is done.`}
+		report, err := notifyDependents(context.Background(), client, "org", "app", 42, opts)
+		require.NoError(t, err)
+		assert.Equal(t, "Heads up: Schema (#42) is done.", report.Dependents[0].Comment)
+	})
+
+	t.Run("open blocker", func(t *testing.T) {
+		server := newNotifyServer()
+		server.blockerState = "open"
+		client := newFakeRESTClient(t, server.handler(t))
+
+		_, err := notifyDependents(context.Background(), client, "org", "app", 42, NotifyOptions{})
+		require.Error(t, err)
+		assert.True(t, IsErrorType(err, ErrorTypeValidation))
+		assert.Contains(t, err.Error(), "still open")
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+			t.Fatalf("unexpected request to %s", req.URL.Path)
+			return nil, nil
+		})
+
+		for _, tmpl := range []string{"{{.blocker", "{{if false}}x{{end}}"} {
+			_, err := notifyDependents(context.Background(), client, "org", "app", 42, NotifyOptions{Template: tmpl})
+			assert.True(t, IsErrorType(err, ErrorTypeValidation), tmpl)
+		}
+	})
+}
+
+func TestRelativeIssueRef(t *testing.T) {
+	assert.Equal(t, "#42", relativeIssueRef("org/app", "Org/App", 42))
+	assert.Equal(t, "org/api#42", relativeIssueRef("org/app", "org/api", 42))
+}
+
+func TestFormatNotifyReport(t *testing.T) {
+	report := &NotifyReport{
+		Blocker: "org/app#42",
+		Title:   "Schema",
+		DryRun:  true,
+		Dependents: []NotifyResult{
+			{Issue: "org/app#10", Unblocked: true, Comment: "#42 closed; you are now unblocked.", Status: StatusPlanned},
+			{Issue: "org/web#11", StillBlockedBy: []string{"org/web#7"}, Status: StatusAlreadyNotified},
+		},
+	}
+
+	var buf strings.Builder
+	require.NoError(t, FormatNotifyReport(&buf, report))
+	assert.Equal(t, `org/app#42 (Schema): 2 open dependents
+  would comment     org/app#10  unblocked
+  already notified  org/web#11  still blocked by org/web#7
+
+Comment for org/app#10:
+  #42 closed; you are now unblocked.
+
+No comments posted. Remove --dry-run to post them.
+`, buf.String())
+}
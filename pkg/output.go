@@ -2,10 +2,10 @@
 package pkg
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,21 +17,33 @@ import (
 type OutputFormat int
 
 const (
-	FormatAuto  OutputFormat = iota // Auto-detect based on TTY
-	FormatTTY                       // Rich TTY output with colors and emojis
-	FormatPlain                     // Plain text output
-	FormatJSON                      // JSON output
-	FormatCSV                       // CSV output
+	FormatAuto     OutputFormat = iota // Auto-detect based on TTY
+	FormatTTY                          // Rich TTY output with colors and emojis
+	FormatPlain                        // Plain text output
+	FormatJSON                         // JSON output
+	FormatCSV                          // CSV output
+	FormatMarkdown                     // Markdown for pasting into issues and pull requests
+)
+
+// Markdown styles for FormatMarkdown
+const (
+	MarkdownTaskList = "tasklist" // Task list items checked when the issue is closed
+	MarkdownTable    = "table"    // One table row per dependency
 )
 
 // OutputOptions contains configuration for output formatting
 type OutputOptions struct {
-	Format       OutputFormat
-	JSONFields   []string // Specific fields to include in JSON output
-	Detailed     bool     // Include detailed information
-	Writer       io.Writer
-	StateFilter  string          // Applied state filter for context-aware messaging
-	OriginalData *DependencyData // Original data before filtering for comparison
+	Format        OutputFormat
+	JSONFields    []string      // Specific fields to include in JSON output
+	Export        ExportOptions // Template or jq filter applied to JSON output
+	MarkdownStyle string        // MarkdownTaskList (default) or MarkdownTable
+	Detailed      bool          // Include detailed information
+	Columns       []string      // Table columns to show; defaults depend on Detailed
+	Width         int           // Table width; detected from the terminal when zero
+	Writer        io.Writer
+	StateFilter   string           // Applied state filter for context-aware messaging
+	Filter        DependencyFilter // Applied attribute filters for context-aware messaging
+	OriginalData  *DependencyData  // Original data before filtering for comparison
 }
 
 // DefaultOutputOptions returns sensible defaults for output options
@@ -104,6 +116,8 @@ func (f *OutputFormatter) FormatOutput(data *DependencyData) error {
 		return f.formatJSONOutput(data)
 	case FormatCSV:
 		return f.formatCSVOutput(data)
+	case FormatMarkdown:
+		return f.formatMarkdownOutput(data)
 	default:
 		return f.formatPlainOutput(data)
 	}
@@ -132,10 +146,29 @@ func (f *OutputFormatter) formatTTYOutput(data *DependencyData) error {
 		}
 	}
 
+	// Position in the sub-issue tree
+	if hierarchy := data.SourceHierarchy; hierarchy != nil {
+		if hierarchy.Parent != nil {
+			if err := f.write("%sParent: %s - %s%s\n", muted(""),
+				markdownIssueRef(*hierarchy.Parent, data.SourceIssue.Repository.String()),
+				hierarchy.Parent.Issue.Title, termenv.CSI+termenv.ResetSeq); err != nil {
+				return err
+			}
+		}
+		if closed, total := hierarchy.SubIssueProgress(); total > 0 {
+			if err := f.write("%sSub-issues: %d of %d closed%s\n",
+				muted(""), closed, total, termenv.CSI+termenv.ResetSeq); err != nil {
+				return err
+			}
+		}
+	}
+
 	if err := f.write("\n"); err != nil {
 		return err
 	}
 
+	columns := f.selectedColumns(data, true)
+
 	// BLOCKED BY section
 	if len(data.BlockedBy) > 0 {
 		if err := f.write("%sBLOCKED BY (%d issues)%s\n",
@@ -146,46 +179,13 @@ func (f *OutputFormatter) formatTTYOutput(data *DependencyData) error {
 			separator(""), termenv.CSI+termenv.ResetSeq); err != nil {
 			return err
 		}
-
-		for _, dep := range data.BlockedBy {
-			emoji := f.getStateEmoji(dep.Issue.State)
-			stateColor := f.getStateColor(dep.Issue.State)
-
-			if err := f.write("%s #%-6d %s %s[%s]%s",
-				emoji, dep.Issue.Number, dep.Issue.Title,
-				stateColor(""), dep.Issue.State, termenv.CSI+termenv.ResetSeq); err != nil {
-				return err
-			}
-
-			// Show assignees if available
-			if len(dep.Issue.Assignees) > 0 {
-				assigneeNames := make([]string, len(dep.Issue.Assignees))
-				for i, assignee := range dep.Issue.Assignees {
-					assigneeNames[i] = "@" + assignee.Login
-				}
-				if err := f.write(" %s%s%s",
-					info(""), strings.Join(assigneeNames, ", "), termenv.CSI+termenv.ResetSeq); err != nil {
-					return err
-				}
-			}
-
-			// Show repository context for cross-repo dependencies
-			if dep.Repository != data.SourceIssue.Repository.String() {
-				if err := f.write("\n         %s%s%s",
-					muted(""), dep.Repository, termenv.CSI+termenv.ResetSeq); err != nil {
-					return err
-				}
-			}
-
-			// Show URL for easy navigation
-			if dep.Issue.HTMLURL != "" {
-				if err := f.write("\n         %s%s%s",
-					muted(""), dep.Issue.HTMLURL, termenv.CSI+termenv.ResetSeq); err != nil {
-					return err
-				}
-			}
-
-			if err := f.write("\n"); err != nil {
+		if err := f.writeDependencyTable(data.BlockedBy, "blocked_by", columns, true); err != nil {
+			return err
+		}
+		if notPlanned := countNotPlanned(data.BlockedBy); notPlanned > 0 {
+			warning := f.colorize(termenv.ANSIYellow)
+			if err := f.write("%s⚠️  %d blocking issue(s) closed as not planned; this issue may need a new plan%s\n",
+				warning(""), notPlanned, termenv.CSI+termenv.ResetSeq); err != nil {
 				return err
 			}
 		}
@@ -204,48 +204,42 @@ func (f *OutputFormatter) formatTTYOutput(data *DependencyData) error {
 			separator(""), termenv.CSI+termenv.ResetSeq); err != nil {
 			return err
 		}
+		if err := f.writeDependencyTable(data.Blocking, "blocks", columns, true); err != nil {
+			return err
+		}
+		if err := f.write("\n"); err != nil {
+			return err
+		}
+	}
 
-		for _, dep := range data.Blocking {
-			emoji := f.getStateEmoji(dep.Issue.State)
-			stateColor := f.getStateColor(dep.Issue.State)
-
-			if err := f.write("%s #%-6d %s %s[%s]%s",
-				emoji, dep.Issue.Number, dep.Issue.Title,
-				stateColor(""), dep.Issue.State, termenv.CSI+termenv.ResetSeq); err != nil {
-				return err
-			}
-
-			// Show assignees if available
-			if len(dep.Issue.Assignees) > 0 {
-				assigneeNames := make([]string, len(dep.Issue.Assignees))
-				for i, assignee := range dep.Issue.Assignees {
-					assigneeNames[i] = "@" + assignee.Login
-				}
-				if err := f.write(" %s%s%s",
-					info(""), strings.Join(assigneeNames, ", "), termenv.CSI+termenv.ResetSeq); err != nil {
-					return err
-				}
-			}
-
-			// Show repository context for cross-repo dependencies
-			if dep.Repository != data.SourceIssue.Repository.String() {
-				if err := f.write("\n         %s%s%s",
-					muted(""), dep.Repository, termenv.CSI+termenv.ResetSeq); err != nil {
-					return err
-				}
-			}
-
-			// Show URL for easy navigation
-			if dep.Issue.HTMLURL != "" {
-				if err := f.write("\n         %s%s%s",
-					muted(""), dep.Issue.HTMLURL, termenv.CSI+termenv.ResetSeq); err != nil {
-					return err
-				}
-			}
-
-			if err := f.write("\n"); err != nil {
-				return err
-			}
+	// Hierarchy sections
+	if data.SourceHierarchy != nil && len(data.SourceHierarchy.SubIssues) > 0 {
+		if err := f.write("%sSUB-ISSUES (%d issues)%s\n",
+			header(""), len(data.SourceHierarchy.SubIssues), termenv.CSI+termenv.ResetSeq); err != nil {
+			return err
+		}
+		if err := f.write("%s──────────────────────%s\n",
+			separator(""), termenv.CSI+termenv.ResetSeq); err != nil {
+			return err
+		}
+		if err := f.writeDependencyTable(data.SourceHierarchy.SubIssues, RelationSubIssue, columns, true); err != nil {
+			return err
+		}
+		if err := f.write("\n"); err != nil {
+			return err
+		}
+	}
+	if len(data.RolledUpBlockedBy) > 0 {
+		if err := f.write("%sBLOCKED THROUGH SUB-ISSUES (%d issues)%s\n",
+			header(""), len(data.RolledUpBlockedBy), termenv.CSI+termenv.ResetSeq); err != nil {
+			return err
+		}
+		if err := f.write("%s──────────────────────────────────────%s\n",
+			separator(""), termenv.CSI+termenv.ResetSeq); err != nil {
+			return err
+		}
+		if err := f.writeDependencyTable(data.RolledUpBlockedBy, RelationRolledUpBlocking, rolledUpColumns(columns), true); err != nil {
+			return err
 		}
 		if err := f.write("\n"); err != nil {
 			return err
@@ -276,116 +270,48 @@ func (f *OutputFormatter) formatTTYOutput(data *DependencyData) error {
 	return nil
 }
 
-// formatPlainOutput formats output for plain text without colors or emojis
+// formatPlainOutput formats output as tab-separated values without headers,
+// like gh's own table printer. Each row starts with the relationship type
+// (blocked_by or blocks) followed by the selected columns.
 func (f *OutputFormatter) formatPlainOutput(data *DependencyData) error {
-	// Header
-	if err := f.write("Dependencies for: #%d - %s\n",
-		data.SourceIssue.Number, data.SourceIssue.Title); err != nil {
-		return err
+	// Empty state handling
+	if data.TotalCount == 0 {
+		mainMsg, tipMsg := f.getEmptyStateMessage(data)
+		fmt.Fprintf(f.options.Writer, "%s\n\n", mainMsg)
+		fmt.Fprintf(f.options.Writer, "%s\n", tipMsg)
+		return nil
 	}
 
-	if !data.SourceIssue.Repository.IsEmpty() {
-		if err := f.write("Repository: %s\n", data.SourceIssue.Repository); err != nil {
-			return err
-		}
-	}
-	if err := f.write("\n"); err != nil {
+	columns := f.selectedColumns(data, false)
+	if err := f.writeDependencyTable(data.BlockedBy, "blocked_by", columns, false); err != nil {
 		return err
 	}
-
-	// BLOCKED BY section
-	if len(data.BlockedBy) > 0 {
-		if err := f.write("BLOCKED BY (%d issues)\n", len(data.BlockedBy)); err != nil {
-			return err
-		}
-		fmt.Fprintf(f.options.Writer, "========================\n")
-
-		for _, dep := range data.BlockedBy {
-			fmt.Fprintf(f.options.Writer, "#%d %s [%s]",
-				dep.Issue.Number, dep.Issue.Title, dep.Issue.State)
-
-			// Show assignees if available
-			if len(dep.Issue.Assignees) > 0 {
-				assigneeNames := make([]string, len(dep.Issue.Assignees))
-				for i, assignee := range dep.Issue.Assignees {
-					assigneeNames[i] = "@" + assignee.Login
-				}
-				fmt.Fprintf(f.options.Writer, " %s", strings.Join(assigneeNames, ", "))
-			}
-
-			// Show repository context for cross-repo dependencies
-			if dep.Repository != data.SourceIssue.Repository.String() {
-				fmt.Fprintf(f.options.Writer, "\n       Repository: %s", dep.Repository)
-			}
-
-			// Show URL for easy navigation
-			if dep.Issue.HTMLURL != "" {
-				fmt.Fprintf(f.options.Writer, "\n       URL: %s", dep.Issue.HTMLURL)
-			}
-
-			if err := f.write("\n"); err != nil {
-				return err
-			}
-		}
-		if err := f.write("\n"); err != nil {
-			return err
-		}
+	if err := f.writeDependencyTable(data.Blocking, "blocks", columns, false); err != nil {
+		return err
 	}
 
-	// BLOCKS section
-	if len(data.Blocking) > 0 {
-		fmt.Fprintf(f.options.Writer, "BLOCKS (%d issues)\n", len(data.Blocking))
-		fmt.Fprintf(f.options.Writer, "==================\n")
-
-		for _, dep := range data.Blocking {
-			fmt.Fprintf(f.options.Writer, "#%d %s [%s]",
-				dep.Issue.Number, dep.Issue.Title, dep.Issue.State)
-
-			// Show assignees if available
-			if len(dep.Issue.Assignees) > 0 {
-				assigneeNames := make([]string, len(dep.Issue.Assignees))
-				for i, assignee := range dep.Issue.Assignees {
-					assigneeNames[i] = "@" + assignee.Login
-				}
-				fmt.Fprintf(f.options.Writer, " %s", strings.Join(assigneeNames, ", "))
-			}
-
-			// Show repository context for cross-repo dependencies
-			if dep.Repository != data.SourceIssue.Repository.String() {
-				fmt.Fprintf(f.options.Writer, "\n       Repository: %s", dep.Repository)
-			}
-
-			// Show URL for easy navigation
-			if dep.Issue.HTMLURL != "" {
-				fmt.Fprintf(f.options.Writer, "\n       URL: %s", dep.Issue.HTMLURL)
-			}
-
-			if err := f.write("\n"); err != nil {
+	// Hierarchy rows follow, so the relationship rows keep their place
+	if hierarchy := data.SourceHierarchy; hierarchy != nil {
+		if hierarchy.Parent != nil {
+			if err := f.writeDependencyTable([]DependencyRelation{*hierarchy.Parent}, RelationParent, columns, false); err != nil {
 				return err
 			}
 		}
-		if err := f.write("\n"); err != nil {
+		if err := f.writeDependencyTable(hierarchy.SubIssues, RelationSubIssue, columns, false); err != nil {
 			return err
 		}
 	}
-
-	// Empty state handling
-	if data.TotalCount == 0 {
-		mainMsg, tipMsg := f.getEmptyStateMessage(data)
-		fmt.Fprintf(f.options.Writer, "%s\n\n", mainMsg)
-		fmt.Fprintf(f.options.Writer, "%s\n", tipMsg)
-	}
-
-	// Footer with metadata
-	if f.options.Detailed {
-		fmt.Fprintf(f.options.Writer, "Fetched at: %s\n", data.FetchedAt.Format(time.RFC3339))
-	}
-
-	return nil
+	return f.writeDependencyTable(data.RolledUpBlockedBy, RelationRolledUpBlocking, rolledUpColumns(columns), false)
 }
 
-// formatJSONOutput formats output as JSON with optional field selection
+// formatJSONOutput formats output as JSON with optional field selection, or
+// through the configured template or jq filter
 func (f *OutputFormatter) formatJSONOutput(data *DependencyData) error {
+	return ExportJSON(f.options.Writer, f.buildJSONOutput(data), f.options.Export)
+}
+
+// buildJSONOutput builds the structure written by JSON and template output
+func (f *OutputFormatter) buildJSONOutput(data *DependencyData) map[string]interface{} {
 	// Create output structure
 	output := map[string]interface{}{
 		"source_issue": f.formatIssueForJSON(&data.SourceIssue),
@@ -399,28 +325,34 @@ func (f *OutputFormatter) formatJSONOutput(data *DependencyData) error {
 		},
 	}
 
+	// Hierarchy is only present when it was fetched, and rolled-up blockers
+	// only when they were computed
+	if data.SourceHierarchy != nil {
+		f.addHierarchyForJSON(output["source_issue"].(map[string]interface{}), data.SourceHierarchy)
+	}
+	if data.RolledUpBlockedBy != nil {
+		rolledUp := f.formatDependenciesForJSON(data.RolledUpBlockedBy)
+		if rolledUp == nil {
+			rolledUp = []map[string]interface{}{}
+		}
+		output["rolled_up_blocked_by"] = rolledUp
+		output["summary"].(map[string]interface{})["rolled_up_blocked_by_count"] = len(data.RolledUpBlockedBy)
+	}
+
 	// Apply field selection if specified
 	if len(f.options.JSONFields) > 0 {
-		filtered := make(map[string]interface{})
-		for _, field := range f.options.JSONFields {
-			if value, exists := output[field]; exists {
-				filtered[field] = value
-			}
-		}
-		output = filtered
+		output = selectJSONFields(output, f.options.JSONFields)
 	}
 
-	// Use Go's JSON encoder for consistent formatting
-	encoder := json.NewEncoder(f.options.Writer)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	return output
 }
 
 // formatCSVOutput formats output as CSV (reuse existing implementation)
 func (f *OutputFormatter) formatCSVOutput(data *DependencyData) error {
 	// CSV header
 	if f.options.Detailed {
-		fmt.Fprintf(f.options.Writer, "type,repository,number,title,state,assignees,labels,html_url\n")
+		fmt.Fprintf(f.options.Writer, "type,repository,number,title,state,assignees,labels,html_url,"+
+			"state_reason,author,milestone,issue_type,opened_at,updated_at,closed_at,comments,locked,created_at,created_by\n")
 	} else {
 		fmt.Fprintf(f.options.Writer, "type,repository,number,title,state\n")
 	}
@@ -437,6 +369,8 @@ func (f *OutputFormatter) formatCSVOutput(data *DependencyData) error {
 			escapeCSV(formatAssigneesForCSV(data.SourceIssue.Assignees)),
 			escapeCSV(formatLabelsForCSV(data.SourceIssue.Labels)),
 			escapeCSV(data.SourceIssue.HTMLURL))
+		fmt.Fprintf(f.options.Writer, ",%s", formatIssueDetailsForCSV(data.SourceIssue))
+		fmt.Fprintf(f.options.Writer, ",,") // relationship metadata does not apply to the source
 	}
 	if err := f.write("\n"); err != nil {
 		return err
@@ -455,6 +389,8 @@ func (f *OutputFormatter) formatCSVOutput(data *DependencyData) error {
 				escapeCSV(formatAssigneesForCSV(dep.Issue.Assignees)),
 				escapeCSV(formatLabelsForCSV(dep.Issue.Labels)),
 				escapeCSV(dep.Issue.HTMLURL))
+			fmt.Fprintf(f.options.Writer, ",%s", formatIssueDetailsForCSV(dep.Issue))
+			fmt.Fprintf(f.options.Writer, ",%s,%s", formatCreatedAtForCSV(dep), escapeCSV(dep.CreatedBy))
 		}
 		if err := f.write("\n"); err != nil {
 			return err
@@ -474,6 +410,8 @@ func (f *OutputFormatter) formatCSVOutput(data *DependencyData) error {
 				escapeCSV(formatAssigneesForCSV(dep.Issue.Assignees)),
 				escapeCSV(formatLabelsForCSV(dep.Issue.Labels)),
 				escapeCSV(dep.Issue.HTMLURL))
+			fmt.Fprintf(f.options.Writer, ",%s", formatIssueDetailsForCSV(dep.Issue))
+			fmt.Fprintf(f.options.Writer, ",%s,%s", formatCreatedAtForCSV(dep), escapeCSV(dep.CreatedBy))
 		}
 		if err := f.write("\n"); err != nil {
 			return err
@@ -483,6 +421,106 @@ func (f *OutputFormatter) formatCSVOutput(data *DependencyData) error {
 	return nil
 }
 
+// formatMarkdownOutput formats output as Markdown with a heading for the source
+// issue and a section per relationship type, rendered as task lists or tables.
+// References to other repositories are fully qualified so GitHub auto-links them.
+func (f *OutputFormatter) formatMarkdownOutput(data *DependencyData) error {
+	sourceRepo := data.SourceIssue.Repository.String()
+
+	source := fmt.Sprintf("#%d", data.SourceIssue.Number)
+	if sourceRepo != "" {
+		source = fmt.Sprintf("%s#%d", sourceRepo, data.SourceIssue.Number)
+	}
+	if err := f.write("## Dependencies for %s: %s\n", source, escapeMarkdown(data.SourceIssue.Title)); err != nil {
+		return err
+	}
+	if data.SourceHierarchy != nil && data.SourceHierarchy.Parent != nil {
+		parent := data.SourceHierarchy.Parent
+		if err := f.write("\nParent: %s %s\n", markdownIssueRef(*parent, sourceRepo), escapeMarkdown(parent.Issue.Title)); err != nil {
+			return err
+		}
+	}
+
+	if data.TotalCount == 0 {
+		mainMsg, _ := f.getEmptyStateMessage(data)
+		if err := f.write("\n_%s_\n", mainMsg); err != nil {
+			return err
+		}
+	}
+
+	type markdownSection struct {
+		title string
+		deps  []DependencyRelation
+	}
+	sections := []markdownSection{
+		{"Blocked by", data.BlockedBy},
+		{"Blocks", data.Blocking},
+	}
+	if data.SourceHierarchy != nil {
+		sections = append(sections,
+			markdownSection{"Sub-issues", data.SourceHierarchy.SubIssues},
+			markdownSection{"Blocked through sub-issues", data.RolledUpBlockedBy})
+	}
+
+	for _, section := range sections {
+		if len(section.deps) == 0 {
+			continue
+		}
+
+		if err := f.write("\n### %s (%d)\n\n", section.title, len(section.deps)); err != nil {
+			return err
+		}
+
+		if f.options.MarkdownStyle == MarkdownTable {
+			if err := f.write("| Issue | Title | State |\n| --- | --- | --- |\n"); err != nil {
+				return err
+			}
+		}
+
+		for _, dep := range section.deps {
+			ref := markdownIssueRef(dep, sourceRepo)
+			title := escapeMarkdown(dep.Issue.Title)
+			if len(dep.Via) > 0 {
+				title += fmt.Sprintf(" (via %s)", strings.Join(dep.Via, ", "))
+			}
+
+			var err error
+			if f.options.MarkdownStyle == MarkdownTable {
+				err = f.write("| %s | %s | %s |\n", ref, title, displayState(dep.Issue))
+			} else if dep.Issue.IsNotPlanned() {
+				// Struck through so it doesn't read as done
+				err = f.write("- [x] ~~%s %s~~ (not planned)\n", ref, title)
+			} else {
+				checkbox := " "
+				if strings.EqualFold(dep.Issue.State, "closed") {
+					checkbox = "x"
+				}
+				err = f.write("- [%s] %s %s\n", checkbox, ref, title)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// markdownIssueRef returns the reference GitHub auto-links for dep: the short
+// form within the source repository and owner/repo#number elsewhere
+func markdownIssueRef(dep DependencyRelation, sourceRepo string) string {
+	if dep.Repository == "" || dep.Repository == sourceRepo {
+		return fmt.Sprintf("#%d", dep.Issue.Number)
+	}
+	return fmt.Sprintf("%s#%d", dep.Repository, dep.Issue.Number)
+}
+
+// escapeMarkdown keeps titles on one line and stops pipes from breaking table cells
+func escapeMarkdown(value string) string {
+	value = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(value)
+	return strings.ReplaceAll(value, "|", "\\|")
+}
+
 // Helper functions for TTY output
 
 // colorize returns a function that applies the given color
@@ -492,9 +530,15 @@ func (f *OutputFormatter) colorize(color termenv.Color) func(string) string {
 	}
 }
 
-// getStateEmoji returns the appropriate emoji for the issue state
-func (f *OutputFormatter) getStateEmoji(state string) string {
-	switch strings.ToLower(state) {
+// getStateEmoji returns the appropriate emoji for the issue state. Issues
+// closed as not planned get their own emoji, since a blocker that will never be
+// done usually means the plan needs to change.
+func (f *OutputFormatter) getStateEmoji(issue Issue) string {
+	if issue.IsNotPlanned() {
+		return "🚫"
+	}
+
+	switch strings.ToLower(issue.State) {
 	case "open":
 		return "🔵"
 	case "closed":
@@ -505,8 +549,12 @@ func (f *OutputFormatter) getStateEmoji(state string) string {
 }
 
 // getStateColor returns the appropriate color function for the issue state
-func (f *OutputFormatter) getStateColor(state string) func(string) string {
-	switch strings.ToLower(state) {
+func (f *OutputFormatter) getStateColor(issue Issue) func(string) string {
+	if issue.IsNotPlanned() {
+		return f.colorize(termenv.ANSIYellow)
+	}
+
+	switch strings.ToLower(issue.State) {
 	case "open":
 		return f.colorize(termenv.ANSIGreen)
 	case "closed":
@@ -516,8 +564,37 @@ func (f *OutputFormatter) getStateColor(state string) func(string) string {
 	}
 }
 
+// displayState returns the state shown to people: "not planned" for issues
+// closed as not planned, otherwise the issue state
+func displayState(issue Issue) string {
+	if issue.IsNotPlanned() {
+		return "not planned"
+	}
+	return issue.State
+}
+
+// countNotPlanned returns how many deps were closed as not planned
+func countNotPlanned(deps []DependencyRelation) int {
+	count := 0
+	for _, dep := range deps {
+		if dep.Issue.IsNotPlanned() {
+			count++
+		}
+	}
+	return count
+}
+
 // Helper functions for JSON formatting
 
+// showJSONField reports whether an issue field belongs in JSON output. Detailed
+// fields are included with --detailed or when selected by a dotted --json path.
+func (f *OutputFormatter) showJSONField(name string) bool {
+	if !jsonDetailedIssueFields[name] || f.options.Detailed {
+		return true
+	}
+	return requestedLeafFields(f.options.JSONFields)[name]
+}
+
 // formatIssueForJSON formats a single issue for JSON output
 func (f *OutputFormatter) formatIssueForJSON(issue *Issue) map[string]interface{} {
 	result := map[string]interface{}{
@@ -527,19 +604,75 @@ func (f *OutputFormatter) formatIssueForJSON(issue *Issue) map[string]interface{
 		"repository": issue.Repository,
 	}
 
-	if f.options.Detailed {
-		if len(issue.Assignees) > 0 {
-			result["assignees"] = formatAssigneesForJSON(issue.Assignees)
-		}
-		if len(issue.Labels) > 0 {
-			result["labels"] = formatLabelsForJSON(issue.Labels)
+	if f.showJSONField("assignees") && len(issue.Assignees) > 0 {
+		result["assignees"] = formatAssigneesForJSON(issue.Assignees)
+	}
+	if f.showJSONField("labels") && len(issue.Labels) > 0 {
+		result["labels"] = formatLabelsForJSON(issue.Labels)
+	}
+	if f.showJSONField("html_url") && issue.HTMLURL != "" {
+		result["html_url"] = issue.HTMLURL
+	}
+	f.addIssueDetailsForJSON(result, issue)
+
+	return result
+}
+
+// addIssueDetailsForJSON adds the issue metadata fields that are set on issue.
+// The issue's creation time is opened_at, since created_at on a relation is when
+// the relationship was added.
+func (f *OutputFormatter) addIssueDetailsForJSON(result map[string]interface{}, issue *Issue) {
+	if issue.StateReason != "" {
+		result["state_reason"] = issue.StateReason
+	}
+	if f.showJSONField("author") && issue.User != nil {
+		result["author"] = issue.User.Login
+	}
+	if f.showJSONField("milestone") && issue.Milestone != nil {
+		milestone := map[string]interface{}{
+			"number": issue.Milestone.Number,
+			"title":  issue.Milestone.Title,
 		}
-		if issue.HTMLURL != "" {
-			result["html_url"] = issue.HTMLURL
+		if !issue.Milestone.DueOn.IsZero() {
+			milestone["due_on"] = issue.Milestone.DueOn.Format(time.RFC3339)
 		}
+		result["milestone"] = milestone
 	}
+	if f.showJSONField("issue_type") && issue.Type != nil {
+		result["issue_type"] = issue.Type.Name
+	}
+	if f.showJSONField("opened_at") && !issue.CreatedAt.IsZero() {
+		result["opened_at"] = issue.CreatedAt.Format(time.RFC3339)
+	}
+	if f.showJSONField("updated_at") && !issue.UpdatedAt.IsZero() {
+		result["updated_at"] = issue.UpdatedAt.Format(time.RFC3339)
+	}
+	if f.showJSONField("closed_at") && !issue.ClosedAt.IsZero() {
+		result["closed_at"] = issue.ClosedAt.Format(time.RFC3339)
+	}
+	if f.showJSONField("comments") {
+		result["comments"] = issue.Comments
+	}
+	if f.showJSONField("locked") {
+		result["locked"] = issue.Locked
+	}
+}
 
-	return result
+// addHierarchyForJSON adds an issue's parent and sub-issues. Both keys are
+// always present once the hierarchy was fetched, so a null parent means the
+// issue is top-level.
+func (f *OutputFormatter) addHierarchyForJSON(result map[string]interface{}, hierarchy *Hierarchy) {
+	var parent interface{}
+	if hierarchy.Parent != nil {
+		parent = f.formatDependenciesForJSON([]DependencyRelation{*hierarchy.Parent})[0]
+	}
+	result["parent"] = parent
+
+	subIssues := f.formatDependenciesForJSON(hierarchy.SubIssues)
+	if subIssues == nil {
+		subIssues = []map[string]interface{}{}
+	}
+	result["sub_issues"] = subIssues
 }
 
 // formatDependenciesForJSON formats dependency relations for JSON output
@@ -554,16 +687,30 @@ func (f *OutputFormatter) formatDependenciesForJSON(deps []DependencyRelation) [
 			"repository": dep.Repository,
 		}
 
-		if f.options.Detailed {
-			if len(dep.Issue.Assignees) > 0 {
-				item["assignees"] = formatAssigneesForJSON(dep.Issue.Assignees)
-			}
-			if len(dep.Issue.Labels) > 0 {
-				item["labels"] = formatLabelsForJSON(dep.Issue.Labels)
-			}
-			if dep.Issue.HTMLURL != "" {
-				item["html_url"] = dep.Issue.HTMLURL
-			}
+		if f.showJSONField("assignees") && len(dep.Issue.Assignees) > 0 {
+			item["assignees"] = formatAssigneesForJSON(dep.Issue.Assignees)
+		}
+		if f.showJSONField("labels") && len(dep.Issue.Labels) > 0 {
+			item["labels"] = formatLabelsForJSON(dep.Issue.Labels)
+		}
+		if f.showJSONField("html_url") && dep.Issue.HTMLURL != "" {
+			item["html_url"] = dep.Issue.HTMLURL
+		}
+		f.addIssueDetailsForJSON(item, &dep.Issue)
+		if f.showJSONField("created_at") && !dep.CreatedAt.IsZero() {
+			item["created_at"] = dep.CreatedAt.Format(time.RFC3339)
+		}
+		if f.showJSONField("created_by") && dep.CreatedBy != "" {
+			item["created_by"] = dep.CreatedBy
+		}
+		if dep.Hierarchy != nil {
+			f.addHierarchyForJSON(item, dep.Hierarchy)
+		}
+		if len(dep.Via) > 0 {
+			item["via"] = dep.Via
+		}
+		if dep.PullRequests != nil {
+			item["pull_requests"] = dep.PullRequests
 		}
 
 		result = append(result, item)
@@ -625,6 +772,46 @@ func formatLabelsForCSV(labels []Label) string {
 	return joinStrings(names, "; ")
 }
 
+// formatCreatedAtForCSV formats a relation's creation time, or "" when unknown
+func formatCreatedAtForCSV(dep DependencyRelation) string {
+	return formatTimeForCSV(dep.CreatedAt)
+}
+
+// formatIssueDetailsForCSV formats the issue metadata columns of detailed CSV,
+// from state_reason through locked
+func formatIssueDetailsForCSV(issue Issue) string {
+	author, milestone, issueType := "", "", ""
+	if issue.User != nil {
+		author = issue.User.Login
+	}
+	if issue.Milestone != nil {
+		milestone = issue.Milestone.Title
+	}
+	if issue.Type != nil {
+		issueType = issue.Type.Name
+	}
+
+	return strings.Join([]string{
+		issue.StateReason,
+		escapeCSV(author),
+		escapeCSV(milestone),
+		escapeCSV(issueType),
+		formatTimeForCSV(issue.CreatedAt),
+		formatTimeForCSV(issue.UpdatedAt),
+		formatTimeForCSV(issue.ClosedAt),
+		strconv.Itoa(issue.Comments),
+		strconv.FormatBool(issue.Locked),
+	}, ",")
+}
+
+// formatTimeForCSV formats t as RFC 3339, or "" when unset
+func formatTimeForCSV(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
 // joinStrings joins a slice of strings with a separator
 func joinStrings(items []string, separator string) string {
 	if len(items) == 0 {
@@ -683,6 +870,10 @@ func (f *OutputFormatter) addFilterContext(data *DependencyData) {
 func (f *OutputFormatter) getEmptyStateMessage(data *DependencyData) (string, string) {
 	var mainMsg, tipMsg string
 
+	if !f.options.Filter.IsEmpty() && f.options.OriginalData != nil && f.options.OriginalData.TotalCount > 0 {
+		return f.getFilteredEmptyStateMessage(data)
+	}
+
 	// Check if we have original data to compare against
 	hasOriginalData := f.options.OriginalData != nil &&
 		(f.options.OriginalData.TotalCount > data.TotalCount)
@@ -726,3 +917,38 @@ func (f *OutputFormatter) getEmptyStateMessage(data *DependencyData) (string, st
 
 	return mainMsg, tipMsg
 }
+
+// getFilteredEmptyStateMessage explains which filters removed every dependency.
+// A dependency excluded by several filters is counted once for each of them.
+func (f *OutputFormatter) getFilteredEmptyStateMessage(data *DependencyData) (string, string) {
+	stateFlag := ""
+	if f.options.StateFilter != "" && f.options.StateFilter != "all" {
+		stateFlag = "--state " + f.options.StateFilter
+	}
+
+	counts := make(map[string]int)
+	original := f.options.OriginalData
+	for _, deps := range [][]DependencyRelation{original.BlockedBy, original.Blocking} {
+		for _, dep := range deps {
+			if stateFlag != "" && dep.Issue.State != f.options.StateFilter {
+				counts[stateFlag]++
+			}
+			for _, flag := range f.options.Filter.Rejections(dep) {
+				counts[flag]++
+			}
+		}
+	}
+
+	// Report in flag order: state first, then the attribute filters
+	var reasons []string
+	for _, flag := range append([]string{stateFlag}, f.options.Filter.Flags()...) {
+		if counts[flag] > 0 {
+			reasons = append(reasons, fmt.Sprintf("%d by %s", counts[flag], flag))
+		}
+	}
+
+	mainMsg := fmt.Sprintf("No dependencies match the filters for issue #%d.", data.SourceIssue.Number)
+	tipMsg := fmt.Sprintf("Note: %d dependencies found; excluded %s.\nRemove or change a filter to see them.",
+		original.TotalCount, strings.Join(reasons, ", "))
+	return mainMsg, tipMsg
+}
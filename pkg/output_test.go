@@ -135,10 +135,13 @@ func TestNewOutputFormatter(t *testing.T) {
 // Test TTY Output Formatting
 func TestFormatTTYOutput(t *testing.T) {
 	tests := []struct {
-		name     string
-		data     *DependencyData
-		detailed bool
-		contains []string
+		name        string
+		data        *DependencyData
+		detailed    bool
+		columns     []string
+		width       int
+		contains    []string
+		notContains []string
 	}{
 		{
 			name:     "single blocked by dependency",
@@ -147,14 +150,16 @@ func TestFormatTTYOutput(t *testing.T) {
 			contains: []string{
 				"Dependencies for: #123 - Main Feature Implementation",
 				"BLOCKED BY (2 issues)",
-				"🔵 #45",
+				"ISSUE",
+				"TITLE",
+				"🔵  #45",
 				"Setup Database Schema",
-				"[open]",
-				"✅ #67",
+				"open",
+				"✅  #67",
 				"API Endpoint Creation",
-				"[closed]",
+				"closed",
 				"BLOCKS (1 issues)",
-				"🔵 #89",
+				"🔵  #89",
 				"Frontend Integration",
 				"testowner/frontend",
 			},
@@ -163,13 +168,41 @@ func TestFormatTTYOutput(t *testing.T) {
 			name:     "detailed output with metadata",
 			data:     createTestDependencyData(),
 			detailed: true,
+			width:    200,
 			contains: []string{
 				"Dependencies for: #123 - Main Feature Implementation",
 				"@bob",
 				"@charlie, @diana",
+				"frontend, urgent",
+				"LABELS",
+				"ADDED BY",
 				"Fetched at: 2024-01-01T12:00:00Z",
+			},
+		},
+		{
+			name:    "selected columns",
+			data:    createTestDependencyData(),
+			columns: []string{"number", "url"},
+			contains: []string{
+				"#45",
 				"https://github.com/testowner/testrepo/issues/45",
 			},
+			notContains: []string{
+				"Setup Database Schema",
+				"@bob",
+			},
+		},
+		{
+			name:    "long titles are truncated to the width",
+			data:    createTestDependencyData(),
+			columns: []string{"number", "title"},
+			width:   24,
+			contains: []string{
+				"Setup Data...",
+			},
+			notContains: []string{
+				"Setup Database Schema",
+			},
 		},
 		{
 			name:     "empty dependencies",
@@ -190,6 +223,11 @@ func TestFormatTTYOutput(t *testing.T) {
 				Format:   FormatTTY,
 				Writer:   &buffer,
 				Detailed: tt.detailed,
+				Columns:  tt.columns,
+				Width:    tt.width,
+			}
+			if options.Width == 0 {
+				options.Width = 120
 			}
 
 			formatter := NewOutputFormatter(options)
@@ -201,6 +239,9 @@ func TestFormatTTYOutput(t *testing.T) {
 			for _, expected := range tt.contains {
 				assert.Contains(t, output, expected, "Output should contain: %s", expected)
 			}
+			for _, unexpected := range tt.notContains {
+				assert.NotContains(t, output, unexpected, "Output should not contain: %s", unexpected)
+			}
 		})
 	}
 }
@@ -208,56 +249,41 @@ func TestFormatTTYOutput(t *testing.T) {
 // Test Plain Output Formatting
 func TestFormatPlainOutput(t *testing.T) {
 	tests := []struct {
-		name        string
-		data        *DependencyData
-		detailed    bool
-		contains    []string
-		notContains []string
+		name     string
+		data     *DependencyData
+		detailed bool
+		columns  []string
+		expected string
 	}{
 		{
-			name:     "basic plain output",
-			data:     createTestDependencyData(),
-			detailed: false,
-			contains: []string{
-				"Dependencies for: #123 - Main Feature Implementation",
-				"Repository: testowner/testrepo",
-				"BLOCKED BY (2 issues)",
-				"#45 Setup Database Schema [open]",
-				"#67 API Endpoint Creation [closed]",
-				"BLOCKS (1 issues)",
-				"#89 Frontend Integration [open]",
-			},
-			notContains: []string{
-				"🔵", "✅", "💡", // No emojis in plain output
-			},
+			name: "tab-separated rows",
+			data: createTestDependencyData(),
+			expected: "blocked_by\t45\tSetup Database Schema\topen\tbob\ttestowner/testrepo\n" +
+				"blocked_by\t67\tAPI Endpoint Creation\tclosed\t\ttestowner/testrepo\n" +
+				"blocks\t89\tFrontend Integration\topen\tcharlie,diana\ttestowner/frontend\n",
 		},
 		{
-			name:     "plain output with assignees",
+			name:     "detailed rows include labels, times, and URL",
 			data:     createTestDependencyData(),
-			detailed: false,
-			contains: []string{
-				"@bob",
-				"@charlie, @diana",
-				"Repository: testowner/frontend",
-			},
+			detailed: true,
+			expected: "blocked_by\t45\tSetup Database Schema\topen\tbob\ttestowner/testrepo\t\t\t\t\t\thttps://github.com/testowner/testrepo/issues/45\n" +
+				"blocked_by\t67\tAPI Endpoint Creation\tclosed\t\ttestowner/testrepo\t\t\t\t\t\thttps://github.com/testowner/testrepo/issues/67\n" +
+				"blocks\t89\tFrontend Integration\topen\tcharlie,diana\ttestowner/frontend\tfrontend,urgent\t\t\t\t\thttps://github.com/testowner/frontend/issues/89\n",
 		},
 		{
-			name:     "detailed plain output",
-			data:     createTestDependencyData(),
-			detailed: true,
-			contains: []string{
-				"URL: https://github.com/testowner/testrepo/issues/45",
-				"Fetched at: 2024-01-01T12:00:00Z",
-			},
+			name:    "selected columns",
+			data:    createTestDependencyData(),
+			columns: []string{"state", "number"},
+			expected: "blocked_by\topen\t45\n" +
+				"blocked_by\tclosed\t67\n" +
+				"blocks\topen\t89\n",
 		},
 		{
-			name:     "empty plain output",
-			data:     createEmptyDependencyData(),
-			detailed: false,
-			contains: []string{
-				"No dependencies found for issue #456",
-				"gh issue-dependency add",
-			},
+			name: "empty plain output",
+			data: createEmptyDependencyData(),
+			expected: "No dependencies found for issue #456.\n\n" +
+				"Use 'gh issue-dependency add' to create dependency relationships.\n" +
+				"Note: Some dependencies may exist in repositories you don't have access to.\n",
 		},
 	}
 
@@ -265,23 +291,18 @@ func TestFormatPlainOutput(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			var buffer bytes.Buffer
 			options := &OutputOptions{
-				Format:   FormatPlain,
-				Writer:   &buffer,
-				Detailed: tt.detailed,
+				Format:      FormatPlain,
+				Writer:      &buffer,
+				Detailed:    tt.detailed,
+				Columns:     tt.columns,
+				StateFilter: "all",
 			}
 
 			formatter := NewOutputFormatter(options)
 			err := formatter.FormatOutput(tt.data)
 
 			assert.NoError(t, err)
-			output := buffer.String()
-
-			for _, expected := range tt.contains {
-				assert.Contains(t, output, expected, "Output should contain: %s", expected)
-			}
-			for _, unexpected := range tt.notContains {
-				assert.NotContains(t, output, unexpected, "Output should not contain: %s", unexpected)
-			}
+			assert.Equal(t, tt.expected, buffer.String())
 		})
 	}
 }
@@ -431,7 +452,8 @@ func TestFormatCSVOutput(t *testing.T) {
 			detailed: true,
 			validate: func(t *testing.T, lines []string) {
 				// Header should include additional fields
-				assert.Equal(t, "type,repository,number,title,state,assignees,labels,html_url", lines[0])
+				assert.Equal(t, "type,repository,number,title,state,assignees,labels,html_url,"+
+					"state_reason,author,milestone,issue_type,opened_at,updated_at,closed_at,comments,locked,created_at,created_by", lines[0])
 
 				// Check assignees and labels are included
 				assert.Contains(t, lines[1], "@alice")
@@ -749,3 +771,152 @@ func TestLargeDatasetOutput(t *testing.T) {
 		assert.Len(t, blockedBy, 100)
 	})
 }
+
+// Test Markdown Output Formatting
+func TestFormatMarkdownOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    string
+		data     *DependencyData
+		expected string
+	}{
+		{
+			name:  "task list",
+			style: MarkdownTaskList,
+			data:  createTestDependencyData(),
+			expected: "## Dependencies for testowner/testrepo#123: Main Feature Implementation\n" +
+				"\n### Blocked by (2)\n\n" +
+				"- [ ] #45 Setup Database Schema\n" +
+				"- [x] #67 API Endpoint Creation\n" +
+				"\n### Blocks (1)\n\n" +
+				"- [ ] testowner/frontend#89 Frontend Integration\n",
+		},
+		{
+			name:  "table",
+			style: MarkdownTable,
+			data:  createTestDependencyData(),
+			expected: "## Dependencies for testowner/testrepo#123: Main Feature Implementation\n" +
+				"\n### Blocked by (2)\n\n" +
+				"| Issue | Title | State |\n| --- | --- | --- |\n" +
+				"| #45 | Setup Database Schema | open |\n" +
+				"| #67 | API Endpoint Creation | closed |\n" +
+				"\n### Blocks (1)\n\n" +
+				"| Issue | Title | State |\n| --- | --- | --- |\n" +
+				"| testowner/frontend#89 | Frontend Integration | open |\n",
+		},
+		{
+			name:  "empty",
+			style: MarkdownTaskList,
+			data:  createEmptyDependencyData(),
+			expected: "## Dependencies for testowner/testrepo#456: Standalone Task\n" +
+				"\n_No dependencies found for issue #456._\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			formatter := NewOutputFormatter(&OutputOptions{
+				Format:        FormatMarkdown,
+				MarkdownStyle: tt.style,
+				Writer:        &buf,
+				StateFilter:   "all",
+			})
+
+			require.NoError(t, formatter.FormatOutput(tt.data))
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	assert.Equal(t, `Parse a \| b`, escapeMarkdown("Parse a | b"))
+	assert.Equal(t, "two lines", escapeMarkdown("two\nlines"))
+}
+
+// createNotPlannedDependencyData returns test data where #67 was closed as not
+// planned and #45 carries the full issue metadata
+func createNotPlannedDependencyData() *DependencyData {
+	data := createTestDependencyData()
+	data.BlockedBy[0].Issue.User = &User{Login: "alice"}
+	data.BlockedBy[0].Issue.Milestone = &Milestone{Number: 3, Title: "v2.0", DueOn: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}
+	data.BlockedBy[0].Issue.Type = &IssueType{Name: "Task"}
+	data.BlockedBy[0].Issue.CreatedAt = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	data.BlockedBy[0].Issue.Comments = 4
+	data.BlockedBy[0].Issue.Locked = true
+	data.BlockedBy[1].Issue.StateReason = StateReasonNotPlanned
+	data.BlockedBy[1].Issue.ClosedAt = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	return data
+}
+
+func TestIssueDetailsOutput(t *testing.T) {
+	format := func(format OutputFormat, detailed bool, fields []string, style string) string {
+		var buf bytes.Buffer
+		formatter := NewOutputFormatter(&OutputOptions{
+			Format:        format,
+			Detailed:      detailed,
+			JSONFields:    fields,
+			MarkdownStyle: style,
+			Writer:        &buf,
+			Width:         200,
+			StateFilter:   "all",
+		})
+		require.NoError(t, formatter.FormatOutput(createNotPlannedDependencyData()))
+		return buf.String()
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var output map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(format(FormatJSON, true, nil, "")), &output))
+		blockedBy := output["blocked_by"].([]interface{})
+
+		first := blockedBy[0].(map[string]interface{})
+		assert.Equal(t, "alice", first["author"])
+		assert.Equal(t, "Task", first["issue_type"])
+		assert.Equal(t, "2024-01-02T03:04:05Z", first["opened_at"])
+		assert.Equal(t, float64(4), first["comments"])
+		assert.Equal(t, true, first["locked"])
+		assert.Equal(t, map[string]interface{}{"number": float64(3), "title": "v2.0", "due_on": "2024-06-01T00:00:00Z"}, first["milestone"])
+
+		second := blockedBy[1].(map[string]interface{})
+		assert.Equal(t, "not_planned", second["state_reason"])
+		assert.Equal(t, "2024-02-01T00:00:00Z", second["closed_at"])
+
+		// Without --detailed only the state reason is included by default
+		require.NoError(t, json.Unmarshal([]byte(format(FormatJSON, false, nil, "")), &output))
+		second = output["blocked_by"].([]interface{})[1].(map[string]interface{})
+		assert.Equal(t, "not_planned", second["state_reason"])
+		assert.NotContains(t, second, "comments")
+
+		require.NoError(t, json.Unmarshal([]byte(format(FormatJSON, false, []string{"blocked_by.comments"}, "")), &output))
+		first = output["blocked_by"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"comments": float64(4)}, first)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		lines := strings.Split(strings.TrimSpace(format(FormatCSV, true, nil, "")), "\n")
+		assert.Contains(t, lines[2], ",,alice,v2.0,Task,2024-01-02T03:04:05Z,,,4,true,")
+		assert.Contains(t, lines[3], ",not_planned,,,,,,2024-02-01T00:00:00Z,0,false,")
+	})
+
+	t.Run("tty", func(t *testing.T) {
+		output := format(FormatTTY, true, nil, "")
+		assert.Contains(t, output, "🚫")
+		assert.Contains(t, output, "not planned")
+		assert.Contains(t, output, "v2.0")
+		assert.Contains(t, output, "1 blocking issue(s) closed as not planned")
+
+		assert.NotContains(t, format(FormatTTY, false, nil, ""), "v2.0", "milestone is a detailed column")
+	})
+
+	t.Run("plain keeps the API state", func(t *testing.T) {
+		output := format(FormatPlain, false, nil, "")
+		assert.Contains(t, output, "blocked_by\t67\tAPI Endpoint Creation\tclosed\t")
+		assert.NotContains(t, output, "not planned")
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		assert.Contains(t, format(FormatMarkdown, false, nil, MarkdownTaskList), "- [x] ~~#67 API Endpoint Creation~~ (not planned)\n")
+		assert.Contains(t, format(FormatMarkdown, false, nil, MarkdownTable), "| #67 | API Endpoint Creation | not planned |\n")
+	})
+}
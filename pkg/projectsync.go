@@ -0,0 +1,697 @@
+// Package pkg provides syncing of blocked state into GitHub Projects.
+//
+// Project boards track work in a status field that knows nothing about
+// blocked-by relationships. Project sync sets that field to a "blocked" value
+// on every item with an open blocker, remembers the value it replaced in a
+// local state file, and puts that value back once the item is unblocked.
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// ProjectSyncStateFileName is the file in ConfigDir remembering the field
+// values project sync replaced
+const ProjectSyncStateFileName = "project-sync.json"
+
+// Project sync actions
+const (
+	ProjectSyncActionBlock   = "block"   // Set the field to the blocked value
+	ProjectSyncActionRestore = "restore" // Put back the value replaced by a block
+)
+
+// StatusPlanned marks a change that a dry run would have made
+const StatusPlanned = "planned"
+
+// projectItemPageSize is how many project items are read per GraphQL query
+const projectItemPageSize = 100
+
+// maxProjectItemPages bounds how many pages of project items are read
+const maxProjectItemPages = 50
+
+// maxProjectBlockers bounds how many blockers are read per project item
+const maxProjectBlockers = 50
+
+// ProjectRef identifies a GitHub project by its owner's login and number
+type ProjectRef struct {
+	Owner  string
+	Number int
+}
+
+// String returns the project as owner/number
+func (p ProjectRef) String() string {
+	return fmt.Sprintf("%s/%d", p.Owner, p.Number)
+}
+
+// projectURLPattern matches https://github.com/orgs/<owner>/projects/<n> and the users/ form
+var projectURLPattern = regexp.MustCompile(`^https?://[^/]+/(?:orgs|users)/([^/]+)/projects/(\d+)`)
+
+// ParseProjectRef parses a project given as owner/number or as a project URL
+func ParseProjectRef(value string) (ProjectRef, error) {
+	value = strings.TrimSpace(value)
+
+	owner, number, ok := strings.Cut(value, "/")
+	if match := projectURLPattern.FindStringSubmatch(value); match != nil {
+		owner, number, ok = match[1], match[2], true
+	}
+
+	n, err := strconv.Atoi(number)
+	if !ok || owner == "" || err != nil || n <= 0 {
+		return ProjectRef{}, NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Invalid project: %s", value),
+			err,
+		).WithSuggestion("Use owner/number, e.g. --project my-org/5").
+			WithSuggestion("Or use the project URL, e.g. https://github.com/orgs/my-org/projects/5")
+	}
+
+	return ProjectRef{Owner: owner, Number: n}, nil
+}
+
+// ProjectSyncOptions configures SyncProject
+type ProjectSyncOptions struct {
+	Project      ProjectRef
+	Field        string // Name of a single-select or text field
+	BlockedValue string // Value the field is set to while an item is blocked
+	DryRun       bool   // Report the changes without making them
+}
+
+// ProjectSyncChange is a field change made, or planned, for one project item
+type ProjectSyncChange struct {
+	ItemID    string   `json:"item_id"`
+	Issue     string   `json:"issue"` // owner/repo#number
+	Title     string   `json:"title"`
+	Action    string   `json:"action"` // One of the ProjectSyncAction constants
+	From      string   `json:"from"`   // "" when the field was empty
+	To        string   `json:"to"`     // "" clears the field
+	BlockedBy []string `json:"blocked_by,omitempty"`
+	Status    string   `json:"status"` // StatusApplied, StatusFailed, or StatusPlanned
+	Error     string   `json:"error,omitempty"`
+}
+
+// ProjectSyncReport describes the outcome of a project sync
+type ProjectSyncReport struct {
+	Project      string              `json:"project"` // owner/number
+	Title        string              `json:"title"`
+	Field        string              `json:"field"`
+	BlockedValue string              `json:"blocked_value"`
+	DryRun       bool                `json:"dry_run"`
+	ItemsChecked int                 `json:"items_checked"` // Issues on the project
+	Blocked      int                 `json:"blocked"`       // Issues with at least one open blocker
+	Changes      []ProjectSyncChange `json:"changes"`
+}
+
+// Failed returns how many changes could not be made
+func (r *ProjectSyncReport) Failed() int {
+	failed := 0
+	for _, change := range r.Changes {
+		if change.Status == StatusFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// projectField is the synced field of a project
+type projectField struct {
+	ID       string
+	Name     string
+	DataType string            // SINGLE_SELECT or TEXT
+	Options  map[string]string // Single-select option IDs by lower-cased name
+}
+
+// projectInfo is the project being synced
+type projectInfo struct {
+	Ref   ProjectRef
+	ID    string
+	Title string
+	Field projectField
+}
+
+// projectItem is an issue on the project with its field value and open blockers
+type projectItem struct {
+	ID        string
+	Issue     string
+	Title     string
+	Value     string
+	BlockedBy []string
+}
+
+// projectSyncState maps a project and field to the values replaced by blocks,
+// keyed by project item ID
+type projectSyncState map[string]map[string]string
+
+// projectSyncStateKey identifies a synced project field in the state file
+func projectSyncStateKey(opts ProjectSyncOptions) string {
+	return fmt.Sprintf("%s:%s", strings.ToLower(opts.Project.String()), strings.ToLower(opts.Field))
+}
+
+// ProjectSyncStatePath returns the location of the project sync state file
+func ProjectSyncStatePath() string {
+	return filepath.Join(ConfigDir(), ProjectSyncStateFileName)
+}
+
+// SyncProject sets the field named by opts on every blocked issue in the
+// project and restores the replaced value on issues that are no longer blocked
+func SyncProject(ctx context.Context, opts ProjectSyncOptions) (*ProjectSyncReport, error) {
+	if err := SetupGitHubClient(); err != nil {
+		return nil, err
+	}
+
+	client, err := api.DefaultGraphQLClient()
+	if err != nil {
+		return nil, WrapInternalError("creating GitHub GraphQL client", err)
+	}
+
+	return syncProject(ctx, client, opts, ProjectSyncStatePath())
+}
+
+// syncProject implements SyncProject with an explicit client and state file
+func syncProject(ctx context.Context, client *api.GraphQLClient, opts ProjectSyncOptions, statePath string) (*ProjectSyncReport, error) {
+	if strings.TrimSpace(opts.Field) == "" {
+		return nil, NewEmptyValueError("--field")
+	}
+	if strings.TrimSpace(opts.BlockedValue) == "" {
+		return nil, NewEmptyValueError("--blocked-value")
+	}
+
+	project, err := fetchProject(ctx, client, opts)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := project.Field.input(opts.BlockedValue); err != nil {
+		return nil, err
+	}
+
+	items, complete, err := fetchProjectItems(ctx, client, project.ID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := readProjectSyncState(statePath)
+	if err != nil {
+		return nil, err
+	}
+	key := projectSyncStateKey(opts)
+	previous := state[key]
+	if previous == nil {
+		previous = make(map[string]string)
+	}
+
+	report := &ProjectSyncReport{
+		Project:      opts.Project.String(),
+		Title:        project.Title,
+		Field:        project.Field.Name,
+		BlockedValue: opts.BlockedValue,
+		DryRun:       opts.DryRun,
+		ItemsChecked: len(items),
+		Changes:      []ProjectSyncChange{},
+	}
+
+	seen := make(map[string]bool)
+	for _, item := range items {
+		seen[item.ID] = true
+		if len(item.BlockedBy) > 0 {
+			report.Blocked++
+		}
+
+		change, ok := planProjectSyncChange(item, opts.BlockedValue, previous)
+		if !ok {
+			continue
+		}
+
+		if opts.DryRun {
+			change.Status = StatusPlanned
+		} else if err := updateProjectField(ctx, client, project, item.ID, change.To); err != nil {
+			change.Status = StatusFailed
+			change.Error = err.Error()
+		} else {
+			change.Status = StatusApplied
+			if change.Action == ProjectSyncActionBlock {
+				previous[item.ID] = change.From
+			} else {
+				delete(previous, item.ID)
+			}
+		}
+
+		report.Changes = append(report.Changes, change)
+	}
+
+	if opts.DryRun {
+		return report, nil
+	}
+
+	// Forget items that left the project. Items past the page limit were not
+	// read, so nothing is forgotten unless every item was.
+	if complete {
+		for itemID := range previous {
+			if !seen[itemID] {
+				delete(previous, itemID)
+			}
+		}
+	}
+	if len(previous) == 0 {
+		delete(state, key)
+	} else {
+		state[key] = previous
+	}
+
+	if err := writeProjectSyncState(statePath, state); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// planProjectSyncChange decides what to do with item. A blocked item gets the
+// blocked value. An unblocked item gets back the value recorded in previous,
+// but only while it still has the blocked value; if someone moved it since,
+// the record is dropped and the item is left alone.
+func planProjectSyncChange(item projectItem, blockedValue string, previous map[string]string) (ProjectSyncChange, bool) {
+	change := ProjectSyncChange{
+		ItemID:    item.ID,
+		Issue:     item.Issue,
+		Title:     item.Title,
+		From:      item.Value,
+		BlockedBy: item.BlockedBy,
+	}
+	isBlockedValue := strings.EqualFold(item.Value, blockedValue)
+
+	if len(item.BlockedBy) > 0 {
+		if isBlockedValue {
+			return change, false
+		}
+		change.Action = ProjectSyncActionBlock
+		change.To = blockedValue
+		return change, true
+	}
+
+	prior, ok := previous[item.ID]
+	if !ok {
+		return change, false
+	}
+	if !isBlockedValue {
+		delete(previous, item.ID)
+		return change, false
+	}
+
+	change.Action = ProjectSyncActionRestore
+	change.To = prior
+	return change, true
+}
+
+// fetchProject reads the project and the field to sync
+func fetchProject(ctx context.Context, client *api.GraphQLClient, opts ProjectSyncOptions) (*projectInfo, error) {
+	query := `query ProjectSyncProject($owner: String!, $number: Int!, $field: String!) {
+  repositoryOwner(login: $owner) {
+    ... on ProjectV2Owner {
+      projectV2(number: $number) {
+        id
+        title
+        field(name: $field) {
+          ... on ProjectV2FieldCommon { id name dataType }
+          ... on ProjectV2SingleSelectField { options { id name } }
+        }
+      }
+    }
+  }
+}`
+
+	var response struct {
+		RepositoryOwner *struct {
+			ProjectV2 *struct {
+				ID    string `json:"id"`
+				Title string `json:"title"`
+				Field *struct {
+					ID       string `json:"id"`
+					Name     string `json:"name"`
+					DataType string `json:"dataType"`
+					Options  []struct {
+						ID   string `json:"id"`
+						Name string `json:"name"`
+					} `json:"options"`
+				} `json:"field"`
+			} `json:"projectV2"`
+		} `json:"repositoryOwner"`
+	}
+
+	variables := map[string]interface{}{
+		"owner":  opts.Project.Owner,
+		"number": opts.Project.Number,
+		"field":  opts.Field,
+	}
+	if err := client.DoWithContext(ctx, query, variables, &response); err != nil && !isPartialGraphQLError(err) {
+		return nil, wrapProjectAPIError(opts.Project, "reading project", err)
+	}
+
+	if response.RepositoryOwner == nil || response.RepositoryOwner.ProjectV2 == nil {
+		return nil, NewAppError(
+			ErrorTypeRepository,
+			fmt.Sprintf("Project not found: %s", opts.Project),
+			nil,
+		).WithContext("project", opts.Project.String()).
+			WithSuggestion("Check the owner and project number in the project URL").
+			WithSuggestion("Make sure your token can read projects: gh auth refresh -s project")
+	}
+
+	project := response.RepositoryOwner.ProjectV2
+	if project.Field == nil || project.Field.ID == "" {
+		return nil, NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Project %s has no field named %q", opts.Project, opts.Field),
+			nil,
+		).WithSuggestion("Field names are case-sensitive; check the project's field settings")
+	}
+
+	field := projectField{
+		ID:       project.Field.ID,
+		Name:     project.Field.Name,
+		DataType: project.Field.DataType,
+		Options:  make(map[string]string),
+	}
+	for _, option := range project.Field.Options {
+		field.Options[strings.ToLower(option.Name)] = option.ID
+	}
+
+	if field.DataType != "SINGLE_SELECT" && field.DataType != "TEXT" {
+		return nil, NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Field %q is not a single-select or text field", field.Name),
+			nil,
+		).WithContext("data_type", field.DataType).
+			WithSuggestion("Choose a single-select field such as Status, or a text field")
+	}
+
+	return &projectInfo{Ref: opts.Project, ID: project.ID, Title: project.Title, Field: field}, nil
+}
+
+// fetchProjectItems reads every issue on the project with its value for the
+// synced field and its open blockers. Draft issues and pull requests are skipped.
+// complete is false when reading stopped at maxProjectItemPages with items left.
+func fetchProjectItems(ctx context.Context, client *api.GraphQLClient, projectID string, opts ProjectSyncOptions) (items []projectItem, complete bool, err error) {
+	query := fmt.Sprintf(`query ProjectSyncItems($id: ID!, $field: String!, $cursor: String) {
+  node(id: $id) {
+    ... on ProjectV2 {
+      items(first: %d, after: $cursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          id
+          fieldValueByName(name: $field) {
+            ... on ProjectV2ItemFieldSingleSelectValue { name }
+            ... on ProjectV2ItemFieldTextValue { text }
+          }
+          content {
+            ... on Issue {
+              number
+              title
+              repository { nameWithOwner }
+              blockedBy(first: %d) { nodes { number state repository { nameWithOwner } } }
+            }
+          }
+        }
+      }
+    }
+  }
+}`, projectItemPageSize, maxProjectBlockers)
+
+	var cursor *string
+
+	for page := 0; page < maxProjectItemPages; page++ {
+		var response struct {
+			Node *struct {
+				Items struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						ID         string `json:"id"`
+						FieldValue *struct {
+							Name string `json:"name"`
+							Text string `json:"text"`
+						} `json:"fieldValueByName"`
+						Content *struct {
+							Number     int    `json:"number"`
+							Title      string `json:"title"`
+							Repository struct {
+								NameWithOwner string `json:"nameWithOwner"`
+							} `json:"repository"`
+							BlockedBy struct {
+								Nodes []struct {
+									Number     int    `json:"number"`
+									State      string `json:"state"`
+									Repository struct {
+										NameWithOwner string `json:"nameWithOwner"`
+									} `json:"repository"`
+								} `json:"nodes"`
+							} `json:"blockedBy"`
+						} `json:"content"`
+					} `json:"nodes"`
+				} `json:"items"`
+			} `json:"node"`
+		}
+
+		variables := map[string]interface{}{"id": projectID, "field": opts.Field, "cursor": cursor}
+		if err := client.DoWithContext(ctx, query, variables, &response); err != nil && !isPartialGraphQLError(err) {
+			return nil, false, wrapProjectAPIError(opts.Project, "reading project items", err)
+		}
+		if response.Node == nil {
+			return items, false, nil
+		}
+
+		for _, node := range response.Node.Items.Nodes {
+			if node.Content == nil || node.Content.Number == 0 {
+				continue
+			}
+
+			item := projectItem{
+				ID:    node.ID,
+				Issue: fmt.Sprintf("%s#%d", node.Content.Repository.NameWithOwner, node.Content.Number),
+				Title: node.Content.Title,
+			}
+			if node.FieldValue != nil {
+				item.Value = node.FieldValue.Name + node.FieldValue.Text
+			}
+			for _, blocker := range node.Content.BlockedBy.Nodes {
+				if strings.EqualFold(blocker.State, "open") {
+					item.BlockedBy = append(item.BlockedBy, fmt.Sprintf("%s#%d", blocker.Repository.NameWithOwner, blocker.Number))
+				}
+			}
+			items = append(items, item)
+		}
+
+		if !response.Node.Items.PageInfo.HasNextPage {
+			return items, true, nil
+		}
+		endCursor := response.Node.Items.PageInfo.EndCursor
+		cursor = &endCursor
+	}
+
+	return items, false, nil
+}
+
+// input returns the mutation value that sets the field to value
+func (f projectField) input(value string) (map[string]interface{}, error) {
+	if f.DataType == "TEXT" {
+		return map[string]interface{}{"text": value}, nil
+	}
+
+	optionID, ok := f.Options[strings.ToLower(value)]
+	if !ok {
+		names := make([]string, 0, len(f.Options))
+		for name := range f.Options {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return nil, NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Field %q has no option %q", f.Name, value),
+			nil,
+		).WithContext("options", strings.Join(names, ", ")).
+			WithSuggestion("Use one of the field's existing options")
+	}
+	return map[string]interface{}{"singleSelectOptionId": optionID}, nil
+}
+
+// updateProjectField sets the project field on an item, clearing it when value is empty
+func updateProjectField(ctx context.Context, client *api.GraphQLClient, project *projectInfo, itemID, value string) error {
+	variables := map[string]interface{}{
+		"project": project.ID,
+		"item":    itemID,
+		"field":   project.Field.ID,
+	}
+
+	query := `mutation ProjectSyncClear($project: ID!, $item: ID!, $field: ID!) {
+  clearProjectV2ItemFieldValue(input: {projectId: $project, itemId: $item, fieldId: $field}) { projectV2Item { id } }
+}`
+	if value != "" {
+		input, err := project.Field.input(value)
+		if err != nil {
+			return err
+		}
+		variables["value"] = input
+		query = `mutation ProjectSyncUpdate($project: ID!, $item: ID!, $field: ID!, $value: ProjectV2FieldValue!) {
+  updateProjectV2ItemFieldValue(input: {projectId: $project, itemId: $item, fieldId: $field, value: $value}) { projectV2Item { id } }
+}`
+	}
+
+	var response struct{}
+	if err := client.DoWithContext(ctx, query, variables, &response); err != nil {
+		return wrapProjectAPIError(project.Ref, "updating project field", err)
+	}
+	return nil
+}
+
+// readProjectSyncState loads the state file; a missing file is treated as empty
+func readProjectSyncState(path string) (projectSyncState, error) {
+	state := make(projectSyncState)
+
+	content, err := os.ReadFile(path) // #nosec G304 -- path is derived from ConfigDir
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, WrapInternalError("reading project sync state", err)
+	}
+
+	if err := json.Unmarshal(content, &state); err != nil {
+		return nil, NewAppError(
+			ErrorTypeInternal,
+			"Project sync state file is corrupt",
+			err,
+		).WithContext("path", path).
+			WithSuggestion("Remove the file to start over; blocked items will then keep their blocked value when unblocked")
+	}
+	return state, nil
+}
+
+// writeProjectSyncState replaces the state file, writing to a temporary file
+// first so an interrupted write never leaves it truncated
+func writeProjectSyncState(path string, state projectSyncState) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return WrapInternalError("writing project sync state", err)
+	}
+
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return WrapInternalError("writing project sync state", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(content, '\n'), 0600); err != nil {
+		return WrapInternalError("writing project sync state", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return WrapInternalError("writing project sync state", err)
+	}
+	return nil
+}
+
+// wrapProjectAPIError converts a Projects API error into an AppError. Tokens
+// without the project scope are the most common failure.
+func wrapProjectAPIError(project ProjectRef, operation string, err error) error {
+	var gqlErr *api.GraphQLError
+	if errors.As(err, &gqlErr) {
+		for _, item := range gqlErr.Errors {
+			if item.Type == "INSUFFICIENT_SCOPES" {
+				return NewAppError(
+					ErrorTypePermission,
+					"Your token cannot access GitHub Projects",
+					err,
+				).WithSuggestion("Add the project scope: gh auth refresh -s project")
+			}
+		}
+	}
+
+	lower := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(lower, "forbidden") || strings.Contains(lower, "does not have permission"):
+		return NewAppError(
+			ErrorTypePermission,
+			fmt.Sprintf("Permission denied for project %s", project),
+			err,
+		).WithSuggestion("Make sure you have write access to the project").
+			WithSuggestion("Add the project scope if needed: gh auth refresh -s project")
+	case strings.Contains(lower, "unauthorized"):
+		return WrapAuthError(err)
+	case strings.Contains(lower, "rate limit"):
+		return WrapAPIError(429, err)
+	default:
+		return WrapInternalError(operation, err)
+	}
+}
+
+// FormatProjectSyncReport writes report as a summary line and one line per change
+func FormatProjectSyncReport(w io.Writer, report *ProjectSyncReport) error {
+	fmt.Fprintf(w, "Project %s (%s): %d issues checked, %d blocked\n",
+		report.Project, report.Title, report.ItemsChecked, report.Blocked)
+
+	if len(report.Changes) == 0 {
+		fmt.Fprintf(w, "%s is up to date\n", report.Field)
+		return nil
+	}
+
+	verb := "Updated"
+	if report.DryRun {
+		verb = "Would update"
+	}
+	fmt.Fprintf(w, "%s %s on %d issues:\n", verb, report.Field, len(report.Changes))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, change := range report.Changes {
+		detail := ""
+		switch {
+		case change.Status == StatusFailed:
+			detail = "failed: " + change.Error
+		case change.Action == ProjectSyncActionBlock:
+			detail = "blocked by " + strings.Join(change.BlockedBy, ", ")
+		default:
+			detail = "unblocked"
+		}
+
+		fmt.Fprintf(tw, "  %s\t%s\t%s → %s\t%s\n",
+			projectSyncSymbol(change), change.Issue, displayFieldValue(change.From), displayFieldValue(change.To), detail)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if report.DryRun {
+		fmt.Fprintln(w, "\nNo changes made. Remove --dry-run to apply them.")
+	}
+	return nil
+}
+
+// projectSyncSymbol returns the marker for a change line
+func projectSyncSymbol(change ProjectSyncChange) string {
+	switch {
+	case change.Status == StatusFailed:
+		return "❌"
+	case change.Action == ProjectSyncActionBlock:
+		return "⛔"
+	default:
+		return "↩️"
+	}
+}
+
+// displayFieldValue shows an empty field value as "(empty)"
+func displayFieldValue(value string) string {
+	if value == "" {
+		return "(empty)"
+	}
+	return value
+}
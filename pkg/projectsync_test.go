@@ -0,0 +1,275 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// projectSyncItemsResponse is a project with four issues: #1 is blocked by an
+// open issue, #2 only by a closed one, #3 is still marked blocked but no longer
+// is, and #4 was moved on by hand after being blocked. A draft issue is skipped.
+const projectSyncItemsResponse = `{"data": {"node": {"items": {
+	"pageInfo": {"hasNextPage": false, "endCursor": "c1"},
+	"nodes": [
+		{"id": "ITEM_1", "fieldValueByName": {"name": "Todo"}, "content": {"number": 1, "title": "One",
+			"repository": {"nameWithOwner": "org/app"},
+			"blockedBy": {"nodes": [{"number": 9, "state": "OPEN", "repository": {"nameWithOwner": "org/api"}}]}}},
+		{"id": "ITEM_2", "fieldValueByName": {"name": "In Progress"}, "content": {"number": 2, "title": "Two",
+			"repository": {"nameWithOwner": "org/app"},
+			"blockedBy": {"nodes": [{"number": 8, "state": "CLOSED", "repository": {"nameWithOwner": "org/app"}}]}}},
+		{"id": "ITEM_3", "fieldValueByName": {"name": "Blocked"}, "content": {"number": 3, "title": "Three",
+			"repository": {"nameWithOwner": "org/app"}, "blockedBy": {"nodes": []}}},
+		{"id": "ITEM_4", "fieldValueByName": {"name": "Done"}, "content": {"number": 4, "title": "Four",
+			"repository": {"nameWithOwner": "org/app"}, "blockedBy": {"nodes": []}}},
+		{"id": "ITEM_DRAFT", "fieldValueByName": null, "content": {}}
+	]
+}}}}`
+
+// projectSyncServer fakes the Projects GraphQL API and records mutations
+type projectSyncServer struct {
+	fieldType string
+	truncated bool // Report more items after every page, past the page limit
+	mutations []map[string]interface{}
+}
+
+func (s *projectSyncServer) client(t *testing.T) *api.GraphQLClient {
+	return newFakeGraphQLClient(t, func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		var request struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.Unmarshal(body, &request))
+
+		switch {
+		case strings.Contains(request.Query, "query ProjectSyncProject"):
+			return jsonResponse(req, 200, `{"data": {"repositoryOwner": {"projectV2": {"id": "PROJECT", "title": "Roadmap",
+				"field": {"id": "FIELD", "name": "Status", "dataType": "`+s.fieldType+`",
+					"options": [{"id": "OPT_TODO", "name": "Todo"}, {"id": "OPT_BLOCKED", "name": "Blocked"},
+						{"id": "OPT_PROGRESS", "name": "In Progress"}]}}}}}`), nil
+		case strings.Contains(request.Query, "query ProjectSyncItems"):
+			if s.truncated && request.Variables["cursor"] != nil {
+				return jsonResponse(req, 200, `{"data": {"node": {"items": {
+					"pageInfo": {"hasNextPage": true, "endCursor": "more"}, "nodes": []}}}}`), nil
+			}
+			if s.truncated {
+				return jsonResponse(req, 200, strings.Replace(projectSyncItemsResponse,
+					`"hasNextPage": false`, `"hasNextPage": true`, 1)), nil
+			}
+			return jsonResponse(req, 200, projectSyncItemsResponse), nil
+		default:
+			s.mutations = append(s.mutations, request.Variables)
+			return jsonResponse(req, 200, `{"data": {}}`), nil
+		}
+	})
+}
+
+// writeTestProjectSyncState writes the state recorded by an earlier sync
+func writeTestProjectSyncState(t *testing.T, previous map[string]string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ProjectSyncStateFileName)
+	require.NoError(t, writeProjectSyncState(path, projectSyncState{"org/5:status": previous}))
+	return path
+}
+
+func testProjectSyncOptions(dryRun bool) ProjectSyncOptions {
+	return ProjectSyncOptions{
+		Project:      ProjectRef{Owner: "org", Number: 5},
+		Field:        "Status",
+		BlockedValue: "Blocked",
+		DryRun:       dryRun,
+	}
+}
+
+func TestSyncProject(t *testing.T) {
+	server := &projectSyncServer{fieldType: "SINGLE_SELECT"}
+	statePath := writeTestProjectSyncState(t, map[string]string{
+		"ITEM_3":    "In Progress",
+		"ITEM_4":    "Todo",
+		"ITEM_GONE": "Todo",
+	})
+
+	report, err := syncProject(context.Background(), server.client(t), testProjectSyncOptions(false), statePath)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, report.ItemsChecked)
+	assert.Equal(t, 1, report.Blocked)
+	require.Len(t, report.Changes, 2)
+
+	block := report.Changes[0]
+	assert.Equal(t, "org/app#1", block.Issue)
+	assert.Equal(t, ProjectSyncActionBlock, block.Action)
+	assert.Equal(t, "Todo", block.From)
+	assert.Equal(t, "Blocked", block.To)
+	assert.Equal(t, []string{"org/api#9"}, block.BlockedBy)
+	assert.Equal(t, StatusApplied, block.Status)
+
+	restore := report.Changes[1]
+	assert.Equal(t, "org/app#3", restore.Issue)
+	assert.Equal(t, ProjectSyncActionRestore, restore.Action)
+	assert.Equal(t, "In Progress", restore.To)
+
+	require.Len(t, server.mutations, 2)
+	assert.Equal(t, map[string]interface{}{"singleSelectOptionId": "OPT_BLOCKED"}, server.mutations[0]["value"])
+	assert.Equal(t, map[string]interface{}{"singleSelectOptionId": "OPT_PROGRESS"}, server.mutations[1]["value"])
+
+	// #1 is remembered; #3 was restored, #4 moved on by hand, and the last item left the project
+	state, err := readProjectSyncState(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, projectSyncState{"org/5:status": {"ITEM_1": "Todo"}}, state)
+}
+
+func TestSyncProjectTruncatedItems(t *testing.T) {
+	server := &projectSyncServer{fieldType: "SINGLE_SELECT", truncated: true}
+	statePath := writeTestProjectSyncState(t, map[string]string{
+		"ITEM_3":      "In Progress",
+		"ITEM_UNREAD": "Todo",
+	})
+
+	report, err := syncProject(context.Background(), server.client(t), testProjectSyncOptions(false), statePath)
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.ItemsChecked)
+
+	// Items past the page limit may still be on the project, so their saved
+	// values are kept
+	state, err := readProjectSyncState(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, projectSyncState{"org/5:status": {"ITEM_1": "Todo", "ITEM_UNREAD": "Todo"}}, state)
+}
+
+func TestSyncProjectDryRun(t *testing.T) {
+	server := &projectSyncServer{fieldType: "SINGLE_SELECT"}
+	statePath := writeTestProjectSyncState(t, map[string]string{"ITEM_3": "In Progress"})
+	before, err := os.ReadFile(statePath)
+	require.NoError(t, err)
+
+	report, err := syncProject(context.Background(), server.client(t), testProjectSyncOptions(true), statePath)
+	require.NoError(t, err)
+
+	require.Len(t, report.Changes, 2)
+	assert.Equal(t, StatusPlanned, report.Changes[0].Status)
+	assert.Empty(t, server.mutations)
+
+	after, err := os.ReadFile(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, string(before), string(after))
+
+	var buf bytes.Buffer
+	require.NoError(t, FormatProjectSyncReport(&buf, report))
+	output := buf.String()
+	assert.Contains(t, output, "Project org/5 (Roadmap): 4 issues checked, 1 blocked")
+	assert.Contains(t, output, "Would update Status on 2 issues:")
+	assert.Contains(t, output, "Todo → Blocked")
+	assert.Contains(t, output, "blocked by org/api#9")
+	assert.Contains(t, output, "No changes made.")
+}
+
+func TestSyncProjectTextField(t *testing.T) {
+	server := &projectSyncServer{fieldType: "TEXT"}
+	statePath := writeTestProjectSyncState(t, map[string]string{"ITEM_3": ""})
+
+	report, err := syncProject(context.Background(), server.client(t), testProjectSyncOptions(false), statePath)
+	require.NoError(t, err)
+	require.Len(t, report.Changes, 2)
+
+	// Text fields are set directly, and an empty previous value clears the field
+	assert.Equal(t, map[string]interface{}{"text": "Blocked"}, server.mutations[0]["value"])
+	assert.NotContains(t, server.mutations[1], "value")
+	assert.Equal(t, "(empty)", displayFieldValue(report.Changes[1].To))
+}
+
+func TestSyncProjectValidation(t *testing.T) {
+	server := &projectSyncServer{fieldType: "SINGLE_SELECT"}
+	statePath := filepath.Join(t.TempDir(), ProjectSyncStateFileName)
+
+	opts := testProjectSyncOptions(false)
+	opts.BlockedValue = "Stuck"
+	_, err := syncProject(context.Background(), server.client(t), opts, statePath)
+	require.Error(t, err)
+	assert.True(t, IsErrorType(err, ErrorTypeValidation))
+	assert.Contains(t, err.Error(), `has no option "Stuck"`)
+
+	server.fieldType = "ITERATION"
+	_, err = syncProject(context.Background(), server.client(t), testProjectSyncOptions(false), statePath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a single-select or text field")
+
+	_, statErr := os.Stat(statePath)
+	assert.True(t, os.IsNotExist(statErr), "validation failures write no state")
+}
+
+func TestSyncProjectErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		errType  ErrorType
+		message  string
+	}{
+		{
+			name:     "project not found",
+			response: `{"data": {"repositoryOwner": {"projectV2": null}}, "errors": [{"type": "NOT_FOUND", "message": "Could not resolve to a ProjectV2"}]}`,
+			errType:  ErrorTypeRepository,
+			message:  "Project not found: org/5",
+		},
+		{
+			name:     "missing project scope",
+			response: `{"data": null, "errors": [{"type": "INSUFFICIENT_SCOPES", "message": "requires one of the following scopes: ['read:project']"}]}`,
+			errType:  ErrorTypePermission,
+			message:  "cannot access GitHub Projects",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeGraphQLClient(t, func(req *http.Request) (*http.Response, error) {
+				return jsonResponse(req, 200, tt.response), nil
+			})
+
+			_, err := syncProject(context.Background(), client, testProjectSyncOptions(false), filepath.Join(t.TempDir(), "state.json"))
+			require.Error(t, err)
+			assert.True(t, IsErrorType(err, tt.errType))
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+func TestParseProjectRef(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected ProjectRef
+		wantErr  bool
+	}{
+		{"org/5", ProjectRef{Owner: "org", Number: 5}, false},
+		{"https://github.com/orgs/my-org/projects/12", ProjectRef{Owner: "my-org", Number: 12}, false},
+		{"https://github.com/users/octocat/projects/3/views/1", ProjectRef{Owner: "octocat", Number: 3}, false},
+		{"org", ProjectRef{}, true},
+		{"org/x", ProjectRef{}, true},
+		{"/5", ProjectRef{}, true},
+		{"org/0", ProjectRef{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			ref, err := ParseProjectRef(tt.value)
+			if tt.wantErr {
+				assert.True(t, IsErrorType(err, ErrorTypeValidation))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ref)
+		})
+	}
+}
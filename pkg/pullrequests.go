@@ -0,0 +1,267 @@
+// Package pkg provides linked pull request status for dependency relations.
+//
+// A blocker with an open, approved pull request is much closer to done than one
+// nobody has started. GitHub links a pull request to the issues it will close,
+// and GraphQL exposes those links as closedByPullRequestsReferences, so the
+// pull requests for many issues can be read in a few batched queries.
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// Pull request statuses, from furthest along to least
+const (
+	PRStatusMerged           = "merged"
+	PRStatusApproved         = "approved"
+	PRStatusChangesRequested = "changes_requested"
+	PRStatusReviewRequired   = "review_required"
+	PRStatusOpen             = "open"
+	PRStatusDraft            = "draft"
+	PRStatusClosed           = "closed"
+)
+
+// Combined CI check states of a pull request's latest commit
+const (
+	ChecksPassing = "passing"
+	ChecksFailing = "failing"
+	ChecksPending = "pending"
+)
+
+// linkedPRBatchSize is how many issues are looked up per GraphQL query
+const linkedPRBatchSize = 25
+
+// maxLinkedPRs bounds how many linked pull requests are read per issue
+const maxLinkedPRs = 10
+
+// LinkedPullRequest is a pull request that will close a related issue when merged
+type LinkedPullRequest struct {
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	Repository string `json:"repository"`
+	Status     string `json:"status"`           // One of the PRStatus constants
+	Checks     string `json:"checks,omitempty"` // One of the Checks constants, or "" without CI
+}
+
+// prStatusOrder ranks statuses so the pull request closest to done sorts first
+var prStatusOrder = map[string]int{
+	PRStatusMerged:           0,
+	PRStatusApproved:         1,
+	PRStatusChangesRequested: 2,
+	PRStatusReviewRequired:   3,
+	PRStatusOpen:             4,
+	PRStatusDraft:            5,
+	PRStatusClosed:           6,
+}
+
+// linkedPRNode is a pull request in the GraphQL response
+type linkedPRNode struct {
+	Number         int    `json:"number"`
+	Title          string `json:"title"`
+	URL            string `json:"url"`
+	State          string `json:"state"` // OPEN, CLOSED, or MERGED
+	IsDraft        bool   `json:"isDraft"`
+	ReviewDecision string `json:"reviewDecision"` // APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED, or null
+	Repository     struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"repository"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State string `json:"state"` // SUCCESS, FAILURE, ERROR, PENDING, or EXPECTED
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+// linkedPRIssue is one aliased repository lookup in the GraphQL response
+type linkedPRIssue struct {
+	Issue *struct {
+		ClosedByPullRequestsReferences struct {
+			Nodes []linkedPRNode `json:"nodes"`
+		} `json:"closedByPullRequestsReferences"`
+	} `json:"issue"`
+}
+
+// linkedPRFields selects everything needed to summarize a linked pull request
+const linkedPRFields = `fragment linkedPullRequests on Issue {
+  closedByPullRequestsReferences(first: %d, includeClosedPrs: true) {
+    nodes {
+      number title url state isDraft reviewDecision
+      repository { nameWithOwner }
+      commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+    }
+  }
+}`
+
+// EnrichWithPullRequests sets PullRequests on every blocked-by, blocking, and
+// rolled-up relation in data. Relations without linked pull requests get an
+// empty list, so output can tell them from relations that were not looked up.
+func EnrichWithPullRequests(ctx context.Context, data *DependencyData) error {
+	client, err := api.DefaultGraphQLClient()
+	if err != nil {
+		return WrapInternalError("creating GitHub GraphQL client", err)
+	}
+
+	return enrichWithPullRequests(ctx, client, data)
+}
+
+// enrichWithPullRequests implements EnrichWithPullRequests with an explicit client
+func enrichWithPullRequests(ctx context.Context, client *api.GraphQLClient, data *DependencyData) error {
+	sourceRepo := data.SourceIssue.Repository.String()
+
+	var relations []*DependencyRelation
+	for _, deps := range [][]DependencyRelation{data.BlockedBy, data.Blocking, data.RolledUpBlockedBy} {
+		for i := range deps {
+			relations = append(relations, &deps[i])
+		}
+	}
+
+	for start := 0; start < len(relations); start += linkedPRBatchSize {
+		end := min(start+linkedPRBatchSize, len(relations))
+		if err := fetchLinkedPullRequests(ctx, client, relations[start:end], sourceRepo); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchLinkedPullRequests looks up the linked pull requests of relations in one
+// query. Issues that are missing or not visible are left without pull requests
+// rather than failing the whole batch. Relations without a repository are in sourceRepo.
+func fetchLinkedPullRequests(ctx context.Context, client *api.GraphQLClient, relations []*DependencyRelation, sourceRepo string) error {
+	var params, selections []string
+	variables := make(map[string]interface{})
+
+	for i, relation := range relations {
+		repo := relation.Repository
+		if repo == "" {
+			repo = sourceRepo
+		}
+		owner, name, _ := strings.Cut(repo, "/")
+		variables[fmt.Sprintf("owner%d", i)] = owner
+		variables[fmt.Sprintf("name%d", i)] = name
+		variables[fmt.Sprintf("number%d", i)] = relation.Issue.Number
+
+		params = append(params, fmt.Sprintf("$owner%d: String!, $name%d: String!, $number%d: Int!", i, i, i))
+		selections = append(selections, fmt.Sprintf(
+			"issue%d: repository(owner: $owner%d, name: $name%d) { issue(number: $number%d) { ...linkedPullRequests } }", i, i, i, i))
+	}
+
+	query := fmt.Sprintf("query LinkedPullRequests(%s) {\n%s\n}\n%s",
+		strings.Join(params, ", "), strings.Join(selections, "\n"), fmt.Sprintf(linkedPRFields, maxLinkedPRs))
+
+	response := make(map[string]*linkedPRIssue)
+	if err := client.DoWithContext(ctx, query, variables, &response); err != nil && !isPartialGraphQLError(err) {
+		return wrapHierarchyAPIError(sourceRepo, "fetching linked pull requests", err)
+	}
+
+	for i, relation := range relations {
+		relation.PullRequests = []LinkedPullRequest{}
+
+		result := response[fmt.Sprintf("issue%d", i)]
+		if result == nil || result.Issue == nil {
+			continue
+		}
+		for _, node := range result.Issue.ClosedByPullRequestsReferences.Nodes {
+			relation.PullRequests = append(relation.PullRequests, node.linkedPullRequest())
+		}
+		sortLinkedPullRequests(relation.PullRequests)
+	}
+
+	return nil
+}
+
+// isPartialGraphQLError reports whether err only says some issues were not found
+// or not visible, in which case the rest of the response is still usable
+func isPartialGraphQLError(err error) bool {
+	var gqlErr *api.GraphQLError
+	if !errors.As(err, &gqlErr) {
+		return false
+	}
+	for _, item := range gqlErr.Errors {
+		if item.Type != "NOT_FOUND" && item.Type != "FORBIDDEN" {
+			return false
+		}
+	}
+	return true
+}
+
+// linkedPullRequest summarizes a GraphQL pull request node
+func (n linkedPRNode) linkedPullRequest() LinkedPullRequest {
+	pr := LinkedPullRequest{
+		Number:     n.Number,
+		Title:      n.Title,
+		URL:        n.URL,
+		Repository: n.Repository.NameWithOwner,
+	}
+
+	switch {
+	case n.State == "MERGED":
+		pr.Status = PRStatusMerged
+	case n.State == "CLOSED":
+		pr.Status = PRStatusClosed
+	case n.IsDraft:
+		pr.Status = PRStatusDraft
+	case n.ReviewDecision == "APPROVED":
+		pr.Status = PRStatusApproved
+	case n.ReviewDecision == "CHANGES_REQUESTED":
+		pr.Status = PRStatusChangesRequested
+	case n.ReviewDecision == "REVIEW_REQUIRED":
+		pr.Status = PRStatusReviewRequired
+	default:
+		pr.Status = PRStatusOpen
+	}
+
+	if len(n.Commits.Nodes) > 0 && n.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+		switch n.Commits.Nodes[0].Commit.StatusCheckRollup.State {
+		case "SUCCESS":
+			pr.Checks = ChecksPassing
+		case "FAILURE", "ERROR":
+			pr.Checks = ChecksFailing
+		case "PENDING", "EXPECTED":
+			pr.Checks = ChecksPending
+		}
+	}
+
+	return pr
+}
+
+// sortLinkedPullRequests orders pull requests closest to done first, then by number
+func sortLinkedPullRequests(prs []LinkedPullRequest) {
+	sort.SliceStable(prs, func(i, j int) bool {
+		if prStatusOrder[prs[i].Status] != prStatusOrder[prs[j].Status] {
+			return prStatusOrder[prs[i].Status] < prStatusOrder[prs[j].Status]
+		}
+		return prs[i].Number < prs[j].Number
+	})
+}
+
+// formatLinkedPullRequest describes pr for tables: "#12 approved ✓" in a
+// terminal and "12:approved:passing" in plain output
+func formatLinkedPullRequest(pr LinkedPullRequest, tty bool) string {
+	if !tty {
+		return strings.TrimSuffix(fmt.Sprintf("%d:%s:%s", pr.Number, pr.Status, pr.Checks), ":")
+	}
+
+	text := fmt.Sprintf("#%d %s", pr.Number, strings.ReplaceAll(pr.Status, "_", " "))
+	switch pr.Checks {
+	case ChecksPassing:
+		text += " ✓"
+	case ChecksFailing:
+		text += " ✗"
+	case ChecksPending:
+		text += " …"
+	}
+	return text
+}
@@ -0,0 +1,174 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeGraphQLClient returns a GraphQL client whose requests are served by handler
+func newFakeGraphQLClient(t *testing.T, handler roundTripFunc) *api.GraphQLClient {
+	t.Helper()
+	client, err := api.NewGraphQLClient(api.ClientOptions{
+		Host:         "github.com",
+		AuthToken:    "test-token",
+		Transport:    handler,
+		LogIgnoreEnv: true,
+	})
+	require.NoError(t, err)
+	return client
+}
+
+// linkedPRResponse answers the linked pull request query for the standard test
+// data: #45 has a draft and an approved pull request, #67 has none, and
+// testowner/frontend#89 is not visible
+const linkedPRResponse = `{
+	"data": {
+		"issue0": {"issue": {"closedByPullRequestsReferences": {"nodes": [
+			{"number": 501, "title": "WIP schema", "url": "https://github.com/testowner/testrepo/pull/501",
+			 "state": "OPEN", "isDraft": true, "reviewDecision": null,
+			 "repository": {"nameWithOwner": "testowner/testrepo"}, "commits": {"nodes": []}},
+			{"number": 502, "title": "Add schema", "url": "https://github.com/testowner/testrepo/pull/502",
+			 "state": "OPEN", "isDraft": false, "reviewDecision": "APPROVED",
+			 "repository": {"nameWithOwner": "testowner/testrepo"},
+			 "commits": {"nodes": [{"commit": {"statusCheckRollup": {"state": "SUCCESS"}}}]}}
+		]}}},
+		"issue1": {"issue": {"closedByPullRequestsReferences": {"nodes": []}}},
+		"issue2": null
+	},
+	"errors": [{"type": "NOT_FOUND", "message": "Could not resolve to a Repository", "path": ["issue2"]}]
+}`
+
+func TestEnrichWithPullRequests(t *testing.T) {
+	var request struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	client := newFakeGraphQLClient(t, func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &request))
+		return jsonResponse(req, 200, linkedPRResponse), nil
+	})
+
+	data := createTestDependencyData()
+	require.NoError(t, enrichWithPullRequests(context.Background(), client, data))
+
+	// One batched query covers every related issue
+	assert.Contains(t, request.Query, "issue2: repository(owner: $owner2, name: $name2)")
+	assert.Equal(t, "frontend", request.Variables["name2"])
+	assert.Equal(t, float64(89), request.Variables["number2"])
+
+	// Closest to done first
+	require.Len(t, data.BlockedBy[0].PullRequests, 2)
+	assert.Equal(t, LinkedPullRequest{
+		Number: 502, Title: "Add schema", URL: "https://github.com/testowner/testrepo/pull/502",
+		Repository: "testowner/testrepo", Status: PRStatusApproved, Checks: ChecksPassing,
+	}, data.BlockedBy[0].PullRequests[0])
+	assert.Equal(t, PRStatusDraft, data.BlockedBy[0].PullRequests[1].Status)
+	assert.Empty(t, data.BlockedBy[0].PullRequests[1].Checks)
+
+	// Looked up but none found, including issues that are not visible
+	assert.NotNil(t, data.BlockedBy[1].PullRequests)
+	assert.Empty(t, data.BlockedBy[1].PullRequests)
+	assert.NotNil(t, data.Blocking[0].PullRequests)
+}
+
+func TestEnrichWithPullRequestsErrors(t *testing.T) {
+	client := newFakeGraphQLClient(t, func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, 200, `{"data": null, "errors": [{"type": "RATE_LIMITED", "message": "API rate limit exceeded"}]}`), nil
+	})
+
+	err := enrichWithPullRequests(context.Background(), client, createTestDependencyData())
+	require.Error(t, err)
+	assert.True(t, IsErrorType(err, ErrorTypeAPI))
+
+	// No related issues means no query
+	client = newFakeGraphQLClient(t, func(req *http.Request) (*http.Response, error) {
+		t.Fatal("unexpected request")
+		return nil, nil
+	})
+	assert.NoError(t, enrichWithPullRequests(context.Background(), client, createEmptyDependencyData()))
+}
+
+func TestLinkedPullRequestStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     string
+		status   string
+		checks   string
+		ttyText  string
+		plainTxt string
+	}{
+		{"merged", `{"number": 1, "state": "MERGED", "isDraft": false}`, PRStatusMerged, "", "#1 merged", "1:merged"},
+		{"closed draft", `{"number": 2, "state": "CLOSED", "isDraft": true}`, PRStatusClosed, "", "#2 closed", "2:closed"},
+		{"draft", `{"number": 3, "state": "OPEN", "isDraft": true, "reviewDecision": "APPROVED"}`, PRStatusDraft, "", "#3 draft", "3:draft"},
+		{"changes requested", `{"number": 4, "state": "OPEN", "reviewDecision": "CHANGES_REQUESTED",
+			"commits": {"nodes": [{"commit": {"statusCheckRollup": {"state": "FAILURE"}}}]}}`,
+			PRStatusChangesRequested, ChecksFailing, "#4 changes requested ✗", "4:changes_requested:failing"},
+		{"review required", `{"number": 5, "state": "OPEN", "reviewDecision": "REVIEW_REQUIRED",
+			"commits": {"nodes": [{"commit": {"statusCheckRollup": {"state": "PENDING"}}}]}}`,
+			PRStatusReviewRequired, ChecksPending, "#5 review required …", "5:review_required:pending"},
+		{"open without review", `{"number": 6, "state": "OPEN"}`, PRStatusOpen, "", "#6 open", "6:open"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var node linkedPRNode
+			require.NoError(t, json.Unmarshal([]byte(tt.node), &node))
+
+			pr := node.linkedPullRequest()
+			assert.Equal(t, tt.status, pr.Status)
+			assert.Equal(t, tt.checks, pr.Checks)
+			assert.Equal(t, tt.ttyText, formatLinkedPullRequest(pr, true))
+			assert.Equal(t, tt.plainTxt, formatLinkedPullRequest(pr, false))
+		})
+	}
+}
+
+func TestLinkedPullRequestOutput(t *testing.T) {
+	data := createTestDependencyData()
+	data.BlockedBy[0].PullRequests = []LinkedPullRequest{
+		{Number: 502, Repository: "testowner/testrepo", Status: PRStatusApproved, Checks: ChecksPassing},
+		{Number: 501, Repository: "testowner/testrepo", Status: PRStatusDraft},
+	}
+	data.BlockedBy[1].PullRequests = []LinkedPullRequest{}
+	data.Blocking[0].PullRequests = []LinkedPullRequest{}
+
+	format := func(format OutputFormat) string {
+		var buf bytes.Buffer
+		formatter := NewOutputFormatter(&OutputOptions{Format: format, Writer: &buf, Width: 200, StateFilter: "all"})
+		require.NoError(t, formatter.FormatOutput(data))
+		return buf.String()
+	}
+
+	tty := format(FormatTTY)
+	assert.Contains(t, tty, "PULL REQUESTS")
+	assert.Contains(t, tty, "#502 approved ✓, #501 draft")
+
+	assert.Contains(t, format(FormatPlain), "\t502:approved:passing,501:draft\n")
+
+	var output map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(format(FormatJSON)), &output))
+	first := output["blocked_by"].([]interface{})[0].(map[string]interface{})
+	prs := first["pull_requests"].([]interface{})
+	require.Len(t, prs, 2)
+	assert.Equal(t, "approved", prs[0].(map[string]interface{})["status"])
+	assert.Equal(t, "passing", prs[0].(map[string]interface{})["checks"])
+	second := output["blocked_by"].([]interface{})[1].(map[string]interface{})
+	assert.Equal(t, []interface{}{}, second["pull_requests"])
+
+	// Not looked up: no column and no key
+	var buf bytes.Buffer
+	formatter := NewOutputFormatter(&OutputOptions{Format: FormatJSON, Writer: &buf, StateFilter: "all"})
+	require.NoError(t, formatter.FormatOutput(createTestDependencyData()))
+	assert.False(t, strings.Contains(buf.String(), "pull_requests"))
+}
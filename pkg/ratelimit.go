@@ -0,0 +1,407 @@
+// Package pkg provides rate limit awareness for GitHub API requests.
+//
+// Every API response carries X-RateLimit-* headers describing the budget of
+// its resource: core for REST, graphql, search, and so on. The transport
+// installed by LimitRequests records them and, once a budget runs low,
+// spaces out further requests so what is left lasts until the budget resets.
+// Secondary rate limits are retried after the Retry-After delay GitHub asks
+// for, and an optional cap stops runaway crawls.
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// Rate limit resources reported by GitHub
+const (
+	RateLimitResourceCore    = "core"    // REST API
+	RateLimitResourceGraphQL = "graphql" // GraphQL API
+	RateLimitResourceSearch  = "search"  // REST search endpoints
+)
+
+// RateLimitSlowdownFraction is the share of a budget below which requests
+// are spaced out until the budget resets
+const RateLimitSlowdownFraction = 0.1
+
+// MaxRateLimitDelay is the longest pause between requests while slowing down
+const MaxRateLimitDelay = 30 * time.Second
+
+// MaxRetryAfter is the longest delay waited out before retrying a rate
+// limited request; longer waits fail instead
+const MaxRetryAfter = 2 * time.Minute
+
+// maxRateLimitRetries is how many times a rate limited request is retried
+const maxRateLimitRetries = 3
+
+// RateLimitBudget is the request budget of one rate limit resource
+type RateLimitBudget struct {
+	Resource  string    `json:"resource"`
+	Limit     int       `json:"limit"`
+	Used      int       `json:"used"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// rateLimitTransport records rate limit budgets from responses, spaces out
+// requests when a budget runs low, retries secondary rate limits, and
+// enforces the request cap
+type rateLimitTransport struct {
+	base        http.RoundTripper
+	maxRequests int // Requests allowed; zero for no cap
+
+	mu       sync.Mutex
+	requests int
+	budgets  map[string]RateLimitBudget
+	next     map[string]time.Time // Earliest start of the next request per resource while slowing down
+	warned   map[string]bool      // Resources whose slowdown was already reported
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+	warn  io.Writer
+}
+
+// Transport installed by LimitRequests, shared by every API client
+var (
+	installedRateLimiter   *rateLimitTransport
+	installedRateLimiterMu sync.Mutex
+)
+
+// newRateLimitTransport wraps base with rate limit handling
+func newRateLimitTransport(base http.RoundTripper, maxRequests int) *rateLimitTransport {
+	return &rateLimitTransport{
+		base:        base,
+		maxRequests: maxRequests,
+		budgets:     make(map[string]RateLimitBudget),
+		next:        make(map[string]time.Time),
+		warned:      make(map[string]bool),
+		now:         time.Now,
+		sleep:       sleepContext,
+		warn:        os.Stderr,
+	}
+}
+
+// LimitRequests makes the GitHub API clients created afterwards rate limit
+// aware, and fails requests after the first maxRequests; zero means no cap.
+// It wraps http.DefaultTransport, which every client is built on.
+func LimitRequests(maxRequests int) {
+	installedRateLimiterMu.Lock()
+	defer installedRateLimiterMu.Unlock()
+
+	if installedRateLimiter == nil {
+		installedRateLimiter = newRateLimitTransport(http.DefaultTransport, maxRequests)
+		http.DefaultTransport = installedRateLimiter
+		return
+	}
+
+	installedRateLimiter.mu.Lock()
+	installedRateLimiter.maxRequests = maxRequests
+	installedRateLimiter.mu.Unlock()
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource := requestRateLimitResource(req)
+
+	for attempt := 0; ; attempt++ {
+		if err := t.count(req); err != nil {
+			return nil, err
+		}
+		if delay := t.delay(resource); delay > 0 {
+			if err := t.sleep(req.Context(), delay); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		t.record(resource, resp.Header)
+
+		wait, limited := t.retryAfter(resp)
+		if !limited || attempt >= maxRateLimitRetries || wait > MaxRetryAfter {
+			return resp, nil
+		}
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil // The body cannot be sent again
+		}
+
+		_ = resp.Body.Close()
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+
+		req = req.Clone(req.Context())
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// count takes one request from the cap. Checking the rate limit itself is free.
+func (t *rateLimitTransport) count(req *http.Request) error {
+	if strings.HasSuffix(req.URL.Path, "/rate_limit") {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.maxRequests > 0 && t.requests >= t.maxRequests {
+		return NewAppError(
+			ErrorTypeAPI,
+			fmt.Sprintf("Stopped after %d API requests (--max-requests)", t.maxRequests),
+			nil,
+		).WithSuggestion("Raise --max-requests, or narrow the command to fewer issues")
+	}
+	t.requests++
+	return nil
+}
+
+// delay returns how long to wait before the next request for resource. With
+// plenty of budget left it is zero. Below RateLimitSlowdownFraction, requests
+// are spread evenly over the time until the budget resets, one after another
+// even when made in parallel. An exhausted budget waits for the reset when
+// that is within MaxRetryAfter, and otherwise lets the request fail.
+func (t *rateLimitTransport) delay(resource string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	budget, ok := t.budgets[resource]
+	now := t.now()
+	untilReset := budget.Reset.Sub(now)
+	if !ok || budget.Limit == 0 || untilReset <= 0 ||
+		float64(budget.Remaining) >= RateLimitSlowdownFraction*float64(budget.Limit) {
+		return 0
+	}
+
+	if budget.Remaining == 0 {
+		if untilReset > MaxRetryAfter {
+			return 0
+		}
+		return untilReset
+	}
+
+	if !t.warned[resource] {
+		t.warned[resource] = true
+		fmt.Fprintf(t.warn, "Warning: %d of %d %s API requests left; slowing down until the limit resets at %s\n",
+			budget.Remaining, budget.Limit, resource, budget.Reset.Local().Format("15:04"))
+	}
+
+	spacing := untilReset / time.Duration(budget.Remaining+1)
+	if spacing > MaxRateLimitDelay {
+		spacing = MaxRateLimitDelay
+	}
+	start := t.next[resource]
+	if start.Before(now) {
+		start = now
+	}
+	t.next[resource] = start.Add(spacing)
+	return start.Sub(now)
+}
+
+// record saves the budget reported in response headers
+func (t *rateLimitTransport) record(resource string, header http.Header) {
+	budget, ok := parseRateLimitHeaders(header)
+	if !ok {
+		return
+	}
+	if budget.Resource == "" {
+		budget.Resource = resource
+	}
+
+	t.mu.Lock()
+	t.budgets[budget.Resource] = budget
+	t.mu.Unlock()
+}
+
+// retryAfter reports whether resp is a rate limited response worth retrying
+// and how long to wait first: the Retry-After delay of a secondary rate
+// limit, or the time until an exhausted budget resets
+func (t *rateLimitTransport) retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), t.now()); ok {
+		return wait, true
+	}
+	if budget, ok := parseRateLimitHeaders(resp.Header); ok && budget.Remaining == 0 {
+		return budget.Reset.Sub(t.now()), true
+	}
+	return 0, false
+}
+
+// requestRateLimitResource returns the resource a request is expected to count against
+func requestRateLimitResource(req *http.Request) string {
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/graphql"):
+		return RateLimitResourceGraphQL
+	case strings.Contains(req.URL.Path, "/search/"):
+		return RateLimitResourceSearch
+	default:
+		return RateLimitResourceCore
+	}
+}
+
+// parseRateLimitHeaders reads the X-RateLimit-* headers of a response
+func parseRateLimitHeaders(header http.Header) (RateLimitBudget, bool) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return RateLimitBudget{}, false
+	}
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return RateLimitBudget{}, false
+	}
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return RateLimitBudget{}, false
+	}
+	used, err := strconv.Atoi(header.Get("X-RateLimit-Used"))
+	if err != nil {
+		used = limit - remaining
+	}
+
+	return RateLimitBudget{
+		Resource:  header.Get("X-RateLimit-Resource"),
+		Limit:     limit,
+		Used:      used,
+		Remaining: remaining,
+		Reset:     time.Unix(reset, 0),
+	}, true
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// retryDelay returns how long to wait before retrying after err: the
+// Retry-After delay GitHub sent with it, or attempt times baseDelay
+func retryDelay(err error, attempt int, baseDelay time.Duration) time.Duration {
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) {
+		if wait, ok := parseRetryAfter(httpErr.Headers.Get("Retry-After"), time.Now()); ok && wait <= MaxRetryAfter {
+			return wait
+		}
+	}
+	return time.Duration(attempt) * baseDelay
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// FetchRateLimits returns the current budget of every rate limit resource.
+// Checking the rate limit does not count against it.
+func FetchRateLimits() ([]RateLimitBudget, error) {
+	if err := SetupGitHubClient(); err != nil {
+		return nil, err
+	}
+
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return nil, WrapInternalError("creating GitHub API client", err)
+	}
+	return fetchRateLimits(client)
+}
+
+// fetchRateLimits implements FetchRateLimits with an explicit client
+func fetchRateLimits(client *api.RESTClient) ([]RateLimitBudget, error) {
+	var response struct {
+		Resources map[string]struct {
+			Limit     int   `json:"limit"`
+			Used      int   `json:"used"`
+			Remaining int   `json:"remaining"`
+			Reset     int64 `json:"reset"`
+		} `json:"resources"`
+	}
+	if err := client.Get("rate_limit", &response); err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "unauthorized") {
+			return nil, WrapAuthError(err)
+		}
+		return nil, NewAppError(ErrorTypeAPI, "Failed to fetch rate limits", err)
+	}
+
+	budgets := make([]RateLimitBudget, 0, len(response.Resources))
+	for resource, limit := range response.Resources {
+		budgets = append(budgets, RateLimitBudget{
+			Resource:  resource,
+			Limit:     limit.Limit,
+			Used:      limit.Used,
+			Remaining: limit.Remaining,
+			Reset:     time.Unix(limit.Reset, 0),
+		})
+	}
+	sortRateLimitBudgets(budgets)
+	return budgets, nil
+}
+
+// sortRateLimitBudgets orders budgets core, graphql, then the rest by name
+func sortRateLimitBudgets(budgets []RateLimitBudget) {
+	rank := func(resource string) int {
+		switch resource {
+		case RateLimitResourceCore:
+			return 0
+		case RateLimitResourceGraphQL:
+			return 1
+		default:
+			return 2
+		}
+	}
+	sort.Slice(budgets, func(i, j int) bool {
+		if rank(budgets[i].Resource) != rank(budgets[j].Resource) {
+			return rank(budgets[i].Resource) < rank(budgets[j].Resource)
+		}
+		return budgets[i].Resource < budgets[j].Resource
+	})
+}
+
+// FormatRateLimits writes budgets as a table, with reset times relative to now
+func FormatRateLimits(w io.Writer, budgets []RateLimitBudget, now time.Time) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RESOURCE\tUSED\tREMAINING\tLIMIT\tRESETS")
+	for _, budget := range budgets {
+		resets := "now"
+		if wait := budget.Reset.Sub(now).Round(time.Minute); wait > 0 {
+			resets = fmt.Sprintf("in %s (%s)", strings.TrimSuffix(wait.String(), "0s"), budget.Reset.Local().Format("15:04"))
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%s\n", budget.Resource, budget.Used, budget.Remaining, budget.Limit, resets)
+	}
+	return tw.Flush()
+}
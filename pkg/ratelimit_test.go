@@ -0,0 +1,244 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRateLimitTransport returns a transport over handler with a fixed
+// clock whose sleeps advance the clock and are recorded
+func newTestRateLimitTransport(handler roundTripFunc, maxRequests int) (*rateLimitTransport, *[]time.Duration, *bytes.Buffer) {
+	now := time.Unix(1700000000, 0)
+	var slept []time.Duration
+	var warnings bytes.Buffer
+
+	transport := newRateLimitTransport(handler, maxRequests)
+	transport.now = func() time.Time { return now }
+	transport.sleep = func(ctx context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		now = now.Add(d)
+		return nil
+	}
+	transport.warn = &warnings
+	return transport, &slept, &warnings
+}
+
+// rateLimitResponse builds a response carrying rate limit headers
+func rateLimitResponse(req *http.Request, status, remaining int, reset time.Time) *http.Response {
+	resp := jsonResponse(req, status, `{}`)
+	resp.Header.Set("X-RateLimit-Limit", "5000")
+	resp.Header.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	resp.Header.Set("X-RateLimit-Used", strconv.Itoa(5000-remaining))
+	resp.Header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	resp.Header.Set("X-RateLimit-Resource", "core")
+	return resp
+}
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "5000")
+	header.Set("X-RateLimit-Remaining", "4990")
+	header.Set("X-RateLimit-Reset", "1700000000")
+	header.Set("X-RateLimit-Resource", "graphql")
+
+	budget, ok := parseRateLimitHeaders(header)
+	require.True(t, ok)
+	assert.Equal(t, RateLimitBudget{Resource: "graphql", Limit: 5000, Used: 10, Remaining: 4990, Reset: time.Unix(1700000000, 0)}, budget)
+
+	_, ok = parseRateLimitHeaders(http.Header{})
+	assert.False(t, ok)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"30", 30 * time.Second, true},
+		{"Fri, 01 Mar 2024 12:01:00 GMT", time.Minute, true},
+		{"Fri, 01 Mar 2024 11:00:00 GMT", 0, true},
+		{"", 0, false},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			wait, ok := parseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, wait)
+		})
+	}
+}
+
+func TestRequestRateLimitResource(t *testing.T) {
+	for path, want := range map[string]string{
+		"/graphql":                  RateLimitResourceGraphQL,
+		"/search/issues":            RateLimitResourceSearch,
+		"/repos/o/r/issues/1":       RateLimitResourceCore,
+		"/api/v3/repos/o/r/issues/": RateLimitResourceCore,
+	} {
+		req, err := http.NewRequest(http.MethodGet, "https://api.github.com"+path, nil)
+		require.NoError(t, err)
+		assert.Equal(t, want, requestRateLimitResource(req), path)
+	}
+}
+
+func TestRateLimitTransportSlowsDown(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	reset := start.Add(10 * time.Minute)
+	remaining := 5000
+
+	transport, slept, warnings := newTestRateLimitTransport(func(req *http.Request) (*http.Response, error) {
+		return rateLimitResponse(req, http.StatusOK, remaining, reset), nil
+	}, 0)
+	client := &http.Client{Transport: transport}
+
+	get := func() {
+		resp, err := client.Get("https://api.github.com/repos/o/r/issues/1")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	// Plenty of budget: no waiting
+	get()
+	get()
+	assert.Empty(t, *slept)
+
+	// Below 10%: after the response reporting it, requests are spread over
+	// the time until the reset
+	remaining = 99
+	get()
+	get()
+	get()
+	get()
+	require.Len(t, *slept, 2)
+	assert.Equal(t, 6*time.Second, (*slept)[0]) // 10 minutes over 100 requests
+	assert.Equal(t, 6*time.Second, (*slept)[1])
+	assert.Equal(t, 1, strings.Count(warnings.String(), "Warning: 99 of 5000 core API requests left"))
+
+	// Exhausted with the reset close by: wait for it
+	remaining = 0
+	get()
+	reset = transport.now().Add(time.Minute)
+	get()
+	*slept = nil
+	get()
+	require.Len(t, *slept, 1)
+	assert.InDelta(t, time.Minute.Seconds(), (*slept)[0].Seconds(), 1, "resets are whole seconds")
+
+	// Exhausted with the reset far off: send the request and let it fail
+	reset = transport.now().Add(time.Hour)
+	get()
+	*slept = nil
+	get()
+	assert.Empty(t, *slept)
+}
+
+func TestRateLimitTransportRetryAfter(t *testing.T) {
+	attempts := 0
+	transport, slept, _ := newTestRateLimitTransport(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts < 3 {
+			resp := jsonResponse(req, http.StatusForbidden, `{"message": "You have exceeded a secondary rate limit"}`)
+			resp.Header.Set("Retry-After", "20")
+			return resp, nil
+		}
+		return jsonResponse(req, http.StatusCreated, `{}`), nil
+	}, 0)
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.github.com/repos/o/r/issues/1/dependencies/blocked_by", strings.NewReader(`{"issue_id": 1}`))
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []time.Duration{20 * time.Second, 20 * time.Second}, *slept)
+}
+
+func TestRateLimitTransportGivesUpOnLongWaits(t *testing.T) {
+	attempts := 0
+	transport, slept, _ := newTestRateLimitTransport(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		resp := jsonResponse(req, http.StatusTooManyRequests, `{}`)
+		resp.Header.Set("Retry-After", "3600")
+		return resp, nil
+	}, 0)
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.github.com/repos/o/r/issues/1", nil)
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, 1, attempts)
+	assert.Empty(t, *slept)
+}
+
+func TestRateLimitTransportMaxRequests(t *testing.T) {
+	transport, _, _ := newTestRateLimitTransport(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, http.StatusOK, `{}`), nil
+	}, 2)
+
+	for i, path := range []string{"/repos/o/r/issues/1", "/rate_limit", "/repos/o/r/issues/2", "/repos/o/r/issues/3"} {
+		req, err := http.NewRequest(http.MethodGet, "https://api.github.com"+path, nil)
+		require.NoError(t, err)
+		resp, err := transport.RoundTrip(req)
+		if i < 3 {
+			require.NoError(t, err, path)
+			_ = resp.Body.Close()
+			continue
+		}
+		require.Error(t, err)
+		assert.True(t, IsErrorType(err, ErrorTypeAPI))
+		assert.Contains(t, err.Error(), "Stopped after 2 API requests")
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	httpErr := &api.HTTPError{StatusCode: http.StatusTooManyRequests, Headers: http.Header{"Retry-After": []string{"7"}}}
+	assert.Equal(t, 7*time.Second, retryDelay(WrapAPIError(429, httpErr), 1, time.Second))
+	assert.Equal(t, 2*time.Second, retryDelay(errors.New("connection reset"), 2, time.Second))
+
+	tooLong := &api.HTTPError{StatusCode: http.StatusTooManyRequests, Headers: http.Header{"Retry-After": []string{"3600"}}}
+	assert.Equal(t, 3*time.Second, retryDelay(fmt.Errorf("wrapped: %w", tooLong), 3, time.Second))
+}
+
+func TestFetchRateLimits(t *testing.T) {
+	client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/rate_limit", req.URL.Path)
+		return jsonResponse(req, http.StatusOK, `{"resources": {
+			"search": {"limit": 30, "used": 0, "remaining": 30, "reset": 1700000060},
+			"graphql": {"limit": 5000, "used": 12, "remaining": 4988, "reset": 1700003600},
+			"core": {"limit": 5000, "used": 100, "remaining": 4900, "reset": 1700001800}}}`), nil
+	})
+
+	budgets, err := fetchRateLimits(client)
+	require.NoError(t, err)
+	require.Len(t, budgets, 3)
+	assert.Equal(t, []string{"core", "graphql", "search"}, []string{budgets[0].Resource, budgets[1].Resource, budgets[2].Resource})
+	assert.Equal(t, 4900, budgets[0].Remaining)
+
+	var buf strings.Builder
+	require.NoError(t, FormatRateLimits(&buf, budgets[:2], time.Unix(1700000000, 0)))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "RESOURCE  USED  REMAINING  LIMIT  RESETS", lines[0])
+	assert.Contains(t, lines[1], "core      100   4900       5000   in 30m")
+	assert.Contains(t, lines[2], "graphql   12    4988       5000   in 1h")
+}
@@ -0,0 +1,152 @@
+// Package pkg provides self-contained HTML dependency reports.
+//
+// A report is a single static HTML file with the crawled graph inlined as JSON,
+// so it can be attached to tickets or published without gh or network access.
+// The page renders an SVG graph, a sortable table of blocked issues, and
+// warnings for cycles and stale blockers.
+package pkg
+
+import (
+	_ "embed"
+	"encoding/json"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+)
+
+// DefaultStaleAfter is how long an open blocker can go without updates before
+// the report flags it as stale
+const DefaultStaleAfter = 30 * 24 * time.Hour
+
+//go:embed templates/report.html
+var reportTemplate string
+
+// ReportOptions controls report analysis
+type ReportOptions struct {
+	StaleAfter time.Duration // Open blockers not updated for this long are stale; DefaultStaleAfter when zero
+}
+
+// BlockedIssue is an issue with at least one blocker
+type BlockedIssue struct {
+	GraphNode
+	Blockers     []GraphNode `json:"blockers"`
+	OpenBlockers int         `json:"open_blockers"`
+}
+
+// StaleBlocker is an open blocker that has not been updated recently
+type StaleBlocker struct {
+	GraphNode
+	Blocks   []string `json:"blocks"`
+	IdleDays int      `json:"idle_days"`
+}
+
+// Report is the analysis of a dependency graph rendered by WriteHTMLReport
+type Report struct {
+	Graph         *DependencyGraph `json:"graph"`
+	Blocked       []BlockedIssue   `json:"blocked"`
+	Cycles        [][]string       `json:"cycles"`
+	StaleBlockers []StaleBlocker   `json:"stale_blockers"`
+	StaleDays     int              `json:"stale_days"`
+	GeneratedAt   time.Time        `json:"generated_at"`
+}
+
+// BuildReport analyzes graph for blocked issues, cycles, and stale blockers as of now
+func BuildReport(graph *DependencyGraph, opts ReportOptions, now time.Time) *Report {
+	staleAfter := opts.StaleAfter
+	if staleAfter <= 0 {
+		staleAfter = DefaultStaleAfter
+	}
+
+	report := &Report{
+		Graph:         graph,
+		Blocked:       []BlockedIssue{},
+		Cycles:        graph.FindCycles(),
+		StaleBlockers: []StaleBlocker{},
+		StaleDays:     int(staleAfter / (24 * time.Hour)),
+		GeneratedAt:   now,
+	}
+	if report.Cycles == nil {
+		report.Cycles = [][]string{}
+	}
+
+	// Open issues that each open blocker is holding up
+	holding := make(map[string][]string)
+
+	for _, node := range graph.Nodes {
+		blockerIDs := graph.Blockers(node.ID)
+		if len(blockerIDs) == 0 {
+			continue
+		}
+
+		blocked := BlockedIssue{GraphNode: node}
+		for _, id := range blockerIDs {
+			blocker, _ := graph.Node(id)
+			blocked.Blockers = append(blocked.Blockers, blocker)
+			if blocker.State == "open" {
+				blocked.OpenBlockers++
+				if node.State == "open" {
+					holding[id] = append(holding[id], node.ID)
+				}
+			}
+		}
+		report.Blocked = append(report.Blocked, blocked)
+	}
+
+	for id, blocks := range holding {
+		blocker, _ := graph.Node(id)
+		if blocker.UpdatedAt.IsZero() {
+			continue
+		}
+		idle := now.Sub(blocker.UpdatedAt)
+		if idle < staleAfter {
+			continue
+		}
+		report.StaleBlockers = append(report.StaleBlockers, StaleBlocker{
+			GraphNode: blocker,
+			Blocks:    blocks,
+			IdleDays:  int(idle / (24 * time.Hour)),
+		})
+	}
+
+	// Longest idle first
+	sort.Slice(report.StaleBlockers, func(i, j int) bool {
+		if report.StaleBlockers[i].IdleDays != report.StaleBlockers[j].IdleDays {
+			return report.StaleBlockers[i].IdleDays > report.StaleBlockers[j].IdleDays
+		}
+		return report.StaleBlockers[i].ID < report.StaleBlockers[j].ID
+	})
+
+	return report
+}
+
+// WriteHTMLReport renders report as a self-contained HTML page
+func WriteHTMLReport(w io.Writer, report *Report) error {
+	// json.Marshal escapes <, >, and & so the data cannot close the script element
+	data, err := json.Marshal(report)
+	if err != nil {
+		return WrapInternalError("encoding report data", err)
+	}
+
+	tmpl, err := template.New("report").Funcs(template.FuncMap{
+		"date": func(t time.Time) string {
+			if t.IsZero() {
+				return ""
+			}
+			return t.Format("2006-01-02")
+		},
+	}).Parse(reportTemplate)
+	if err != nil {
+		return WrapInternalError("parsing report template", err)
+	}
+
+	err = tmpl.Execute(w, struct {
+		*Report
+		Data template.JS
+	}{report, template.JS(data)}) // #nosec G203 -- data is JSON with HTML characters escaped
+	if err != nil {
+		return WrapInternalError("rendering report", err)
+	}
+
+	return nil
+}
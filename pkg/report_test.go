@@ -0,0 +1,77 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createReportGraph returns a graph where #1 is blocked by a stale #2 and a closed #3
+func createReportGraph(now time.Time) *DependencyGraph {
+	graph := NewDependencyGraph("owner/repo")
+	blocked := graph.AddIssue("owner/repo", Issue{Number: 1, Title: "Ship <feature>", State: "open",
+		HTMLURL: "https://github.com/owner/repo/issues/1", UpdatedAt: now})
+	stale := graph.AddIssue("owner/repo", Issue{Number: 2, Title: "Stale blocker", State: "open",
+		HTMLURL: "https://github.com/owner/repo/issues/2", UpdatedAt: now.Add(-45 * 24 * time.Hour)})
+	closed := graph.AddIssue("owner/repo", Issue{Number: 3, Title: "Done", State: "closed",
+		HTMLURL: "https://github.com/owner/repo/issues/3", UpdatedAt: now.Add(-90 * 24 * time.Hour)})
+
+	graph.AddEdge(stale, blocked)
+	graph.AddEdge(closed, blocked)
+	return graph
+}
+
+func TestBuildReport(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	report := BuildReport(createReportGraph(now), ReportOptions{}, now)
+
+	assert.Equal(t, 30, report.StaleDays)
+	assert.Empty(t, report.Cycles)
+
+	require.Len(t, report.Blocked, 1)
+	assert.Equal(t, "owner/repo#1", report.Blocked[0].ID)
+	assert.Equal(t, 1, report.Blocked[0].OpenBlockers)
+	assert.Len(t, report.Blocked[0].Blockers, 2)
+
+	// Closed blockers are never stale
+	require.Len(t, report.StaleBlockers, 1)
+	assert.Equal(t, "owner/repo#2", report.StaleBlockers[0].ID)
+	assert.Equal(t, 45, report.StaleBlockers[0].IdleDays)
+	assert.Equal(t, []string{"owner/repo#1"}, report.StaleBlockers[0].Blocks)
+
+	report = BuildReport(createReportGraph(now), ReportOptions{StaleAfter: 60 * 24 * time.Hour}, now)
+	assert.Empty(t, report.StaleBlockers)
+}
+
+func TestWriteHTMLReport(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	graph := createReportGraph(now)
+	graph.AddEdge("owner/repo#1", "owner/repo#2")
+	report := BuildReport(graph, ReportOptions{}, now)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteHTMLReport(&buf, report))
+	html := buf.String()
+
+	assert.Contains(t, html, "<title>Dependency report for owner/repo</title>")
+	assert.Contains(t, html, `<a href="https://github.com/owner/repo/issues/1">owner/repo#1</a>`)
+	assert.Contains(t, html, "Ship &lt;feature&gt;", "titles are HTML escaped")
+	assert.Contains(t, html, "Dependency cycle: owner/repo#1 &rarr; owner/repo#2 &rarr; owner/repo#1")
+	assert.Contains(t, html, "has not been updated in 45 days")
+
+	// The inlined graph data round-trips and cannot break out of its script element
+	match := regexp.MustCompile(`(?s)<script type="application/json" id="report-data">(.*?)</script>`).FindStringSubmatch(html)
+	require.Len(t, match, 2)
+	assert.NotContains(t, match[1], "<feature>")
+
+	var decoded Report
+	require.NoError(t, json.Unmarshal([]byte(match[1]), &decoded))
+	assert.Len(t, decoded.Graph.Nodes, 3)
+	assert.Len(t, decoded.Graph.Edges, 3)
+	assert.Equal(t, "Ship <feature>", decoded.Graph.Nodes[0].Title)
+}
@@ -0,0 +1,197 @@
+// Package pkg provides GitHub issue search integration for query-driven operations.
+//
+// This file resolves GitHub search queries into concrete issue references so that
+// bulk commands can target "every issue matching X" instead of explicit numbers.
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// Search configuration
+const (
+	SearchPageSize    = 100  // Maximum page size allowed by the search API
+	SearchResultLimit = 1000 // GitHub search never returns more than 1000 results
+)
+
+// searchIssueItem models a single item returned from the issue search API.
+// Search results carry the repository as an API URL rather than an object.
+type searchIssueItem struct {
+	Issue
+	RepositoryURL string `json:"repository_url"`
+	PullRequest   *struct {
+		URL string `json:"url"`
+	} `json:"pull_request,omitempty"`
+}
+
+// searchIssuesResponse models the envelope returned by the issue search API
+type searchIssuesResponse struct {
+	TotalCount        int               `json:"total_count"`
+	IncompleteResults bool              `json:"incomplete_results"`
+	Items             []searchIssueItem `json:"items"`
+}
+
+// SearchIssues resolves a GitHub issue search query into the matching issues.
+// Pull requests are skipped, and at most limit issues are returned (capped at
+// SearchResultLimit). Each returned issue has its Repository populated so it can
+// be converted into an IssueRef for cross-repository operations.
+func SearchIssues(ctx context.Context, query string, limit int) ([]Issue, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, NewEmptyValueError("search query")
+	}
+
+	// Verify GitHub CLI authentication
+	if err := SetupGitHubClient(); err != nil {
+		return nil, err
+	}
+
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return nil, WrapInternalError("creating GitHub API client for search", err)
+	}
+
+	return searchIssues(ctx, client, query, limit)
+}
+
+// searchIssues pages through the search API until limit issues are collected
+func searchIssues(ctx context.Context, client *api.RESTClient, query string, limit int) ([]Issue, error) {
+	if limit <= 0 || limit > SearchResultLimit {
+		limit = SearchResultLimit
+	}
+
+	query = normalizeIssueQuery(query)
+
+	var issues []Issue
+	for page := 1; len(issues) < limit; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, NewTimeoutError("searching issues")
+		}
+
+		endpoint := fmt.Sprintf("search/issues?q=%s&per_page=%d&page=%d",
+			url.QueryEscape(query), SearchPageSize, page)
+
+		var response searchIssuesResponse
+		if err := client.Get(endpoint, &response); err != nil {
+			return nil, handleSearchError(err, query)
+		}
+
+		for _, item := range response.Items {
+			if item.PullRequest != nil {
+				continue // The query may match pull requests; dependencies are issue-only
+			}
+
+			issue := item.Issue
+			if fullName := repoFromAPIURL(item.RepositoryURL); fullName != "" {
+				parts := strings.SplitN(fullName, "/", 2)
+				issue.Repository = RepositoryInfo{
+					Name:     parts[1],
+					FullName: fullName,
+					Owner: struct {
+						Login string `json:"login"`
+					}{Login: parts[0]},
+				}
+			}
+			issues = append(issues, issue)
+
+			if len(issues) >= limit {
+				break
+			}
+		}
+
+		// Stop when the last page has been consumed
+		if len(response.Items) < SearchPageSize || page*SearchPageSize >= response.TotalCount {
+			break
+		}
+	}
+
+	return issues, nil
+}
+
+// normalizeIssueQuery restricts a search query to issues unless the caller
+// already specified a type qualifier.
+func normalizeIssueQuery(query string) string {
+	lower := strings.ToLower(query)
+	for _, qualifier := range []string{"is:issue", "type:issue", "is:pr", "type:pr"} {
+		if strings.Contains(lower, qualifier) {
+			return query
+		}
+	}
+	return query + " is:issue"
+}
+
+// QueryRepository returns the owner/repo named by the repo: qualifier of a
+// search query, or "" when the query names no repository or several
+func QueryRepository(query string) string {
+	repository := ""
+	for _, term := range strings.Fields(query) {
+		if len(term) < len("repo:") || !strings.EqualFold(term[:len("repo:")], "repo:") {
+			continue
+		}
+		if repository != "" {
+			return ""
+		}
+		repository = term[len("repo:"):]
+	}
+	return repository
+}
+
+// repoFromAPIURL extracts owner/repo from an API repository URL such as
+// https://api.github.com/repos/owner/repo
+func repoFromAPIURL(apiURL string) string {
+	idx := strings.Index(apiURL, "/repos/")
+	if idx < 0 {
+		return ""
+	}
+
+	parts := strings.Split(strings.Trim(apiURL[idx+len("/repos/"):], "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return ""
+	}
+
+	return parts[0] + "/" + parts[1]
+}
+
+// handleSearchError categorizes search API errors
+func handleSearchError(err error, query string) error {
+	errMsg := strings.ToLower(err.Error())
+
+	if strings.Contains(errMsg, "unauthorized") || strings.Contains(errMsg, "401") {
+		return WrapAuthError(err)
+	}
+	if strings.Contains(errMsg, "rate limit") || strings.Contains(errMsg, "429") {
+		return WrapAPIError(429, err)
+	}
+	if strings.Contains(errMsg, "validation failed") || strings.Contains(errMsg, "422") {
+		return NewAppError(
+			ErrorTypeValidation,
+			"Invalid search query",
+			err,
+		).WithContext("query", query).
+			WithSuggestion("Check the query syntax at https://docs.github.com/search-github/searching-on-github/searching-issues-and-pull-requests").
+			WithSuggestion("Try the same query with 'gh search issues' to preview matches")
+	}
+
+	return WrapInternalError("searching issues", err)
+}
+
+// IssueRefFromIssue converts an issue returned by the API into an IssueRef.
+// The issue's Repository must be populated; otherwise the HTML URL is used.
+func IssueRefFromIssue(issue Issue) IssueRef {
+	fullName := issue.Repository.FullName
+	if fullName == "" {
+		fullName = extractRepoFromURL(issue.HTMLURL)
+	}
+
+	parts := strings.SplitN(fullName, "/", 2)
+	if len(parts) != 2 {
+		return IssueRef{Number: issue.Number}
+	}
+
+	return CreateIssueRef(parts[0], parts[1], issue.Number)
+}
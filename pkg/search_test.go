@@ -0,0 +1,160 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper for fake API clients
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newFakeRESTClient creates a REST client whose requests are served by handler
+func newFakeRESTClient(t *testing.T, handler roundTripFunc) *api.RESTClient {
+	t.Helper()
+	client, err := api.NewRESTClient(api.ClientOptions{
+		Host:         "github.com",
+		AuthToken:    "test-token",
+		Transport:    handler,
+		LogIgnoreEnv: true,
+	})
+	require.NoError(t, err)
+	return client
+}
+
+// jsonResponse builds an HTTP response with a JSON body
+func jsonResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
+
+func TestNormalizeIssueQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{"adds issue qualifier", "repo:org/app label:bug", "repo:org/app label:bug is:issue"},
+		{"keeps is:issue", "repo:org/app is:issue", "repo:org/app is:issue"},
+		{"keeps type qualifier", "repo:org/app type:issue is:open", "repo:org/app type:issue is:open"},
+		{"respects explicit pr", "repo:org/app is:pr", "repo:org/app is:pr"},
+		{"case insensitive", "repo:org/app IS:ISSUE", "repo:org/app IS:ISSUE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizeIssueQuery(tt.query))
+		})
+	}
+}
+
+func TestQueryRepository(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"repo:org/app label:bug", "org/app"},
+		{"is:open Repo:org/app", "org/app"},
+		{"label:bug -repo:org/app", ""},
+		{"repo:org/app repo:org/api", ""},
+		{"org:org label:bug", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.expected, QueryRepository(tt.query))
+		})
+	}
+}
+
+func TestRepoFromAPIURL(t *testing.T) {
+	assert.Equal(t, "org/app", repoFromAPIURL("https://api.github.com/repos/org/app"))
+	assert.Equal(t, "org/app", repoFromAPIURL("https://ghe.example.com/api/v3/repos/org/app/"))
+	assert.Equal(t, "", repoFromAPIURL("https://api.github.com/users/org"))
+	assert.Equal(t, "", repoFromAPIURL("https://api.github.com/repos/org"))
+	assert.Equal(t, "", repoFromAPIURL(""))
+}
+
+func TestIssueRefFromIssue(t *testing.T) {
+	t.Run("uses repository", func(t *testing.T) {
+		ref := IssueRefFromIssue(Issue{Number: 12, Repository: RepositoryInfo{FullName: "org/app"}})
+		assert.Equal(t, CreateIssueRef("org", "app", 12), ref)
+	})
+
+	t.Run("falls back to URL", func(t *testing.T) {
+		ref := IssueRefFromIssue(Issue{Number: 7, HTMLURL: "https://github.com/org/web/issues/7"})
+		assert.Equal(t, "org/web#7", ref.String())
+	})
+}
+
+func TestSearchIssues(t *testing.T) {
+	t.Run("empty query", func(t *testing.T) {
+		_, err := SearchIssues(context.Background(), "  ", 0)
+		require.Error(t, err)
+		assert.True(t, IsErrorType(err, ErrorTypeValidation))
+	})
+
+	t.Run("skips pull requests and sets repository", func(t *testing.T) {
+		var gotQuery string
+		client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+			gotQuery = req.URL.Query().Get("q")
+			return jsonResponse(req, 200, `{"total_count": 3, "items": [
+				{"id": 1, "number": 12, "title": "API auth", "state": "open", "repository_url": "https://api.github.com/repos/org/app"},
+				{"id": 2, "number": 13, "title": "A PR", "state": "open", "repository_url": "https://api.github.com/repos/org/app", "pull_request": {"url": "x"}},
+				{"id": 3, "number": 4, "title": "API docs", "state": "open", "repository_url": "https://api.github.com/repos/org/docs"}
+			]}`), nil
+		})
+
+		issues, err := searchIssues(context.Background(), client, "label:area:api is:open", 0)
+		require.NoError(t, err)
+		assert.Equal(t, "label:area:api is:open is:issue", gotQuery)
+		require.Len(t, issues, 2)
+		assert.Equal(t, "org/app", issues[0].Repository.FullName)
+		assert.Equal(t, "org/docs", issues[1].Repository.FullName)
+		assert.Equal(t, int64(3), issues[1].ID)
+	})
+
+	t.Run("pages until limit", func(t *testing.T) {
+		pages := 0
+		client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+			pages++
+			var items []string
+			for i := 0; i < SearchPageSize; i++ {
+				n := (pages-1)*SearchPageSize + i + 1
+				items = append(items, fmt.Sprintf(`{"number": %d, "repository_url": "https://api.github.com/repos/org/app"}`, n))
+			}
+			return jsonResponse(req, 200, fmt.Sprintf(`{"total_count": 500, "items": [%s]}`, strings.Join(items, ","))), nil
+		})
+
+		issues, err := searchIssues(context.Background(), client, "repo:org/app", 150)
+		require.NoError(t, err)
+		assert.Len(t, issues, 150)
+		assert.Equal(t, 2, pages)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(req, 422, `{"message": "Validation Failed"}`), nil
+		})
+
+		_, err := searchIssues(context.Background(), client, "repo:", 0)
+		require.Error(t, err)
+		assert.True(t, IsErrorType(err, ErrorTypeValidation))
+		assert.Contains(t, err.Error(), "Invalid search query")
+	})
+}
@@ -0,0 +1,243 @@
+// Package pkg provides composite sorting of dependency relations.
+//
+// A sort spec is a comma-separated list of keys such as "state,-updated,number".
+// A leading "-" sorts that key in descending order. Sorting is stable, and ties
+// on every key fall back to repository and issue number so the order is
+// deterministic across runs.
+package pkg
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Sort fields accepted in a sort spec
+const (
+	SortNumber     = "number"
+	SortTitle      = "title"
+	SortState      = "state"
+	SortRepository = "repository"
+	SortCreated    = "created"
+	SortUpdated    = "updated"
+	SortClosed     = "closed"
+	SortAssignee   = "assignee"
+	SortLabel      = "label" // Written as label:<prefix>
+	SortMilestone  = "milestone"
+)
+
+// SortKey is one key of a sort spec
+type SortKey struct {
+	Field      string
+	Prefix     string // Label prefix for SortLabel
+	Descending bool
+}
+
+// String returns the key as written in a sort spec
+func (k SortKey) String() string {
+	field := k.Field
+	if k.Field == SortLabel {
+		field += ":" + k.Prefix
+	}
+	if k.Descending {
+		return "-" + field
+	}
+	return field
+}
+
+// SortFieldNames returns the fields accepted in a sort spec, in documentation order
+func SortFieldNames() []string {
+	return []string{
+		SortNumber, SortTitle, SortState, SortRepository, SortCreated,
+		SortUpdated, SortClosed, SortAssignee, SortLabel + ":<prefix>", SortMilestone,
+	}
+}
+
+// ParseSortSpec parses a comma-separated sort spec such as "state,-updated,number"
+func ParseSortSpec(spec string) ([]SortKey, error) {
+	var keys []SortKey
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key := SortKey{}
+		if strings.HasPrefix(part, "-") {
+			key.Descending = true
+			part = part[1:]
+		}
+
+		field, prefix, hasPrefix := strings.Cut(part, ":")
+		key.Field = strings.ToLower(field)
+
+		switch key.Field {
+		case SortNumber, SortTitle, SortState, SortRepository, SortCreated,
+			SortUpdated, SortClosed, SortAssignee, SortMilestone:
+			if hasPrefix {
+				return nil, newSortSpecError(part, fmt.Sprintf("Sort field %s does not take a value", key.Field))
+			}
+		case SortLabel:
+			if strings.TrimSpace(prefix) == "" {
+				return nil, newSortSpecError(part, "Sort field label requires a prefix").
+					WithSuggestion("Use label:<prefix>, e.g. label:priority/")
+			}
+			key.Prefix = strings.ToLower(strings.TrimSpace(prefix))
+		default:
+			return nil, newSortSpecError(part, fmt.Sprintf("Invalid sort: %s", part))
+		}
+
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, NewEmptyValueError("sort")
+	}
+
+	return keys, nil
+}
+
+// newSortSpecError builds a sort validation error with suggestions for field
+func newSortSpecError(field, message string) *AppError {
+	appErr := NewAppError(ErrorTypeValidation, message, nil).WithContext("sort", field)
+
+	name, _, _ := strings.Cut(strings.TrimPrefix(field, "-"), ":")
+	if matches := closestMatches(name, sortFieldBaseNames(), 3); len(matches) > 0 {
+		appErr.WithSuggestion(fmt.Sprintf("Did you mean: %s?", strings.Join(matches, ", ")))
+	}
+	return appErr.WithSuggestion(fmt.Sprintf("Use one or more of: %s; prefix a field with - to sort descending",
+		strings.Join(SortFieldNames(), ", ")))
+}
+
+// sortFieldBaseNames returns the sort field names without value placeholders
+func sortFieldBaseNames() []string {
+	names := SortFieldNames()
+	for i, name := range names {
+		names[i], _, _ = strings.Cut(name, ":")
+	}
+	return names
+}
+
+// SortDependencies stably sorts deps by keys. Missing values, such as an
+// unassigned issue or an open issue's close time, sort last in either direction.
+func SortDependencies(deps []DependencyRelation, keys []SortKey) {
+	sort.SliceStable(deps, func(i, j int) bool {
+		for _, key := range keys {
+			if c := compareByKey(deps[i], deps[j], key); c != 0 {
+				return c < 0
+			}
+		}
+
+		// Tie-break on identity so equal keys never depend on input order
+		if c := strings.Compare(strings.ToLower(deps[i].Repository), strings.ToLower(deps[j].Repository)); c != 0 {
+			return c < 0
+		}
+		return deps[i].Issue.Number < deps[j].Issue.Number
+	})
+}
+
+// compareByKey compares a and b on a single key, honoring its direction
+func compareByKey(a, b DependencyRelation, key SortKey) int {
+	var c int
+	missingA, missingB := false, false
+
+	switch key.Field {
+	case SortNumber:
+		c = cmp.Compare(a.Issue.Number, b.Issue.Number)
+	case SortTitle:
+		c = strings.Compare(strings.ToLower(a.Issue.Title), strings.ToLower(b.Issue.Title))
+	case SortState:
+		c = cmp.Compare(StateOrder(a.Issue.State), StateOrder(b.Issue.State))
+	case SortRepository:
+		c = strings.Compare(strings.ToLower(a.Repository), strings.ToLower(b.Repository))
+	case SortCreated:
+		missingA, missingB = a.Issue.CreatedAt.IsZero(), b.Issue.CreatedAt.IsZero()
+		c = a.Issue.CreatedAt.Compare(b.Issue.CreatedAt)
+	case SortUpdated:
+		missingA, missingB = a.Issue.UpdatedAt.IsZero(), b.Issue.UpdatedAt.IsZero()
+		c = a.Issue.UpdatedAt.Compare(b.Issue.UpdatedAt)
+	case SortClosed:
+		missingA, missingB = a.Issue.ClosedAt.IsZero(), b.Issue.ClosedAt.IsZero()
+		c = a.Issue.ClosedAt.Compare(b.Issue.ClosedAt)
+	case SortAssignee:
+		loginA, loginB := firstAssignee(a.Issue), firstAssignee(b.Issue)
+		missingA, missingB = loginA == "", loginB == ""
+		c = strings.Compare(loginA, loginB)
+	case SortLabel:
+		labelA, labelB := firstLabelWithPrefix(a.Issue, key.Prefix), firstLabelWithPrefix(b.Issue, key.Prefix)
+		missingA, missingB = labelA == "", labelB == ""
+		c = strings.Compare(labelA, labelB)
+	case SortMilestone:
+		missingA, missingB = a.Issue.Milestone == nil, b.Issue.Milestone == nil
+		if !missingA && !missingB {
+			c = compareMilestones(*a.Issue.Milestone, *b.Issue.Milestone)
+		}
+	}
+
+	// Missing values always sort last, regardless of direction
+	switch {
+	case missingA && missingB:
+		return 0
+	case missingA:
+		return 1
+	case missingB:
+		return -1
+	}
+
+	if key.Descending {
+		return -c
+	}
+	return c
+}
+
+// StateOrder returns a numeric value for state sorting (open = 0, closed = 1)
+func StateOrder(state string) int {
+	switch strings.ToLower(state) {
+	case "open":
+		return 0
+	case "closed":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// firstAssignee returns the alphabetically first assignee login, or ""
+func firstAssignee(issue Issue) string {
+	first := ""
+	for _, assignee := range issue.Assignees {
+		login := strings.ToLower(assignee.Login)
+		if first == "" || login < first {
+			first = login
+		}
+	}
+	return first
+}
+
+// firstLabelWithPrefix returns the alphabetically first label starting with prefix, or ""
+func firstLabelWithPrefix(issue Issue, prefix string) string {
+	first := ""
+	for _, label := range issue.Labels {
+		name := strings.ToLower(label.Name)
+		if strings.HasPrefix(name, prefix) && (first == "" || name < first) {
+			first = name
+		}
+	}
+	return first
+}
+
+// compareMilestones orders milestones by due date, undated last, then by title
+func compareMilestones(a, b Milestone) int {
+	switch {
+	case a.DueOn.IsZero() && !b.DueOn.IsZero():
+		return 1
+	case !a.DueOn.IsZero() && b.DueOn.IsZero():
+		return -1
+	}
+	if c := a.DueOn.Compare(b.DueOn); c != 0 {
+		return c
+	}
+	return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+}
@@ -0,0 +1,153 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createSortTestDependencies returns dependencies with distinct dates, assignees, labels, and milestones
+func createSortTestDependencies() []DependencyRelation {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	sprint := &Milestone{Number: 1, Title: "Sprint 1", DueOn: day(20)}
+	release := &Milestone{Number: 2, Title: "Release", DueOn: day(10)}
+
+	return []DependencyRelation{
+		{Repository: "org/web", Issue: Issue{Number: 7, Title: "Beta", State: "closed",
+			CreatedAt: day(1), UpdatedAt: day(9), ClosedAt: day(9),
+			Labels: []Label{{Name: "priority/2"}}, Milestone: sprint}},
+		{Repository: "org/api", Issue: Issue{Number: 3, Title: "alpha", State: "open",
+			CreatedAt: day(3), UpdatedAt: day(5),
+			Assignees: []User{{Login: "zoe"}, {Login: "amy"}}, Labels: []Label{{Name: "priority/1"}}}},
+		{Repository: "org/api", Issue: Issue{Number: 12, Title: "Gamma", State: "open",
+			CreatedAt: day(2), UpdatedAt: day(8),
+			Assignees: []User{{Login: "bob"}}, Milestone: release}},
+		{Repository: "org/web", Issue: Issue{Number: 2, Title: "Delta", State: "closed",
+			CreatedAt: day(4), UpdatedAt: day(6), ClosedAt: day(6)}},
+	}
+}
+
+// sortedNumbers sorts createSortTestDependencies by spec and returns the issue numbers
+func sortedNumbers(t *testing.T, spec string) []int {
+	t.Helper()
+	keys, err := ParseSortSpec(spec)
+	require.NoError(t, err)
+
+	deps := createSortTestDependencies()
+	SortDependencies(deps, keys)
+
+	numbers := make([]int, len(deps))
+	for i, dep := range deps {
+		numbers[i] = dep.Issue.Number
+	}
+	return numbers
+}
+
+func TestParseSortSpec(t *testing.T) {
+	keys, err := ParseSortSpec("state, -updated,label:Priority/,number")
+	require.NoError(t, err)
+	assert.Equal(t, []SortKey{
+		{Field: "state"},
+		{Field: "updated", Descending: true},
+		{Field: "label", Prefix: "priority/"},
+		{Field: "number"},
+	}, keys)
+	assert.Equal(t, "-updated", keys[1].String())
+	assert.Equal(t, "label:priority/", keys[2].String())
+
+	tests := []struct {
+		spec       string
+		message    string
+		suggestion string
+	}{
+		{"updatd", "Invalid sort: updatd", "Did you mean: updated?"},
+		{"state,-milestne", "Invalid sort: milestne", "Did you mean: milestone?"},
+		{"label", "Sort field label requires a prefix", "Use label:<prefix>, e.g. label:priority/"},
+		{"title:x", "Sort field title does not take a value", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := ParseSortSpec(tt.spec)
+			require.Error(t, err)
+
+			var appErr *AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, ErrorTypeValidation, appErr.Type)
+			assert.Equal(t, tt.message, appErr.Message)
+			if tt.suggestion != "" {
+				assert.Contains(t, appErr.Suggestions, tt.suggestion)
+			}
+		})
+	}
+
+	_, err = ParseSortSpec(" , ")
+	assert.Error(t, err)
+}
+
+func TestSortDependencies(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected []int
+	}{
+		{"number", []int{2, 3, 7, 12}},
+		{"-number", []int{12, 7, 3, 2}},
+		{"title", []int{3, 7, 2, 12}},
+		{"created", []int{7, 12, 3, 2}},
+		{"-updated", []int{7, 12, 2, 3}},
+		{"closed", []int{2, 7, 3, 12}},   // open issues have no close time and sort last
+		{"-closed", []int{7, 2, 3, 12}},  // ...in either direction
+		{"assignee", []int{3, 12, 2, 7}}, // amy, bob, then unassigned by repository and number
+		{"label:priority/", []int{3, 7, 12, 2}},
+		{"milestone", []int{12, 7, 3, 2}}, // earliest due date first, none last
+		{"state,-updated", []int{12, 3, 7, 2}},
+		{"repository", []int{3, 12, 2, 7}}, // ties break on issue number
+		{"state,repository", []int{3, 12, 2, 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sortedNumbers(t, tt.spec))
+		})
+	}
+}
+
+func TestSortDependenciesDeterministic(t *testing.T) {
+	keys, err := ParseSortSpec("state")
+	require.NoError(t, err)
+
+	forward := createSortTestDependencies()
+	reversed := createSortTestDependencies()
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+
+	SortDependencies(forward, keys)
+	SortDependencies(reversed, keys)
+	assert.Equal(t, forward, reversed, "input order must not affect the result")
+}
+
+func TestStateOrder(t *testing.T) {
+	tests := []struct {
+		state    string
+		expected int
+	}{
+		{"open", 0},
+		{"Open", 0}, // Case insensitive
+		{"OPEN", 0}, // Case insensitive
+		{"closed", 1},
+		{"Closed", 1},  // Case insensitive
+		{"CLOSED", 1},  // Case insensitive
+		{"unknown", 2}, // Unknown states get highest priority
+		{"", 2},        // Empty state gets highest priority
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			result := StateOrder(tt.state)
+			assert.Equal(t, tt.expected, result, "StateOrder(%q) should return %d", tt.state, tt.expected)
+		})
+	}
+}
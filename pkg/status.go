@@ -0,0 +1,330 @@
+// Package pkg provides the personal dependency status dashboard.
+//
+// The status view answers two standup questions at once: which open blockers
+// the user's assigned issues are waiting on, and which other people's issues
+// are waiting on the user. It searches for the user's open assigned issues and
+// reads the blocked-by and blocking relationships of each.
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/cli/go-gh/v2/pkg/text"
+	"github.com/muesli/termenv"
+)
+
+// DefaultStatusLimit is the number of assigned issues checked when no limit is given
+const DefaultStatusLimit = 100
+
+// StatusOptions configures FetchStatus
+type StatusOptions struct {
+	User  string // Login whose assigned issues are checked
+	Org   string // Limits the search to one organization or user account; all when empty
+	Limit int    // Maximum assigned issues; DefaultStatusLimit when zero
+}
+
+// StatusIssue is an issue in the status report
+type StatusIssue struct {
+	Issue      string    `json:"issue"` // owner/repo#number
+	Number     int       `json:"number"`
+	Title      string    `json:"title"`
+	Repository string    `json:"repository"`
+	HTMLURL    string    `json:"html_url"`
+	Assignees  []string  `json:"assignees"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// StatusEntry is one of the user's assigned issues with its open blockers and
+// the open issues it blocks for other people
+type StatusEntry struct {
+	StatusIssue
+	BlockedBy []StatusIssue `json:"blocked_by"`
+	Blocking  []StatusIssue `json:"blocking"`
+}
+
+// StatusSummary counts the entries in a status report
+type StatusSummary struct {
+	AssignedCount     int `json:"assigned_count"`       // Open issues assigned to the user
+	BlockedCount      int `json:"blocked_count"`        // Assigned issues with an open blocker
+	BlockingCount     int `json:"blocking_count"`       // Assigned issues blocking someone else's issue
+	WaitingOnCount    int `json:"waiting_on_count"`     // Distinct open blockers
+	WaitingOnYouCount int `json:"waiting_on_you_count"` // Distinct issues waiting on the user
+}
+
+// StatusReport is the status dashboard for one user
+type StatusReport struct {
+	User      string        `json:"user"`
+	Org       string        `json:"org,omitempty"`
+	Issues    []StatusEntry `json:"issues"`
+	Summary   StatusSummary `json:"summary"`
+	FetchedAt time.Time     `json:"fetched_at"`
+}
+
+// FetchStatus builds the status report for opts.User
+func FetchStatus(ctx context.Context, opts StatusOptions) (*StatusReport, error) {
+	if err := SetupGitHubClient(); err != nil {
+		return nil, err
+	}
+
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return nil, WrapInternalError("creating GitHub API client", err)
+	}
+
+	return fetchStatus(ctx, client, opts)
+}
+
+// fetchStatus implements FetchStatus with an explicit client
+func fetchStatus(ctx context.Context, client *api.RESTClient, opts StatusOptions) (*StatusReport, error) {
+	if strings.TrimSpace(opts.User) == "" {
+		return nil, NewEmptyValueError("--user")
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultStatusLimit
+	}
+
+	query := fmt.Sprintf("is:open is:issue assignee:%s", opts.User)
+	if opts.Org != "" {
+		query += fmt.Sprintf(" org:%s", opts.Org)
+	}
+
+	assigned, err := searchIssues(ctx, client, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &StatusReport{
+		User:      opts.User,
+		Org:       opts.Org,
+		Issues:    make([]StatusEntry, len(assigned)),
+		FetchedAt: time.Now(),
+	}
+
+	// Each issue needs two requests, so read several issues at once
+	errs := make([]error, len(assigned))
+	semaphore := make(chan struct{}, bulkConcurrency)
+	var wg sync.WaitGroup
+
+	for i, issue := range assigned {
+		wg.Add(1)
+		go func(i int, issue Issue) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			report.Issues[i], errs[i] = fetchStatusEntry(ctx, client, issue, opts.User)
+		}(i, issue)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report.Summary = summarizeStatus(report.Issues)
+	return report, nil
+}
+
+// fetchStatusEntry reads the open blockers of issue and the open issues it
+// blocks that are not assigned to user
+func fetchStatusEntry(ctx context.Context, client *api.RESTClient, issue Issue, user string) (StatusEntry, error) {
+	ref := IssueRefFromIssue(issue)
+	entry := StatusEntry{
+		StatusIssue: newStatusIssue(issue, ref.Owner+"/"+ref.Repo),
+		BlockedBy:   []StatusIssue{},
+		Blocking:    []StatusIssue{},
+	}
+
+	blockedBy, err := fetchDependencyRelationships(ctx, client, ref.Owner, ref.Repo, ref.Number, "blocked_by")
+	if err != nil {
+		return entry, err
+	}
+	for _, dep := range blockedBy {
+		if strings.EqualFold(dep.Issue.State, "open") {
+			entry.BlockedBy = append(entry.BlockedBy, newStatusIssue(dep.Issue, dep.Repository))
+		}
+	}
+
+	blocking, err := fetchDependencyRelationships(ctx, client, ref.Owner, ref.Repo, ref.Number, "blocking")
+	if err != nil {
+		return entry, err
+	}
+	for _, dep := range blocking {
+		if strings.EqualFold(dep.Issue.State, "open") && !isAssignedTo(dep.Issue, user) {
+			entry.Blocking = append(entry.Blocking, newStatusIssue(dep.Issue, dep.Repository))
+		}
+	}
+
+	return entry, nil
+}
+
+// newStatusIssue converts an issue in repo for the status report
+func newStatusIssue(issue Issue, repo string) StatusIssue {
+	assignees := []string{}
+	for _, assignee := range issue.Assignees {
+		assignees = append(assignees, assignee.Login)
+	}
+
+	return StatusIssue{
+		Issue:      fmt.Sprintf("%s#%d", repo, issue.Number),
+		Number:     issue.Number,
+		Title:      issue.Title,
+		Repository: repo,
+		HTMLURL:    issue.HTMLURL,
+		Assignees:  assignees,
+		UpdatedAt:  issue.UpdatedAt,
+	}
+}
+
+// isAssignedTo reports whether login is one of the issue's assignees
+func isAssignedTo(issue Issue, login string) bool {
+	for _, assignee := range issue.Assignees {
+		if strings.EqualFold(assignee.Login, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// summarizeStatus counts the entries, counting a blocker or a waiting issue
+// once however many assigned issues it is related to
+func summarizeStatus(entries []StatusEntry) StatusSummary {
+	summary := StatusSummary{AssignedCount: len(entries)}
+	blockers := make(map[string]bool)
+	waiting := make(map[string]bool)
+
+	for _, entry := range entries {
+		if len(entry.BlockedBy) > 0 {
+			summary.BlockedCount++
+		}
+		if len(entry.Blocking) > 0 {
+			summary.BlockingCount++
+		}
+		for _, blocker := range entry.BlockedBy {
+			blockers[strings.ToLower(blocker.Issue)] = true
+		}
+		for _, blocked := range entry.Blocking {
+			waiting[strings.ToLower(blocked.Issue)] = true
+		}
+	}
+
+	summary.WaitingOnCount = len(blockers)
+	summary.WaitingOnYouCount = len(waiting)
+	return summary
+}
+
+// FormatStatusReport writes report grouped by assigned issue. TTY output has a
+// section for what the user is waiting on and one for who is waiting on them;
+// plain output has one tab-separated row per relationship.
+func FormatStatusReport(w io.Writer, report *StatusReport, tty bool, width int) error {
+	if !tty {
+		return formatStatusPlain(w, report)
+	}
+
+	out := termenv.NewOutput(w)
+	header := func(s string) string { return out.String(s).Foreground(termenv.ANSIYellow).String() }
+	muted := func(s string) string { return out.String(s).Foreground(termenv.ANSIBrightBlack).String() }
+
+	scope := ""
+	if report.Org != "" {
+		scope = " in " + report.Org
+	}
+	fmt.Fprintf(w, "Status for %s%s\n", report.User, scope)
+	fmt.Fprintln(w, muted(fmt.Sprintf("%d assigned open issues, %d blocked, %d blocking others",
+		report.Summary.AssignedCount, report.Summary.BlockedCount, report.Summary.BlockingCount)))
+
+	if report.Summary.BlockedCount == 0 && report.Summary.BlockingCount == 0 {
+		fmt.Fprintln(w, "\nNothing is blocking you, and nobody is waiting on you.")
+		return nil
+	}
+
+	sections := []struct {
+		title   string
+		count   int
+		related func(StatusEntry) []StatusIssue
+	}{
+		{"WAITING ON", report.Summary.WaitingOnCount, func(e StatusEntry) []StatusIssue { return e.BlockedBy }},
+		{"WAITING ON YOU", report.Summary.WaitingOnYouCount, func(e StatusEntry) []StatusIssue { return e.Blocking }},
+	}
+
+	for _, section := range sections {
+		if section.count == 0 {
+			continue
+		}
+
+		fmt.Fprintf(w, "\n%s\n", header(fmt.Sprintf("%s (%d issues)", section.title, section.count)))
+
+		table := tableprinter.New(w, true, width)
+		table.AddHeader([]string{"ISSUE", "TITLE", "ASSIGNEES", "LAST ACTIVITY"}, tableprinter.WithColor(muted))
+		for _, entry := range report.Issues {
+			related := section.related(entry)
+			if len(related) == 0 {
+				continue
+			}
+
+			table.AddField(entry.Issue, tableprinter.WithTruncate(nil))
+			table.AddField(entry.Title)
+			table.AddField("")
+			table.AddField("")
+			table.EndRow()
+
+			for _, issue := range related {
+				table.AddField("  "+issue.Issue, tableprinter.WithTruncate(nil))
+				table.AddField(issue.Title)
+				table.AddField(strings.Join(issue.Assignees, ", "), tableprinter.WithColor(func(s string) string {
+					return out.String(s).Foreground(termenv.ANSIBlue).String()
+				}))
+				table.AddField(formatStatusActivity(issue.UpdatedAt, report.FetchedAt), tableprinter.WithColor(muted))
+				table.EndRow()
+			}
+		}
+		if err := table.Render(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// formatStatusPlain writes one row per relationship: the relationship type,
+// the assigned issue, the related issue, and the related issue's title,
+// assignees, and last activity
+func formatStatusPlain(w io.Writer, report *StatusReport) error {
+	table := tableprinter.New(w, false, 0)
+	for _, entry := range report.Issues {
+		for _, rows := range []struct {
+			relType string
+			issues  []StatusIssue
+		}{{"blocked_by", entry.BlockedBy}, {"blocking", entry.Blocking}} {
+			for _, issue := range rows.issues {
+				table.AddField(rows.relType)
+				table.AddField(entry.Issue)
+				table.AddField(issue.Issue)
+				table.AddField(issue.Title)
+				table.AddField(strings.Join(issue.Assignees, ","))
+				table.AddField(formatTableTime(issue.UpdatedAt, false))
+				table.EndRow()
+			}
+		}
+	}
+	return table.Render()
+}
+
+// formatStatusActivity describes how long ago an issue was last updated
+func formatStatusActivity(updated, now time.Time) string {
+	if updated.IsZero() {
+		return ""
+	}
+	return text.RelativeTimeAgo(now, updated)
+}
@@ -0,0 +1,161 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statusResponses serves two issues assigned to octocat: org/app#1 is blocked
+// by an open and a closed issue and blocks bob's issue and one of octocat's
+// own, while org/api#2 is not related to anything
+var statusResponses = map[string]string{
+	"/search/issues": `{"total_count": 2, "items": [
+		{"number": 1, "title": "Login page", "state": "open", "html_url": "https://github.com/org/app/issues/1",
+		 "repository_url": "https://api.github.com/repos/org/app", "assignees": [{"login": "octocat"}]},
+		{"number": 2, "title": "Token API", "state": "open", "html_url": "https://github.com/org/api/issues/2",
+		 "repository_url": "https://api.github.com/repos/org/api", "assignees": [{"login": "octocat"}]}
+	]}`,
+	"/repos/org/app/issues/1/dependencies/blocked_by": `[
+		{"number": 7, "title": "Auth endpoint", "state": "open", "html_url": "https://github.com/org/api/issues/7",
+		 "assignees": [{"login": "carol"}], "updated_at": "2024-06-01T12:00:00Z"},
+		{"number": 8, "title": "Schema", "state": "closed", "html_url": "https://github.com/org/api/issues/8"}
+	]`,
+	"/repos/org/app/issues/1/dependencies/blocking": `[
+		{"number": 9, "title": "Settings page", "state": "open", "html_url": "https://github.com/org/web/issues/9",
+		 "assignees": [{"login": "bob"}], "updated_at": "2024-06-02T12:00:00Z"},
+		{"number": 10, "title": "My follow-up", "state": "open", "html_url": "https://github.com/org/app/issues/10",
+		 "assignees": [{"login": "OctoCat"}]}
+	]`,
+}
+
+// createTestStatusReport fetches a status report from statusResponses and
+// returns it with the search query that was sent
+func createTestStatusReport(t *testing.T) (*StatusReport, string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var query string
+	client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if req.URL.Path == "/search/issues" {
+			query = req.URL.Query().Get("q")
+		}
+		if body, ok := statusResponses[req.URL.Path]; ok {
+			return jsonResponse(req, 200, body), nil
+		}
+		return jsonResponse(req, 200, `[]`), nil
+	})
+
+	report, err := fetchStatus(context.Background(), client, StatusOptions{User: "octocat", Org: "org"})
+	require.NoError(t, err)
+	return report, query
+}
+
+func TestFetchStatus(t *testing.T) {
+	report, query := createTestStatusReport(t)
+
+	assert.Equal(t, "is:open is:issue assignee:octocat org:org", query)
+	require.Len(t, report.Issues, 2)
+
+	entry := report.Issues[0]
+	assert.Equal(t, "org/app#1", entry.Issue)
+
+	// Only open blockers
+	require.Len(t, entry.BlockedBy, 1)
+	assert.Equal(t, "org/api#7", entry.BlockedBy[0].Issue)
+	assert.Equal(t, []string{"carol"}, entry.BlockedBy[0].Assignees)
+
+	// Only issues that other people are waiting on
+	require.Len(t, entry.Blocking, 1)
+	assert.Equal(t, "org/web#9", entry.Blocking[0].Issue)
+
+	assert.Empty(t, report.Issues[1].BlockedBy)
+	assert.NotNil(t, report.Issues[1].Blocking)
+
+	assert.Equal(t, StatusSummary{
+		AssignedCount:     2,
+		BlockedCount:      1,
+		BlockingCount:     1,
+		WaitingOnCount:    1,
+		WaitingOnYouCount: 1,
+	}, report.Summary)
+}
+
+func TestFetchStatusRequiresUser(t *testing.T) {
+	client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+		t.Fatal("unexpected request")
+		return nil, nil
+	})
+
+	_, err := fetchStatus(context.Background(), client, StatusOptions{})
+	assert.True(t, IsErrorType(err, ErrorTypeValidation))
+}
+
+func TestSummarizeStatusCountsDistinctIssues(t *testing.T) {
+	shared := StatusIssue{Issue: "org/api#7"}
+	summary := summarizeStatus([]StatusEntry{
+		{BlockedBy: []StatusIssue{shared}, Blocking: []StatusIssue{}},
+		{BlockedBy: []StatusIssue{{Issue: "ORG/api#7"}, {Issue: "org/api#8"}}, Blocking: []StatusIssue{}},
+	})
+
+	assert.Equal(t, 2, summary.BlockedCount)
+	assert.Equal(t, 2, summary.WaitingOnCount)
+	assert.Equal(t, 0, summary.BlockingCount)
+}
+
+func TestFormatStatusReport(t *testing.T) {
+	report, _ := createTestStatusReport(t)
+	report.FetchedAt = time.Date(2024, 6, 4, 12, 0, 0, 0, time.UTC)
+
+	t.Run("tty", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, FormatStatusReport(&buf, report, true, 120))
+		output := buf.String()
+
+		assert.Contains(t, output, "Status for octocat in org")
+		assert.Contains(t, output, "2 assigned open issues, 1 blocked, 1 blocking others")
+		assert.Contains(t, output, "WAITING ON (1 issues)")
+		assert.Contains(t, output, "WAITING ON YOU (1 issues)")
+		assert.Contains(t, output, "about 3 days ago")
+		assert.Less(t, strings.Index(output, "org/api#7"), strings.Index(output, "org/web#9"))
+		assert.NotContains(t, output, "org/api#2", "unrelated issues are left out")
+	})
+
+	t.Run("nothing to report", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, FormatStatusReport(&buf, &StatusReport{User: "octocat"}, true, 120))
+		assert.Contains(t, buf.String(), "Nothing is blocking you, and nobody is waiting on you.")
+	})
+
+	t.Run("plain", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, FormatStatusReport(&buf, report, false, 0))
+		assert.Equal(t,
+			"blocked_by\torg/app#1\torg/api#7\tAuth endpoint\tcarol\t2024-06-01T12:00:00Z\n"+
+				"blocking\torg/app#1\torg/web#9\tSettings page\tbob\t2024-06-02T12:00:00Z\n",
+			buf.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		content, err := json.Marshal(report)
+		require.NoError(t, err)
+
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(content, &decoded))
+		first := decoded["issues"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "org/app#1", first["issue"])
+		assert.Len(t, first["blocked_by"], 1)
+		assert.Equal(t, float64(1), decoded["summary"].(map[string]interface{})["waiting_on_you_count"])
+	})
+}
@@ -0,0 +1,169 @@
+// Package pkg provides relationship history from GitHub issue timeline events.
+//
+// GitHub records a timeline event on the source issue whenever a blocked-by or
+// blocking relationship is added. These events are the only record of who added
+// a relationship and when, so detailed output reads them to annotate each relation.
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// Timeline event types recorded when a relationship is added to an issue
+const (
+	TimelineEventBlockedByAdded = "blocked_by_added"
+	TimelineEventBlockingAdded  = "blocking_added"
+)
+
+// maxTimelinePages bounds how many pages of timeline events are read per issue
+const maxTimelinePages = 10
+
+// timelineEvent is the subset of an issue timeline event used for relationships
+type timelineEvent struct {
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Actor     *User     `json:"actor"`
+
+	// BlockingIssue is set on blocked_by_added events, BlockedIssue on blocking_added
+	BlockingIssue *timelineIssue `json:"blocking_issue"`
+	BlockedIssue  *timelineIssue `json:"blocked_issue"`
+}
+
+// timelineIssue identifies the related issue referenced by a timeline event
+type timelineIssue struct {
+	Number        int            `json:"number"`
+	HTMLURL       string         `json:"html_url"`
+	RepositoryURL string         `json:"repository_url"`
+	Repository    RepositoryInfo `json:"repository"`
+}
+
+// repository returns the owner/repo of the referenced issue, or "" if unknown
+func (i *timelineIssue) repository() string {
+	if i.Repository.FullName != "" {
+		return i.Repository.FullName
+	}
+	if i.RepositoryURL != "" {
+		if idx := strings.Index(i.RepositoryURL, "/repos/"); idx >= 0 {
+			return i.RepositoryURL[idx+len("/repos/"):]
+		}
+	}
+	return extractRepoFromURL(i.HTMLURL)
+}
+
+// relationshipEvent is when and by whom a relationship was most recently added
+type relationshipEvent struct {
+	createdAt time.Time
+	createdBy string
+}
+
+// EnrichWithRelationshipEvents sets CreatedAt and CreatedBy on every relation in
+// data from the source issue's timeline. Relations without a matching event,
+// such as those added before GitHub recorded these events, are left unchanged.
+func EnrichWithRelationshipEvents(ctx context.Context, data *DependencyData) error {
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return WrapInternalError("creating GitHub API client", err)
+	}
+
+	return enrichWithRelationshipEvents(ctx, client, data)
+}
+
+// enrichWithRelationshipEvents implements EnrichWithRelationshipEvents with an explicit client
+func enrichWithRelationshipEvents(ctx context.Context, client *api.RESTClient, data *DependencyData) error {
+	if len(data.BlockedBy) == 0 && len(data.Blocking) == 0 {
+		return nil
+	}
+
+	repo := data.SourceIssue.Repository.String()
+	events, err := fetchRelationshipEvents(ctx, client, repo, data.SourceIssue.Number)
+	if err != nil {
+		return err
+	}
+
+	applyRelationshipEvents(data.BlockedBy, events, TimelineEventBlockedByAdded, repo)
+	applyRelationshipEvents(data.Blocking, events, TimelineEventBlockingAdded, repo)
+	return nil
+}
+
+// fetchRelationshipEvents reads the issue timeline and indexes relationship-added
+// events by event type and related issue reference. Later events win, so a
+// relationship that was removed and re-added reports its latest addition.
+func fetchRelationshipEvents(ctx context.Context, client *api.RESTClient, repo string, issueNumber int) (map[string]relationshipEvent, error) {
+	events := make(map[string]relationshipEvent)
+
+	for page := 1; page <= maxTimelinePages; page++ {
+		endpoint := fmt.Sprintf("repos/%s/issues/%d/timeline?per_page=100&page=%d", repo, issueNumber, page)
+
+		var batch []timelineEvent
+		if err := client.DoWithContext(ctx, "GET", endpoint, nil, &batch); err != nil {
+			lower := strings.ToLower(err.Error())
+			if strings.Contains(lower, "forbidden") {
+				return nil, WrapPermissionError(repo, err)
+			}
+			if strings.Contains(lower, "unauthorized") {
+				return nil, WrapAuthError(err)
+			}
+			if strings.Contains(lower, "rate limit") {
+				return nil, WrapAPIError(429, err)
+			}
+			return nil, WrapInternalError("fetching issue timeline", err)
+		}
+
+		for _, event := range batch {
+			related := event.BlockingIssue
+			if event.Event == TimelineEventBlockingAdded {
+				related = event.BlockedIssue
+			}
+			if related == nil || related.Number == 0 {
+				continue
+			}
+
+			relatedRepo := related.repository()
+			if relatedRepo == "" {
+				relatedRepo = repo
+			}
+
+			key := relationshipEventKey(event.Event, relatedRepo, related.Number)
+			if existing, ok := events[key]; ok && existing.createdAt.After(event.CreatedAt) {
+				continue
+			}
+
+			recorded := relationshipEvent{createdAt: event.CreatedAt}
+			if event.Actor != nil {
+				recorded.createdBy = event.Actor.Login
+			}
+			events[key] = recorded
+		}
+
+		if len(batch) < 100 {
+			break
+		}
+	}
+
+	return events, nil
+}
+
+// applyRelationshipEvents copies matching event metadata onto relations
+func applyRelationshipEvents(relations []DependencyRelation, events map[string]relationshipEvent, eventType, sourceRepo string) {
+	for i := range relations {
+		repo := relations[i].Repository
+		if repo == "" {
+			repo = sourceRepo
+		}
+
+		if event, ok := events[relationshipEventKey(eventType, repo, relations[i].Issue.Number)]; ok {
+			relations[i].CreatedAt = event.createdAt
+			relations[i].CreatedBy = event.createdBy
+		}
+	}
+}
+
+// relationshipEventKey identifies an event by type and related issue
+func relationshipEventKey(eventType, repo string, number int) string {
+	return fmt.Sprintf("%s:%s#%d", eventType, strings.ToLower(repo), number)
+}
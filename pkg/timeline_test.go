@@ -0,0 +1,146 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createEnrichedDependencyData returns test data with relationship metadata on #45
+func createEnrichedDependencyData() *DependencyData {
+	data := createTestDependencyData()
+	data.BlockedBy[0].CreatedAt = time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+	data.BlockedBy[0].CreatedBy = "alice"
+	return data
+}
+
+func TestEnrichWithRelationshipEvents(t *testing.T) {
+	timeline := `[
+		{"event": "labeled", "created_at": "2024-01-01T00:00:00Z", "actor": {"login": "bob"}},
+		{"event": "blocked_by_added", "created_at": "2024-01-02T10:00:00Z", "actor": {"login": "bob"},
+		 "blocking_issue": {"number": 45, "repository_url": "https://api.github.com/repos/testowner/testrepo"}},
+		{"event": "blocked_by_added", "created_at": "2024-02-03T10:00:00Z", "actor": {"login": "alice"},
+		 "blocking_issue": {"number": 45, "repository_url": "https://api.github.com/repos/testowner/testrepo"}},
+		{"event": "blocking_added", "created_at": "2024-02-04T09:15:00Z", "actor": {"login": "carol"},
+		 "blocked_issue": {"number": 89, "html_url": "https://github.com/testowner/frontend/issues/89"}},
+		{"event": "blocked_by_added", "created_at": "2024-02-05T09:15:00Z", "actor": {"login": "dave"},
+		 "blocking_issue": {"number": 89, "html_url": "https://github.com/testowner/frontend/issues/89"}}
+	]`
+
+	var requested []string
+	client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req.URL.Path)
+		return jsonResponse(req, 200, timeline), nil
+	})
+
+	data := createTestDependencyData()
+	require.NoError(t, enrichWithRelationshipEvents(context.Background(), client, data))
+
+	assert.Equal(t, []string{"/repos/testowner/testrepo/issues/123/timeline"}, requested)
+
+	// The latest addition wins
+	assert.Equal(t, "alice", data.BlockedBy[0].CreatedBy)
+	assert.Equal(t, time.Date(2024, 2, 3, 10, 0, 0, 0, time.UTC), data.BlockedBy[0].CreatedAt)
+
+	// No event for #67
+	assert.True(t, data.BlockedBy[1].CreatedAt.IsZero())
+	assert.Empty(t, data.BlockedBy[1].CreatedBy)
+
+	// Event type must match the relationship direction
+	assert.Equal(t, "carol", data.Blocking[0].CreatedBy)
+	assert.Equal(t, time.Date(2024, 2, 4, 9, 15, 0, 0, time.UTC), data.Blocking[0].CreatedAt)
+}
+
+func TestEnrichWithRelationshipEventsPaging(t *testing.T) {
+	pages := 0
+	client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+		pages++
+		if req.URL.Query().Get("page") == "1" {
+			events := make([]string, 100)
+			for i := range events {
+				events[i] = `{"event": "commented", "created_at": "2024-01-01T00:00:00Z"}`
+			}
+			return jsonResponse(req, 200, "["+strings.Join(events, ",")+"]"), nil
+		}
+		return jsonResponse(req, 200, `[{"event": "blocked_by_added", "created_at": "2024-01-02T00:00:00Z",
+			"actor": {"login": "erin"}, "blocking_issue": {"number": 67}}]`), nil
+	})
+
+	data := createTestDependencyData()
+	require.NoError(t, enrichWithRelationshipEvents(context.Background(), client, data))
+
+	assert.Equal(t, 2, pages)
+	assert.Equal(t, "erin", data.BlockedBy[1].CreatedBy, "issues without a repository default to the source repository")
+}
+
+func TestEnrichWithRelationshipEventsErrors(t *testing.T) {
+	t.Run("no relations skips the request", func(t *testing.T) {
+		client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+			t.Fatal("unexpected request")
+			return nil, nil
+		})
+		assert.NoError(t, enrichWithRelationshipEvents(context.Background(), client, createEmptyDependencyData()))
+	})
+
+	t.Run("forbidden is a permission error", func(t *testing.T) {
+		client := newFakeRESTClient(t, func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(req, 403, `{"message": "Forbidden"}`), nil
+		})
+		err := enrichWithRelationshipEvents(context.Background(), client, createTestDependencyData())
+		require.Error(t, err)
+
+		var appErr *AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, ErrorTypePermission, appErr.Type)
+	})
+}
+
+func TestRelationshipMetadataOutput(t *testing.T) {
+	format := func(format OutputFormat, detailed bool, fields []string) string {
+		var buf bytes.Buffer
+		formatter := NewOutputFormatter(&OutputOptions{
+			Format:      format,
+			Detailed:    detailed,
+			JSONFields:  fields,
+			Writer:      &buf,
+			StateFilter: "all",
+		})
+		require.NoError(t, formatter.FormatOutput(createEnrichedDependencyData()))
+		return buf.String()
+	}
+
+	t.Run("plain", func(t *testing.T) {
+		assert.Contains(t, format(FormatPlain, true, nil), "\t2024-03-05T14:30:00Z\talice\t")
+		assert.NotContains(t, format(FormatPlain, false, nil), "alice")
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		lines := strings.Split(strings.TrimSpace(format(FormatCSV, true, nil)), "\n")
+		assert.True(t, strings.HasSuffix(lines[1], ",,"), "source row has empty relationship columns")
+		assert.True(t, strings.HasSuffix(lines[2], ",2024-03-05T14:30:00Z,alice"))
+		assert.True(t, strings.HasSuffix(lines[3], ",,"))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var output map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(format(FormatJSON, true, nil)), &output))
+		first := output["blocked_by"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "2024-03-05T14:30:00Z", first["created_at"])
+		assert.Equal(t, "alice", first["created_by"])
+
+		require.NoError(t, json.Unmarshal([]byte(format(FormatJSON, false, nil)), &output))
+		first = output["blocked_by"].([]interface{})[0].(map[string]interface{})
+		assert.NotContains(t, first, "created_at")
+
+		require.NoError(t, json.Unmarshal([]byte(format(FormatJSON, false, []string{"blocked_by.created_by"})), &output))
+		first = output["blocked_by"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"created_by": "alice"}, first)
+	})
+}
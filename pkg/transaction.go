@@ -0,0 +1,168 @@
+// Package pkg provides transactional execution for batch dependency mutations.
+//
+// This file implements the --atomic mode for batch add and remove: every applied
+// relationship change is recorded, and on the first failure the recorded changes
+// are reverted in reverse order so the dependency graph is never left half-edited.
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Batch operation statuses reported for each target
+const (
+	StatusApplied        = "applied"         // Change was made and kept
+	StatusFailed         = "failed"          // Change could not be made
+	StatusRolledBack     = "rolled_back"     // Change was made, then reverted after a later failure
+	StatusRollbackFailed = "rollback_failed" // Change was made but could not be reverted
+	StatusSkipped        = "skipped"         // Change was not attempted because the batch aborted
+)
+
+// BatchError reports a batch that did not fully succeed. It carries the outcome
+// of every target so callers can act on individual failures and rollbacks.
+type BatchError struct {
+	Action  string       // "add" or "remove"
+	Atomic  bool         // Whether the batch ran in --atomic mode
+	Results []BulkResult // Per-target outcomes, in batch order
+	appErr  *AppError    // User-facing summary used for messages and exit codes
+}
+
+func (e *BatchError) Error() string {
+	return e.appErr.Error()
+}
+
+// Unwrap exposes the summary AppError so error type checks and formatting work
+func (e *BatchError) Unwrap() error {
+	return e.appErr
+}
+
+// Count returns the number of results with the given status
+func (e *BatchError) Count(status string) int {
+	return countResults(e.Results, status)
+}
+
+// newBatchError summarizes failed batch results into a BatchError
+func newBatchError(action string, results []BulkResult, atomic bool) *BatchError {
+	failed := countResults(results, StatusFailed)
+	applied := countResults(results, StatusApplied)
+	rolledBack := countResults(results, StatusRolledBack)
+	rollbackFailed := countResults(results, StatusRollbackFailed)
+	skipped := countResults(results, StatusSkipped)
+
+	var appErr *AppError
+	if atomic {
+		message := fmt.Sprintf("Atomic batch %s failed; %d applied changes rolled back", action, rolledBack)
+		if rollbackFailed > 0 {
+			message = fmt.Sprintf("Atomic batch %s failed and rollback was incomplete: %d changes could not be reverted",
+				action, rollbackFailed)
+		}
+		appErr = NewAppError(ErrorTypeAPI, message, firstResultError(results)).
+			WithContext("failed", strconv.Itoa(failed)).
+			WithContext("rolled_back", strconv.Itoa(rolledBack)).
+			WithContext("skipped", strconv.Itoa(skipped))
+		if rollbackFailed > 0 {
+			appErr.WithContext("rollback_failed", strconv.Itoa(rollbackFailed)).
+				WithSuggestion("Use 'gh issue-dependency list' to inspect the relationships that could not be reverted")
+		}
+		appErr.WithSuggestion("Fix the failing relationship and rerun the batch")
+	} else {
+		appErr = NewAppError(
+			ErrorTypeAPI,
+			fmt.Sprintf("Batch %s partially failed: %d succeeded, %d failed", action, applied, failed),
+			firstResultError(results),
+		).WithContext("succeeded", strconv.Itoa(applied)).
+			WithContext("failed", strconv.Itoa(failed)).
+			WithSuggestion("Review the errors and retry failed operations individually").
+			WithSuggestion("Use --atomic to roll back all changes when any of them fails")
+	}
+
+	return &BatchError{
+		Action:  action,
+		Atomic:  atomic,
+		Results: results,
+		appErr:  appErr,
+	}
+}
+
+// AsBatchError extracts a BatchError from an error chain
+func AsBatchError(err error) (*BatchError, bool) {
+	var batchErr *BatchError
+	if errors.As(err, &batchErr) {
+		return batchErr, true
+	}
+	return nil, false
+}
+
+// runAtomicOperations applies ops one at a time. On the first failure the
+// remaining ops are skipped and every applied op is reverted in reverse order.
+func runAtomicOperations(ops []BulkOperation, apply, revert func(BulkOperation) error) []BulkResult {
+	results := make([]BulkResult, len(ops))
+	for i, op := range ops {
+		results[i] = BulkResult{Operation: op, Status: StatusSkipped}
+	}
+
+	// journal records the indexes of applied ops so they can be reverted
+	var journal []int
+
+	for i, op := range ops {
+		if err := apply(op); err != nil {
+			results[i].Status = StatusFailed
+			results[i].Err = err
+
+			for j := len(journal) - 1; j >= 0; j-- {
+				applied := journal[j]
+				if rollbackErr := revert(ops[applied]); rollbackErr != nil {
+					results[applied].Status = StatusRollbackFailed
+					results[applied].RollbackErr = rollbackErr
+				} else {
+					results[applied].Status = StatusRolledBack
+				}
+			}
+			return results
+		}
+
+		results[i].Status = StatusApplied
+		journal = append(journal, i)
+	}
+
+	return results
+}
+
+// countResults counts results with the given status
+func countResults(results []BulkResult, status string) int {
+	count := 0
+	for _, result := range results {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// firstResultError returns the first failure in results, used as the error cause
+func firstResultError(results []BulkResult) error {
+	for _, result := range results {
+		if result.Status == StatusFailed && result.Err != nil {
+			return result.Err
+		}
+	}
+	return nil
+}
+
+// resultSymbol returns the display marker for a result status
+func resultSymbol(status string) string {
+	switch status {
+	case StatusApplied:
+		return "✅"
+	case StatusRolledBack:
+		return "↩️"
+	case StatusRollbackFailed:
+		return "⚠️"
+	case StatusSkipped:
+		return "⏭️"
+	default:
+		return "❌"
+	}
+}
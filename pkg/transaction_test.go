@@ -0,0 +1,136 @@
+package pkg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTransactionOps builds blocked-by operations from org/app#1 to each target number
+func createTransactionOps(numbers ...int) []BulkOperation {
+	var ops []BulkOperation
+	for _, n := range numbers {
+		ops = append(ops, BulkOperation{
+			Source:  CreateIssueRef("org", "app", 1),
+			Target:  CreateIssueRef("org", "app", n),
+			RelType: "blocked-by",
+		})
+	}
+	return ops
+}
+
+func TestRunAtomicOperations(t *testing.T) {
+	t.Run("all succeed", func(t *testing.T) {
+		var reverted []int
+		results := runAtomicOperations(createTransactionOps(10, 11, 12),
+			func(op BulkOperation) error { return nil },
+			func(op BulkOperation) error {
+				reverted = append(reverted, op.Target.Number)
+				return nil
+			})
+
+		for _, result := range results {
+			assert.Equal(t, StatusApplied, result.Status)
+		}
+		assert.Empty(t, reverted)
+	})
+
+	t.Run("failure rolls back applied steps in reverse order", func(t *testing.T) {
+		var applied, reverted []int
+		results := runAtomicOperations(createTransactionOps(10, 11, 12, 13),
+			func(op BulkOperation) error {
+				if op.Target.Number == 12 {
+					return errors.New("forbidden")
+				}
+				applied = append(applied, op.Target.Number)
+				return nil
+			},
+			func(op BulkOperation) error {
+				reverted = append(reverted, op.Target.Number)
+				return nil
+			})
+
+		assert.Equal(t, []int{10, 11}, applied, "steps after the failure must not run")
+		assert.Equal(t, []int{11, 10}, reverted)
+
+		require.Len(t, results, 4)
+		assert.Equal(t, StatusRolledBack, results[0].Status)
+		assert.Equal(t, StatusRolledBack, results[1].Status)
+		assert.Equal(t, StatusFailed, results[2].Status)
+		assert.EqualError(t, results[2].Err, "forbidden")
+		assert.Equal(t, StatusSkipped, results[3].Status)
+	})
+
+	t.Run("rollback failure is recorded per target", func(t *testing.T) {
+		results := runAtomicOperations(createTransactionOps(10, 11, 12),
+			func(op BulkOperation) error {
+				if op.Target.Number == 12 {
+					return errors.New("boom")
+				}
+				return nil
+			},
+			func(op BulkOperation) error {
+				if op.Target.Number == 10 {
+					return errors.New("still there")
+				}
+				return nil
+			})
+
+		assert.Equal(t, StatusRollbackFailed, results[0].Status)
+		assert.EqualError(t, results[0].RollbackErr, "still there")
+		assert.Equal(t, StatusRolledBack, results[1].Status)
+	})
+}
+
+func TestNewBatchError(t *testing.T) {
+	ops := createTransactionOps(10, 11, 12)
+
+	t.Run("atomic rollback", func(t *testing.T) {
+		cause := errors.New("forbidden")
+		err := newBatchError("add", []BulkResult{
+			{Operation: ops[0], Status: StatusRolledBack},
+			{Operation: ops[1], Status: StatusFailed, Err: cause},
+			{Operation: ops[2], Status: StatusSkipped},
+		}, true)
+
+		assert.Contains(t, err.Error(), "1 applied changes rolled back")
+		assert.True(t, IsErrorType(err, ErrorTypeAPI))
+		assert.ErrorIs(t, err, cause)
+
+		var appErr *AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "1", appErr.Context["rolled_back"])
+		assert.Equal(t, "1", appErr.Context["skipped"])
+		assert.NotContains(t, appErr.Context, "errors", "results must not be flattened into a string")
+	})
+
+	t.Run("atomic incomplete rollback", func(t *testing.T) {
+		err := newBatchError("remove", []BulkResult{
+			{Operation: ops[0], Status: StatusRollbackFailed, RollbackErr: errors.New("x")},
+			{Operation: ops[1], Status: StatusFailed, Err: errors.New("y")},
+		}, true)
+
+		assert.Contains(t, err.Error(), "rollback was incomplete")
+		assert.Equal(t, 1, err.Count(StatusRollbackFailed))
+	})
+
+	t.Run("non-atomic partial failure", func(t *testing.T) {
+		err := newBatchError("removal", []BulkResult{
+			{Operation: ops[0], Status: StatusApplied},
+			{Operation: ops[1], Status: StatusFailed, Err: errors.New("y")},
+		}, false)
+
+		assert.Equal(t, "Batch removal partially failed: 1 succeeded, 1 failed", err.Error())
+		assert.False(t, err.Atomic)
+	})
+}
+
+func TestResultSymbol(t *testing.T) {
+	assert.Equal(t, "✅", resultSymbol(StatusApplied))
+	assert.Equal(t, "❌", resultSymbol(StatusFailed))
+	assert.Equal(t, "↩️", resultSymbol(StatusRolledBack))
+	assert.Equal(t, "⚠️", resultSymbol(StatusRollbackFailed))
+	assert.Equal(t, "⏭️", resultSymbol(StatusSkipped))
+}
@@ -0,0 +1,135 @@
+// Package pkg provides undo support for journaled dependency mutations.
+//
+// Undo replays the inverse of journal entries: additions are removed and
+// removals are re-created. Each inverse change is validated against the current
+// state of the issues, previewed, confirmed, and journaled like any other mutation.
+package pkg
+
+import (
+	"fmt"
+)
+
+// UndoOptions contains options for undo operations
+type UndoOptions struct {
+	DryRun bool
+	Force  bool
+	Atomic bool // Re-apply every undone entry if any undo step fails
+}
+
+// Undoer replays the inverse of journal entries
+type Undoer struct {
+	adder   *DependencyAdder
+	remover *DependencyRemover
+}
+
+// NewUndoer creates a new undoer with GitHub API client
+func NewUndoer() (*Undoer, error) {
+	remover, err := NewDependencyRemover()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Undoer{
+		adder:   remover.adder(),
+		remover: remover,
+	}, nil
+}
+
+// Undo reverts entries in the given order, which should be newest first
+func (u *Undoer) Undo(entries []JournalEntry, opts UndoOptions) error {
+	if len(entries) == 0 {
+		return NewEmptyValueError("operations to undo")
+	}
+
+	// 1. Convert entries into inverse operations and validate them
+	ops := make([]BulkOperation, len(entries))
+	byOperation := make(map[BulkOperation]JournalEntry, len(entries))
+	touched := make(map[string]bool)
+
+	for i, entry := range entries {
+		op, err := entry.Operation()
+		if err != nil {
+			return err
+		}
+		op.Title = fmt.Sprintf("(%s, undoes %s)", entry.InverseAction(), entry.ID)
+
+		// Earlier steps in this undo may change the same relationship, so only
+		// the first step for a relationship can be checked against current state
+		key := fmt.Sprintf("%s %s %s", op.Source.String(), op.RelType, op.Target.String())
+		if !touched[key] {
+			if err := u.validateUndo(entry, op); err != nil {
+				return err
+			}
+			touched[key] = true
+		}
+
+		ops[i] = op
+		byOperation[op] = entry
+	}
+
+	// 2. Preview, confirm, apply, and report
+	apply := func(op BulkOperation) error {
+		entry := byOperation[op]
+		return u.apply(entry.InverseAction(), op, entry.ID)
+	}
+	revert := func(op BulkOperation) error {
+		return u.apply(byOperation[op].Action, op, "")
+	}
+
+	return executeBulkOperations("undo", "", ops, BulkOptions{
+		DryRun:      opts.DryRun,
+		Force:       opts.Force,
+		Atomic:      opts.Atomic,
+		Concurrency: 1, // undo steps must run newest first
+	}, nil, apply, revert)
+}
+
+// validateUndo checks that the inverse of entry can be applied to the current state
+func (u *Undoer) validateUndo(entry JournalEntry, op BulkOperation) error {
+	// Undo restores the recorded prior state, which only differs from the state
+	// after the entry if the entry really added or removed the relationship
+	if prior := entry.PriorState; prior != nil && prior.RelationshipExisted != (entry.Action == JournalActionRemove) {
+		return NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Cannot undo operation %s: %s", entry.ID, op.String()),
+			nil,
+		).WithContext("operation", entry.ID).
+			WithSuggestion(fmt.Sprintf("The relationship was already %s before this operation; undoing it would not restore that state", prior.relationshipState()))
+	}
+
+	var err error
+	if entry.InverseAction() == JournalActionRemove {
+		err = u.remover.validator.ValidateRemoval(op.Source, op.Target, op.RelType)
+	} else {
+		err = u.adder.validateAddition(op.Source, op.Target, op.RelType)
+	}
+
+	if err != nil {
+		return NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Cannot undo operation %s: %s", entry.ID, op.String()),
+			err,
+		).WithContext("operation", entry.ID).
+			WithSuggestion("The relationship was changed after this operation was recorded").
+			WithSuggestion(fmt.Sprintf("Use 'gh issue-dependency list %s' to inspect the current state", op.Source.String()))
+	}
+
+	return nil
+}
+
+// apply performs action for op and journals it as the undo of undoOf
+func (u *Undoer) apply(action string, op BulkOperation, undoOf string) error {
+	prior := priorStateFor(u.adder.client, op)
+	var err error
+	if action == JournalActionRemove {
+		err = u.remover.deleteRelationshipWithRetry(op.Source, op.Target, op.RelType)
+	} else {
+		err = u.adder.createRelationshipWithRetry(op.Source, op.Target, op.RelType)
+	}
+	if err != nil {
+		return err
+	}
+
+	recordMutation(u.adder.client, action, op, prior, undoOf)
+	return nil
+}
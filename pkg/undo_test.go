@@ -0,0 +1,38 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateUndoPriorState(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  string
+		existed bool
+	}{
+		{"addition of an existing relationship", JournalActionAdd, true},
+		{"removal of a missing relationship", JournalActionRemove, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := createJournalEntry("e1", tt.action, 10, "")
+			entry.PriorState = &JournalPriorState{RelationshipExisted: tt.existed}
+			op, err := entry.Operation()
+			require.NoError(t, err)
+
+			// The recorded state is checked before the current state is fetched
+			err = (&Undoer{}).validateUndo(entry, op)
+			require.Error(t, err)
+			assert.True(t, IsErrorType(err, ErrorTypeValidation))
+			assert.Contains(t, err.Error(), "Cannot undo operation e1")
+
+			var appErr *AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Contains(t, appErr.Suggestions[0], "undoing it would not restore that state")
+		})
+	}
+}
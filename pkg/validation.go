@@ -61,6 +61,7 @@ func (ref IssueRef) String() string {
 type RemoveOptions struct {
 	DryRun bool
 	Force  bool
+	Atomic bool // Restore every removed relationship if any removal fails
 }
 
 // ValidationResult contains the result of a validation operation
@@ -99,14 +100,17 @@ func (v *RemovalValidator) ValidateRemoval(source, target IssueRef, relType stri
 	}
 
 	// 4. Verify relationship actually exists
-	exists, err := v.VerifyRelationshipExists(source, target, relType)
+	relation, err := v.lookupRelation(source, target, relType)
 	if err != nil {
 		return fmt.Errorf("relationship verification failed: %w", err)
 	}
-	if !exists {
+	if relation == nil {
 		return v.createRelationshipNotFoundError(source, target, relType)
 	}
 
+	// 5. Keep the state just read for the journal entry of the removal
+	v.rememberRemoval(source, target, relType, relation)
+
 	return nil
 }
 
@@ -133,16 +137,18 @@ func (v *RemovalValidator) ValidateBatchRemoval(source IssueRef, targets []Issue
 		}
 
 		// Verify relationship exists
-		exists, err := v.VerifyRelationshipExists(source, target, relType)
+		relation, err := v.lookupRelation(source, target, relType)
 		if err != nil {
 			validationErrors = append(validationErrors,
 				fmt.Sprintf("Relationship verification failed for %s: %v", target.String(), err))
 			continue
 		}
-		if !exists {
+		if relation == nil {
 			validationErrors = append(validationErrors,
 				fmt.Sprintf("No %s relationship found between %s and %s", relType, source.String(), target.String()))
+			continue
 		}
+		v.rememberRemoval(source, target, relType, relation)
 	}
 
 	if len(validationErrors) > 0 {
@@ -161,17 +167,36 @@ func (v *RemovalValidator) ValidateBatchRemoval(source IssueRef, targets []Issue
 // VerifyRelationshipExists checks if a dependency relationship actually exists
 // between the source and target issues using the GitHub API.
 func (v *RemovalValidator) VerifyRelationshipExists(source, target IssueRef, relType string) (bool, error) {
+	relation, err := v.lookupRelation(source, target, relType)
+	if err != nil {
+		return false, err
+	}
+	return relation != nil, nil
+}
+
+// lookupRelation returns the relationship between source and target, or nil
+// when there is none
+func (v *RemovalValidator) lookupRelation(source, target IssueRef, relType string) (*DependencyRelation, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	// Fetch existing relationships for the source issue
 	dependencies, err := v.fetchIssueDependencies(ctx, source)
 	if err != nil {
-		return false, fmt.Errorf("failed to fetch dependencies for %s: %w", source.String(), err)
+		return nil, fmt.Errorf("failed to fetch dependencies for %s: %w", source.String(), err)
 	}
 
-	// Check if the target exists in the appropriate relationship list
-	return v.relationshipExistsInData(dependencies, target, relType), nil
+	// Look for the target in the appropriate relationship list
+	return v.findRelationInData(dependencies, target, relType), nil
+}
+
+// rememberRemoval records the validated relationship as the prior state of its removal
+func (v *RemovalValidator) rememberRemoval(source, target IssueRef, relType string, relation *DependencyRelation) {
+	rememberPriorState(BulkOperation{Source: source, Target: target, RelType: relType}, JournalPriorState{
+		RelationshipExisted: true,
+		TargetState:         relation.Issue.State,
+		TargetTitle:         relation.Issue.Title,
+	})
 }
 
 // validateInputs performs basic input validation
@@ -293,8 +318,14 @@ func (v *RemovalValidator) fetchIssueDependencies(ctx context.Context, ref Issue
 
 // relationshipExistsInData checks if a relationship exists in the dependency data
 func (v *RemovalValidator) relationshipExistsInData(data *DependencyData, target IssueRef, relType string) bool {
+	return v.findRelationInData(data, target, relType) != nil
+}
+
+// findRelationInData returns the relationship to target in the dependency data,
+// or nil when there is none
+func (v *RemovalValidator) findRelationInData(data *DependencyData, target IssueRef, relType string) *DependencyRelation {
 	if data == nil {
-		return false
+		return nil
 	}
 
 	var relationsToCheck []DependencyRelation
@@ -309,7 +340,7 @@ func (v *RemovalValidator) relationshipExistsInData(data *DependencyData, target
 	}
 
 	// Check if target exists in the relationship list
-	for _, relation := range relationsToCheck {
+	for i, relation := range relationsToCheck {
 		// Match by issue number and repository
 		if relation.Issue.Number == target.Number {
 			// Check repository match - handle both full name and individual owner/repo
@@ -320,12 +351,12 @@ func (v *RemovalValidator) relationshipExistsInData(data *DependencyData, target
 
 			if relation.Repository == targetRepo ||
 				relation.Issue.Repository.FullName == targetRepo {
-				return true
+				return &relationsToCheck[i]
 			}
 		}
 	}
 
-	return false
+	return nil
 }
 
 // createRelationshipNotFoundError creates a specific error for non-existent relationships
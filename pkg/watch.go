@@ -0,0 +1,269 @@
+// Package pkg provides polling for dependency changes.
+//
+// Watching an issue re-fetches its dependencies on an interval and reports
+// what changed: blockers closing or reopening, and relationships being added
+// or removed. Polls skip the dependency cache and revalidate the underlying
+// responses with conditional requests instead, so an unchanged issue costs no
+// rate limit.
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MinWatchInterval is the shortest polling interval accepted by watch
+const MinWatchInterval = 10 * time.Second
+
+// Watch event types
+const (
+	WatchEventClosed   = "closed"   // A blocker was closed
+	WatchEventReopened = "reopened" // A blocker was reopened
+	WatchEventAdded    = "added"    // A relationship was added
+	WatchEventRemoved  = "removed"  // A relationship was removed
+)
+
+// WatchEvent is one change between two polls of an issue's dependencies
+type WatchEvent struct {
+	Type     string // One of the WatchEvent constants
+	Relation string // "blocked_by" or "blocking"
+	Issue    string // owner/repo#number of the related issue
+	Title    string
+	State    string // State of the related issue after the change
+}
+
+// String describes the event, e.g. "Blocker org/app#45 closed: Set up database"
+func (e WatchEvent) String() string {
+	subject := "Blocker"
+	if e.Relation == "blocking" {
+		subject = "Blocked issue"
+	}
+
+	switch e.Type {
+	case WatchEventAdded:
+		return fmt.Sprintf("%s %s added (%s): %s", subject, e.Issue, e.State, e.Title)
+	case WatchEventRemoved:
+		return fmt.Sprintf("%s %s removed: %s", subject, e.Issue, e.Title)
+	default:
+		return fmt.Sprintf("%s %s %s: %s", subject, e.Issue, e.Type, e.Title)
+	}
+}
+
+// WatchOptions configures WatchIssueDependencies
+type WatchOptions struct {
+	Interval time.Duration // Time between polls; at least MinWatchInterval
+
+	// UntilUnblocked stops watching once the issue has no open blockers
+	UntilUnblocked bool
+
+	// OnUpdate is called with the first fetch, where events is nil, and after
+	// every poll that found changes. Returning an error stops watching.
+	OnUpdate func(events []WatchEvent, data *DependencyData) error
+
+	// OnError is called when a poll fails with a network or API error; watching
+	// continues with the next poll. Other errors stop watching.
+	OnError func(err error)
+}
+
+// WatchIssueDependencies polls the dependencies of an issue until ctx is
+// canceled or, with UntilUnblocked, the issue has no open blockers. With
+// UntilUnblocked, a canceled watch returns an error, since the issue may
+// still be blocked.
+func WatchIssueDependencies(ctx context.Context, owner, repo string, issueNumber int, opts WatchOptions) error {
+	if opts.Interval < MinWatchInterval {
+		return NewAppError(
+			ErrorTypeValidation,
+			fmt.Sprintf("Watch interval %s is too short", opts.Interval),
+			nil,
+		).WithSuggestion(fmt.Sprintf("Use an interval of at least %s", MinWatchInterval))
+	}
+
+	if err := SetupGitHubClient(); err != nil {
+		return err
+	}
+
+	client, err := newConditionalRESTClient()
+	if err != nil {
+		return WrapInternalError("creating GitHub API client", err)
+	}
+
+	cacheKey := getCacheKey(owner, repo, issueNumber)
+	fetch := func(ctx context.Context) (*DependencyData, error) {
+		data, err := fetchDependencyData(ctx, client, owner, repo, issueNumber)
+		if err == nil {
+			// Keep the cache current for other commands
+			saveToCache(cacheKey, data)
+		}
+		return data, err
+	}
+
+	return watchDependencies(ctx, fetch, opts)
+}
+
+// watchDependencies implements WatchIssueDependencies with an explicit fetch function
+func watchDependencies(ctx context.Context, fetch func(context.Context) (*DependencyData, error), opts WatchOptions) error {
+	previous, err := fetch(ctx)
+	if err != nil {
+		return err
+	}
+	if opts.OnUpdate != nil {
+		if err := opts.OnUpdate(nil, previous); err != nil {
+			return err
+		}
+	}
+
+	// stopped ends a canceled watch; gating scripts must not mistake it for
+	// the issue being unblocked
+	stopped := func() error {
+		if !opts.UntilUnblocked {
+			return nil
+		}
+		return NewAppError(
+			ErrorTypeIssue,
+			fmt.Sprintf("Stopped watching with %d open blockers", OpenBlockerCount(previous)),
+			ctx.Err(),
+		).WithSuggestion("Run the command again to keep waiting for the blockers to close")
+	}
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if opts.UntilUnblocked && OpenBlockerCount(previous) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return stopped()
+		case <-ticker.C:
+		}
+
+		current, err := fetch(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return stopped()
+			}
+			if opts.OnError != nil && (IsErrorType(err, ErrorTypeNetwork) || IsErrorType(err, ErrorTypeAPI)) {
+				opts.OnError(err)
+				continue
+			}
+			return err
+		}
+
+		events := DiffDependencies(previous, current)
+		previous = current
+		if len(events) > 0 && opts.OnUpdate != nil {
+			if err := opts.OnUpdate(events, current); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// OpenBlockerCount returns how many of the issue's blockers are open
+func OpenBlockerCount(data *DependencyData) int {
+	open := 0
+	for _, dep := range data.BlockedBy {
+		if strings.EqualFold(dep.Issue.State, "open") {
+			open++
+		}
+	}
+	return open
+}
+
+// DiffDependencies returns the changes from before to after: relationships
+// added or removed in either direction, and blockers that closed or reopened.
+// Events are ordered by relation, then by issue.
+func DiffDependencies(before, after *DependencyData) []WatchEvent {
+	var events []WatchEvent
+	events = append(events, diffRelations("blocked_by", before.BlockedBy, after.BlockedBy, true)...)
+	events = append(events, diffRelations("blocking", before.Blocking, after.Blocking, false)...)
+	return events
+}
+
+// diffRelations compares one relationship list; state changes are only
+// reported when trackState is set
+func diffRelations(relation string, before, after []DependencyRelation, trackState bool) []WatchEvent {
+	key := func(dep DependencyRelation) string { return hierarchyKey(dep.Repository, dep.Issue.Number) }
+	event := func(eventType string, dep DependencyRelation) WatchEvent {
+		return WatchEvent{
+			Type:     eventType,
+			Relation: relation,
+			Issue:    fmt.Sprintf("%s#%d", dep.Repository, dep.Issue.Number),
+			Title:    dep.Issue.Title,
+			State:    dep.Issue.State,
+		}
+	}
+
+	old := make(map[string]DependencyRelation)
+	for _, dep := range before {
+		old[key(dep)] = dep
+	}
+
+	var events []WatchEvent
+	seen := make(map[string]bool)
+	for _, dep := range after {
+		seen[key(dep)] = true
+		prior, existed := old[key(dep)]
+		switch {
+		case !existed:
+			events = append(events, event(WatchEventAdded, dep))
+		case trackState && !strings.EqualFold(prior.Issue.State, dep.Issue.State):
+			if strings.EqualFold(dep.Issue.State, "closed") {
+				events = append(events, event(WatchEventClosed, dep))
+			} else {
+				events = append(events, event(WatchEventReopened, dep))
+			}
+		}
+	}
+	for _, dep := range before {
+		if !seen[key(dep)] {
+			events = append(events, event(WatchEventRemoved, dep))
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Issue < events[j].Issue })
+	return events
+}
+
+// RunWatchCommand runs command through the shell for event, passing the event
+// in GH_ISSUE_DEPENDENCY_* environment variables. Output goes to the
+// terminal. The issue being watched is source, as owner/repo#number.
+func RunWatchCommand(ctx context.Context, command, source string, event WatchEvent) error {
+	cmd := shellCommand(ctx, command)
+	cmd.Env = append(os.Environ(),
+		"GH_ISSUE_DEPENDENCY_SOURCE="+source,
+		"GH_ISSUE_DEPENDENCY_EVENT="+event.Type,
+		"GH_ISSUE_DEPENDENCY_RELATION="+event.Relation,
+		"GH_ISSUE_DEPENDENCY_ISSUE="+event.Issue,
+		"GH_ISSUE_DEPENDENCY_TITLE="+event.Title,
+		"GH_ISSUE_DEPENDENCY_STATE="+event.State,
+		"GH_ISSUE_DEPENDENCY_MESSAGE="+event.String(),
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return NewAppError(
+			ErrorTypeInternal,
+			fmt.Sprintf("--exec command failed: %v", err),
+			err,
+		).WithContext("command", command)
+	}
+	return nil
+}
+
+// shellCommand runs command with the platform's shell
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command) // #nosec G204 -- the command is the user's own --exec value
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command) // #nosec G204 -- the command is the user's own --exec value
+}
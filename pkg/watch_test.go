@@ -0,0 +1,159 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// watchTestData returns the standard test data with the blocker states given
+// for #45 and #67
+func watchTestData(state45, state67 string) *DependencyData {
+	data := createTestDependencyData()
+	data.BlockedBy[0].Issue.State = state45
+	data.BlockedBy[1].Issue.State = state67
+	return data
+}
+
+func TestDiffDependencies(t *testing.T) {
+	t.Run("no changes", func(t *testing.T) {
+		assert.Empty(t, DiffDependencies(createTestDependencyData(), createTestDependencyData()))
+	})
+
+	t.Run("blocker closed and reopened", func(t *testing.T) {
+		events := DiffDependencies(watchTestData("open", "closed"), watchTestData("closed", "open"))
+		require.Len(t, events, 2)
+		assert.Equal(t, WatchEvent{Type: WatchEventClosed, Relation: "blocked_by", Issue: "testowner/testrepo#45",
+			Title: "Setup Database Schema", State: "closed"}, events[0])
+		assert.Equal(t, WatchEventReopened, events[1].Type)
+		assert.Equal(t, "Blocker testowner/testrepo#45 closed: Setup Database Schema", events[0].String())
+	})
+
+	t.Run("edges added and removed", func(t *testing.T) {
+		after := createTestDependencyData()
+		after.BlockedBy = after.BlockedBy[:1]
+		after.Blocking = append(after.Blocking, DependencyRelation{
+			Issue:      Issue{Number: 90, Title: "Docs", State: "open"},
+			Repository: "testowner/docs",
+		})
+		// A blocked issue changing state is not an event
+		after.Blocking[0].Issue.State = "closed"
+
+		events := DiffDependencies(createTestDependencyData(), after)
+		require.Len(t, events, 2)
+		assert.Equal(t, WatchEventRemoved, events[0].Type)
+		assert.Equal(t, "testowner/testrepo#67", events[0].Issue)
+		assert.Equal(t, WatchEventAdded, events[1].Type)
+		assert.Equal(t, "Blocked issue testowner/docs#90 added (open): Docs", events[1].String())
+	})
+}
+
+func TestWatchDependencies(t *testing.T) {
+	polls := []*DependencyData{
+		watchTestData("open", "closed"),
+		watchTestData("open", "closed"),
+		watchTestData("closed", "closed"),
+	}
+
+	fetches := 0
+	fetch := func(ctx context.Context) (*DependencyData, error) {
+		data := polls[min(fetches, len(polls)-1)]
+		fetches++
+		return data, nil
+	}
+
+	var updates [][]WatchEvent
+	err := watchDependencies(context.Background(), fetch, WatchOptions{
+		Interval:       time.Millisecond,
+		UntilUnblocked: true,
+		OnUpdate: func(events []WatchEvent, data *DependencyData) error {
+			updates = append(updates, events)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	// The first fetch, then only the poll with a change; the issue is then unblocked
+	assert.Equal(t, 3, fetches)
+	require.Len(t, updates, 2)
+	assert.Nil(t, updates[0])
+	require.Len(t, updates[1], 1)
+	assert.Equal(t, WatchEventClosed, updates[1][0].Type)
+}
+
+func TestWatchDependenciesErrors(t *testing.T) {
+	t.Run("transient errors are reported and retried", func(t *testing.T) {
+		fetches := 0
+		fetch := func(ctx context.Context) (*DependencyData, error) {
+			fetches++
+			switch fetches {
+			case 1:
+				return watchTestData("open", "closed"), nil
+			case 2:
+				return nil, WrapNetworkError(errors.New("connection reset"))
+			default:
+				return watchTestData("closed", "closed"), nil
+			}
+		}
+
+		var reported []error
+		err := watchDependencies(context.Background(), fetch, WatchOptions{
+			Interval:       time.Millisecond,
+			UntilUnblocked: true,
+			OnError:        func(err error) { reported = append(reported, err) },
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, fetches)
+		assert.Len(t, reported, 1)
+	})
+
+	t.Run("other errors stop watching", func(t *testing.T) {
+		fetches := 0
+		fetch := func(ctx context.Context) (*DependencyData, error) {
+			fetches++
+			if fetches == 2 {
+				return nil, WrapAuthError(errors.New("unauthorized"))
+			}
+			return watchTestData("open", "open"), nil
+		}
+
+		err := watchDependencies(context.Background(), fetch, WatchOptions{Interval: time.Millisecond, OnError: func(error) {}})
+		assert.True(t, IsErrorType(err, ErrorTypeAuthentication))
+	})
+
+	t.Run("canceled before unblocked", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		fetches := 0
+		fetch := func(ctx context.Context) (*DependencyData, error) {
+			fetches++
+			if fetches == 2 {
+				cancel()
+			}
+			return watchTestData("open", "closed"), nil
+		}
+
+		err := watchDependencies(ctx, fetch, WatchOptions{Interval: time.Millisecond, UntilUnblocked: true})
+		require.Error(t, err)
+		assert.True(t, IsErrorType(err, ErrorTypeIssue))
+		assert.Contains(t, err.Error(), "Stopped watching with 1 open blockers")
+	})
+
+	t.Run("canceled without until unblocked", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		fetch := func(ctx context.Context) (*DependencyData, error) {
+			return watchTestData("open", "closed"), nil
+		}
+
+		assert.NoError(t, watchDependencies(ctx, fetch, WatchOptions{Interval: time.Millisecond}))
+	})
+
+	t.Run("interval too short", func(t *testing.T) {
+		err := WatchIssueDependencies(context.Background(), "owner", "repo", 1, WatchOptions{Interval: time.Second})
+		assert.True(t, IsErrorType(err, ErrorTypeValidation))
+	})
+}